@@ -49,6 +49,12 @@ type ContainerResponse = os.ContainerResponse
 // ContainersReply from public import os/os.proto
 type ContainersReply = os.ContainersReply
 
+// DmesgRequest from public import os/os.proto
+type DmesgRequest = os.DmesgRequest
+
+// KernelMessage from public import os/os.proto
+type KernelMessage = os.KernelMessage
+
 // ProcessesRequest from public import os/os.proto
 type ProcessesRequest = os.ProcessesRequest
 
@@ -97,12 +103,21 @@ type RebootResponse = machine.RebootResponse
 // RebootReply from public import machine/machine.proto
 type RebootReply = machine.RebootReply
 
+// ResetRequest from public import machine/machine.proto
+type ResetRequest = machine.ResetRequest
+
 // ResetResponse from public import machine/machine.proto
 type ResetResponse = machine.ResetResponse
 
 // ResetReply from public import machine/machine.proto
 type ResetReply = machine.ResetReply
 
+// RollbackResponse from public import machine/machine.proto
+type RollbackResponse = machine.RollbackResponse
+
+// RollbackReply from public import machine/machine.proto
+type RollbackReply = machine.RollbackReply
+
 // ShutdownResponse from public import machine/machine.proto
 type ShutdownResponse = machine.ShutdownResponse
 
@@ -211,6 +226,49 @@ type PlatformInfo = machine.PlatformInfo
 // LogsRequest from public import machine/machine.proto
 type LogsRequest = machine.LogsRequest
 
+// EventsRequest from public import machine/machine.proto
+type EventsRequest = machine.EventsRequest
+
+// Event from public import machine/machine.proto
+type Event = machine.Event
+
+// SequenceEvent from public import machine/machine.proto
+type SequenceEvent = machine.SequenceEvent
+
+// PhaseEvent from public import machine/machine.proto
+type PhaseEvent = machine.PhaseEvent
+
+// ServiceStateEvent from public import machine/machine.proto
+type ServiceStateEvent = machine.ServiceStateEvent
+
+// ResetScope from public import machine/machine.proto
+type ResetScope = machine.ResetScope
+
+var ResetScope_name = machine.ResetScope_name
+var ResetScope_value = machine.ResetScope_value
+
+const ResetScope_EPHEMERAL = ResetScope(machine.ResetScope_EPHEMERAL)
+const ResetScope_SYSTEM_DISK = ResetScope(machine.ResetScope_SYSTEM_DISK)
+
+// EventType from public import machine/machine.proto
+type EventType = machine.EventType
+
+var EventType_name = machine.EventType_name
+var EventType_value = machine.EventType_value
+
+const EventType_SEQUENCE = EventType(machine.EventType_SEQUENCE)
+const EventType_PHASE = EventType(machine.EventType_PHASE)
+const EventType_SERVICE = EventType(machine.EventType_SERVICE)
+
+// EventAction from public import machine/machine.proto
+type EventAction = machine.EventAction
+
+var EventAction_name = machine.EventAction_name
+var EventAction_value = machine.EventAction_value
+
+const EventAction_START = EventAction(machine.EventAction_START)
+const EventAction_STOP = EventAction(machine.EventAction_STOP)
+
 // TimeRequest from public import time/time.proto
 type TimeRequest = time.TimeRequest
 
@@ -220,6 +278,9 @@ type TimeReply = time.TimeReply
 // TimeResponse from public import time/time.proto
 type TimeResponse = time.TimeResponse
 
+// TimeSource from public import time/time.proto
+type TimeSource = time.TimeSource
+
 // RoutesReply from public import network/network.proto
 type RoutesReply = network.RoutesReply
 
@@ -238,6 +299,42 @@ type InterfacesResponse = network.InterfacesResponse
 // Interface from public import network/network.proto
 type Interface = network.Interface
 
+// InterfaceAddress from public import network/network.proto
+type InterfaceAddress = network.InterfaceAddress
+
+// LeasesReply from public import network/network.proto
+type LeasesReply = network.LeasesReply
+
+// LeasesResponse from public import network/network.proto
+type LeasesResponse = network.LeasesResponse
+
+// Lease from public import network/network.proto
+type Lease = network.Lease
+
+// LeaseEvent from public import network/network.proto
+type LeaseEvent = network.LeaseEvent
+
+// ApplyRequest from public import network/network.proto
+type ApplyRequest = network.ApplyRequest
+
+// ApplyReply from public import network/network.proto
+type ApplyReply = network.ApplyReply
+
+// ApplyResponse from public import network/network.proto
+type ApplyResponse = network.ApplyResponse
+
+// ConfirmReply from public import network/network.proto
+type ConfirmReply = network.ConfirmReply
+
+// ConfirmResponse from public import network/network.proto
+type ConfirmResponse = network.ConfirmResponse
+
+// WatchRequest from public import network/network.proto
+type WatchRequest = network.WatchRequest
+
+// WatchEvent from public import network/network.proto
+type WatchEvent = network.WatchEvent
+
 // AddressFamily from public import network/network.proto
 type AddressFamily = network.AddressFamily
 
@@ -286,6 +383,53 @@ const InterfaceFlags_FLAG_LOOPBACK = InterfaceFlags(network.InterfaceFlags_FLAG_
 const InterfaceFlags_FLAG_POINT_TO_POINT = InterfaceFlags(network.InterfaceFlags_FLAG_POINT_TO_POINT)
 const InterfaceFlags_FLAG_MULTICAST = InterfaceFlags(network.InterfaceFlags_FLAG_MULTICAST)
 
+// OperState from public import network/network.proto
+type OperState = network.OperState
+
+var OperState_name = network.OperState_name
+var OperState_value = network.OperState_value
+
+const OperState_OPER_UNKNOWN = OperState(network.OperState_OPER_UNKNOWN)
+const OperState_OPER_NOT_PRESENT = OperState(network.OperState_OPER_NOT_PRESENT)
+const OperState_OPER_DOWN = OperState(network.OperState_OPER_DOWN)
+const OperState_OPER_LOWER_LAYER_DOWN = OperState(network.OperState_OPER_LOWER_LAYER_DOWN)
+const OperState_OPER_TESTING = OperState(network.OperState_OPER_TESTING)
+const OperState_OPER_DORMANT = OperState(network.OperState_OPER_DORMANT)
+const OperState_OPER_UP = OperState(network.OperState_OPER_UP)
+
+// LeaseState from public import network/network.proto
+type LeaseState = network.LeaseState
+
+var LeaseState_name = network.LeaseState_name
+var LeaseState_value = network.LeaseState_value
+
+const LeaseState_LEASE_INIT = LeaseState(network.LeaseState_LEASE_INIT)
+const LeaseState_LEASE_SELECTING = LeaseState(network.LeaseState_LEASE_SELECTING)
+const LeaseState_LEASE_REQUESTING = LeaseState(network.LeaseState_LEASE_REQUESTING)
+const LeaseState_LEASE_BOUND = LeaseState(network.LeaseState_LEASE_BOUND)
+const LeaseState_LEASE_RENEWING = LeaseState(network.LeaseState_LEASE_RENEWING)
+const LeaseState_LEASE_REBINDING = LeaseState(network.LeaseState_LEASE_REBINDING)
+const LeaseState_LEASE_RELEASED = LeaseState(network.LeaseState_LEASE_RELEASED)
+
+// WatchType from public import network/network.proto
+type WatchType = network.WatchType
+
+var WatchType_name = network.WatchType_name
+var WatchType_value = network.WatchType_value
+
+const WatchType_LINK = WatchType(network.WatchType_LINK)
+const WatchType_ADDRESS = WatchType(network.WatchType_ADDRESS)
+const WatchType_ROUTE = WatchType(network.WatchType_ROUTE)
+
+// WatchAction from public import network/network.proto
+type WatchAction = network.WatchAction
+
+var WatchAction_name = network.WatchAction_name
+var WatchAction_value = network.WatchAction_value
+
+const WatchAction_UPDATE = WatchAction(network.WatchAction_UPDATE)
+const WatchAction_DELETE = WatchAction(network.WatchAction_DELETE)
+
 // NodeMetadata from public import common/common.proto
 type NodeMetadata = common.NodeMetadata
 
@@ -485,6 +629,18 @@ func (p *ApiProxy) UnaryProxy(ctx context.Context, method string, creds credenti
 			resp.Response = append(resp.Response, msg.(*machine.ResetReply).Response[0])
 		}
 		response = resp
+	case "/machine.Machine/Rollback":
+		// Initialize target clients
+		clients, err := createMachineClient(targets, creds, proxyMd)
+		if err != nil {
+			break
+		}
+		resp := &machine.RollbackReply{}
+		msgs, err = proxyMachineRunner(clients, in, proxyRollback)
+		for _, msg := range msgs {
+			resp.Response = append(resp.Response, msg.(*machine.RollbackReply).Response[0])
+		}
+		response = resp
 	case "/machine.Machine/ServiceList":
 		// Initialize target clients
 		clients, err := createMachineClient(targets, creds, proxyMd)
@@ -617,6 +773,42 @@ func (p *ApiProxy) UnaryProxy(ctx context.Context, method string, creds credenti
 			resp.Response = append(resp.Response, msg.(*network.InterfacesReply).Response[0])
 		}
 		response = resp
+	case "/network.Network/Leases":
+		// Initialize target clients
+		clients, err := createNetworkClient(targets, creds, proxyMd)
+		if err != nil {
+			break
+		}
+		resp := &network.LeasesReply{}
+		msgs, err = proxyNetworkRunner(clients, in, proxyLeases)
+		for _, msg := range msgs {
+			resp.Response = append(resp.Response, msg.(*network.LeasesReply).Response[0])
+		}
+		response = resp
+	case "/network.Network/Apply":
+		// Initialize target clients
+		clients, err := createNetworkClient(targets, creds, proxyMd)
+		if err != nil {
+			break
+		}
+		resp := &network.ApplyReply{}
+		msgs, err = proxyNetworkRunner(clients, in, proxyApply)
+		for _, msg := range msgs {
+			resp.Response = append(resp.Response, msg.(*network.ApplyReply).Response[0])
+		}
+		response = resp
+	case "/network.Network/Confirm":
+		// Initialize target clients
+		clients, err := createNetworkClient(targets, creds, proxyMd)
+		if err != nil {
+			break
+		}
+		resp := &network.ConfirmReply{}
+		msgs, err = proxyNetworkRunner(clients, in, proxyConfirm)
+		for _, msg := range msgs {
+			resp.Response = append(resp.Response, msg.(*network.ConfirmReply).Response[0])
+		}
+		response = resp
 
 	}
 
@@ -692,6 +884,24 @@ func (p *ApiProxy) StreamProxy(ss grpc.ServerStream, method string, creds creden
 	proxyMd.Set("proxyfrom", md[":authority"]...)
 
 	switch method {
+	case "/os.OS/DmesgStream":
+		// Initialize target clients
+		clients, err := createOSClient(targets, creds, proxyMd)
+		if err != nil {
+			break
+		}
+		m := new(os.DmesgRequest)
+		if err := ss.RecvMsg(m); err != nil {
+			return err
+		}
+		// artificially limit this to only the first client/target until
+		// we get multi-stream stuff sorted
+		clientStream, err := clients[0].Conn.DmesgStream(clients[0].Context, m)
+		if err != nil {
+			return err
+		}
+		var msg os.KernelMessage
+		return copyClientServer(&msg, clientStream, ss.(grpc.ServerStream))
 	case "/machine.Machine/CopyOut":
 		// Initialize target clients
 		clients, err := createMachineClient(targets, creds, proxyMd)
@@ -710,6 +920,24 @@ func (p *ApiProxy) StreamProxy(ss grpc.ServerStream, method string, creds creden
 		}
 		var msg machine.StreamingData
 		return copyClientServer(&msg, clientStream, ss.(grpc.ServerStream))
+	case "/machine.Machine/Events":
+		// Initialize target clients
+		clients, err := createMachineClient(targets, creds, proxyMd)
+		if err != nil {
+			break
+		}
+		m := new(machine.EventsRequest)
+		if err := ss.RecvMsg(m); err != nil {
+			return err
+		}
+		// artificially limit this to only the first client/target until
+		// we get multi-stream stuff sorted
+		clientStream, err := clients[0].Conn.Events(clients[0].Context, m)
+		if err != nil {
+			return err
+		}
+		var msg machine.Event
+		return copyClientServer(&msg, clientStream, ss.(grpc.ServerStream))
 	case "/machine.Machine/Kubeconfig":
 		// Initialize target clients
 		clients, err := createMachineClient(targets, creds, proxyMd)
@@ -764,6 +992,24 @@ func (p *ApiProxy) StreamProxy(ss grpc.ServerStream, method string, creds creden
 		}
 		var msg common.Data
 		return copyClientServer(&msg, clientStream, ss.(grpc.ServerStream))
+	case "/network.Network/Watch":
+		// Initialize target clients
+		clients, err := createNetworkClient(targets, creds, proxyMd)
+		if err != nil {
+			break
+		}
+		m := new(network.WatchRequest)
+		if err := ss.RecvMsg(m); err != nil {
+			return err
+		}
+		// artificially limit this to only the first client/target until
+		// we get multi-stream stuff sorted
+		clientStream, err := clients[0].Conn.Watch(clients[0].Context, m)
+		if err != nil {
+			return err
+		}
+		var msg network.WatchEvent
+		return copyClientServer(&msg, clientStream, ss.(grpc.ServerStream))
 
 	}
 
@@ -931,7 +1177,18 @@ func proxyReboot(client *proxyMachineClient, in interface{}, wg *sync.WaitGroup,
 
 func proxyReset(client *proxyMachineClient, in interface{}, wg *sync.WaitGroup, respCh chan proto.Message, errCh chan error) {
 	defer wg.Done()
-	resp, err := client.Conn.Reset(client.Context, in.(*empty.Empty))
+	resp, err := client.Conn.Reset(client.Context, in.(*machine.ResetRequest))
+	if err != nil {
+		errCh <- err
+		return
+	}
+	resp.Response[0].Metadata = &NodeMetadata{Hostname: client.Target}
+	respCh <- resp
+}
+
+func proxyRollback(client *proxyMachineClient, in interface{}, wg *sync.WaitGroup, respCh chan proto.Message, errCh chan error) {
+	defer wg.Done()
+	resp, err := client.Conn.Rollback(client.Context, in.(*empty.Empty))
 	if err != nil {
 		errCh <- err
 		return
@@ -1129,6 +1386,39 @@ func proxyInterfaces(client *proxyNetworkClient, in interface{}, wg *sync.WaitGr
 	respCh <- resp
 }
 
+func proxyLeases(client *proxyNetworkClient, in interface{}, wg *sync.WaitGroup, respCh chan proto.Message, errCh chan error) {
+	defer wg.Done()
+	resp, err := client.Conn.Leases(client.Context, in.(*empty.Empty))
+	if err != nil {
+		errCh <- err
+		return
+	}
+	resp.Response[0].Metadata = &NodeMetadata{Hostname: client.Target}
+	respCh <- resp
+}
+
+func proxyApply(client *proxyNetworkClient, in interface{}, wg *sync.WaitGroup, respCh chan proto.Message, errCh chan error) {
+	defer wg.Done()
+	resp, err := client.Conn.Apply(client.Context, in.(*network.ApplyRequest))
+	if err != nil {
+		errCh <- err
+		return
+	}
+	resp.Response[0].Metadata = &NodeMetadata{Hostname: client.Target}
+	respCh <- resp
+}
+
+func proxyConfirm(client *proxyNetworkClient, in interface{}, wg *sync.WaitGroup, respCh chan proto.Message, errCh chan error) {
+	defer wg.Done()
+	resp, err := client.Conn.Confirm(client.Context, in.(*empty.Empty))
+	if err != nil {
+		errCh <- err
+		return
+	}
+	resp.Response[0].Metadata = &NodeMetadata{Hostname: client.Target}
+	respCh <- resp
+}
+
 func createOSClient(targets []string, creds credentials.TransportCredentials, proxyMd metadata.MD) ([]*proxyOSClient, error) {
 	var errors *go_multierror.Error
 	clients := make([]*proxyOSClient, 0, len(targets))
@@ -1247,6 +1537,15 @@ func (r *Registrator) Dmesg(ctx context.Context, in *empty.Empty) (*common.DataR
 	return r.OSClient.Dmesg(ctx, in)
 }
 
+func (r *Registrator) DmesgStream(in *os.DmesgRequest, srv os.OS_DmesgStreamServer) error {
+	client, err := r.OSClient.DmesgStream(srv.Context(), in)
+	if err != nil {
+		return err
+	}
+	var msg os.KernelMessage
+	return copyClientServer(&msg, client, srv)
+}
+
 func (r *Registrator) Memory(ctx context.Context, in *empty.Empty) (*os.MemInfoReply, error) {
 	return r.OSClient.Memory(ctx, in)
 }
@@ -1272,6 +1571,15 @@ func (r *Registrator) CopyOut(in *machine.CopyOutRequest, srv machine.Machine_Co
 	return copyClientServer(&msg, client, srv)
 }
 
+func (r *Registrator) Events(in *machine.EventsRequest, srv machine.Machine_EventsServer) error {
+	client, err := r.MachineClient.Events(srv.Context(), in)
+	if err != nil {
+		return err
+	}
+	var msg machine.Event
+	return copyClientServer(&msg, client, srv)
+}
+
 func (r *Registrator) Kubeconfig(in *empty.Empty, srv machine.Machine_KubeconfigServer) error {
 	client, err := r.MachineClient.Kubeconfig(srv.Context(), in)
 	if err != nil {
@@ -1307,10 +1615,14 @@ func (r *Registrator) Reboot(ctx context.Context, in *empty.Empty) (*machine.Reb
 	return r.MachineClient.Reboot(ctx, in)
 }
 
-func (r *Registrator) Reset(ctx context.Context, in *empty.Empty) (*machine.ResetReply, error) {
+func (r *Registrator) Reset(ctx context.Context, in *machine.ResetRequest) (*machine.ResetReply, error) {
 	return r.MachineClient.Reset(ctx, in)
 }
 
+func (r *Registrator) Rollback(ctx context.Context, in *empty.Empty) (*machine.RollbackReply, error) {
+	return r.MachineClient.Rollback(ctx, in)
+}
+
 func (r *Registrator) ServiceList(ctx context.Context, in *empty.Empty) (*machine.ServiceListReply, error) {
 	return r.MachineClient.ServiceList(ctx, in)
 }
@@ -1363,6 +1675,27 @@ func (r *Registrator) Interfaces(ctx context.Context, in *empty.Empty) (*network
 	return r.NetworkClient.Interfaces(ctx, in)
 }
 
+func (r *Registrator) Leases(ctx context.Context, in *empty.Empty) (*network.LeasesReply, error) {
+	return r.NetworkClient.Leases(ctx, in)
+}
+
+func (r *Registrator) Apply(ctx context.Context, in *network.ApplyRequest) (*network.ApplyReply, error) {
+	return r.NetworkClient.Apply(ctx, in)
+}
+
+func (r *Registrator) Confirm(ctx context.Context, in *empty.Empty) (*network.ConfirmReply, error) {
+	return r.NetworkClient.Confirm(ctx, in)
+}
+
+func (r *Registrator) Watch(in *network.WatchRequest, srv network.Network_WatchServer) error {
+	client, err := r.NetworkClient.Watch(srv.Context(), in)
+	if err != nil {
+		return err
+	}
+	var msg network.WatchEvent
+	return copyClientServer(&msg, client, srv)
+}
+
 type LocalOSClient struct {
 	os.OSClient
 }
@@ -1387,6 +1720,10 @@ func (c *LocalOSClient) Dmesg(ctx context.Context, in *empty.Empty, opts ...grpc
 	return c.OSClient.Dmesg(ctx, in, opts...)
 }
 
+func (c *LocalOSClient) DmesgStream(ctx context.Context, in *os.DmesgRequest, opts ...grpc.CallOption) (os.OS_DmesgStreamClient, error) {
+	return c.OSClient.DmesgStream(ctx, in, opts...)
+}
+
 func (c *LocalOSClient) Memory(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*os.MemInfoReply, error) {
 	return c.OSClient.Memory(ctx, in, opts...)
 }
@@ -1423,6 +1760,10 @@ func (c *LocalMachineClient) CopyOut(ctx context.Context, in *machine.CopyOutReq
 	return c.MachineClient.CopyOut(ctx, in, opts...)
 }
 
+func (c *LocalMachineClient) Events(ctx context.Context, in *machine.EventsRequest, opts ...grpc.CallOption) (machine.Machine_EventsClient, error) {
+	return c.MachineClient.Events(ctx, in, opts...)
+}
+
 func (c *LocalMachineClient) Kubeconfig(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (machine.Machine_KubeconfigClient, error) {
 	return c.MachineClient.Kubeconfig(ctx, in, opts...)
 }
@@ -1443,10 +1784,14 @@ func (c *LocalMachineClient) Reboot(ctx context.Context, in *empty.Empty, opts .
 	return c.MachineClient.Reboot(ctx, in, opts...)
 }
 
-func (c *LocalMachineClient) Reset(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*machine.ResetReply, error) {
+func (c *LocalMachineClient) Reset(ctx context.Context, in *machine.ResetRequest, opts ...grpc.CallOption) (*machine.ResetReply, error) {
 	return c.MachineClient.Reset(ctx, in, opts...)
 }
 
+func (c *LocalMachineClient) Rollback(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*machine.RollbackReply, error) {
+	return c.MachineClient.Rollback(ctx, in, opts...)
+}
+
 func (c *LocalMachineClient) ServiceList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*machine.ServiceListReply, error) {
 	return c.MachineClient.ServiceList(ctx, in, opts...)
 }
@@ -1530,3 +1875,19 @@ func (c *LocalNetworkClient) Routes(ctx context.Context, in *empty.Empty, opts .
 func (c *LocalNetworkClient) Interfaces(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*network.InterfacesReply, error) {
 	return c.NetworkClient.Interfaces(ctx, in, opts...)
 }
+
+func (c *LocalNetworkClient) Leases(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*network.LeasesReply, error) {
+	return c.NetworkClient.Leases(ctx, in, opts...)
+}
+
+func (c *LocalNetworkClient) Apply(ctx context.Context, in *network.ApplyRequest, opts ...grpc.CallOption) (*network.ApplyReply, error) {
+	return c.NetworkClient.Apply(ctx, in, opts...)
+}
+
+func (c *LocalNetworkClient) Confirm(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*network.ConfirmReply, error) {
+	return c.NetworkClient.Confirm(ctx, in, opts...)
+}
+
+func (c *LocalNetworkClient) Watch(ctx context.Context, in *network.WatchRequest, opts ...grpc.CallOption) (network.Network_WatchClient, error) {
+	return c.NetworkClient.Watch(ctx, in, opts...)
+}
@@ -48,7 +48,11 @@ func (ContainerDriver) EnumDescriptor() ([]byte, []int) {
 
 // Common metadata message nested in all reply message types
 type NodeMetadata struct {
-	Hostname             string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// Error is set when the node failed to serve a streaming request, so that
+	// per-node failures can be reported in-band when fanning out to several
+	// targets.
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -90,12 +94,22 @@ func (m *NodeMetadata) GetHostname() string {
 	return ""
 }
 
+func (m *NodeMetadata) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 // The response message containing the requested logs.
 type Data struct {
-	Bytes                []byte   `protobuf:"bytes,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Bytes []byte `protobuf:"bytes,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Metadata identifies the node which produced the data when streaming from
+	// several targets.
+	Metadata             *NodeMetadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *Data) Reset()         { *m = Data{} }
@@ -134,6 +148,13 @@ func (m *Data) GetBytes() []byte {
 	return nil
 }
 
+func (m *Data) GetMetadata() *NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type DataResponse struct {
 	Metadata             *NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Bytes                *Data         `protobuf:"bytes,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
@@ -239,21 +260,22 @@ func init() {
 func init() { proto.RegisterFile("common/common.proto", fileDescriptor_8f954d82c0b891f6) }
 
 var fileDescriptor_8f954d82c0b891f6 = []byte{
-	// 254 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xc1, 0x4b, 0xf3, 0x30,
-	0x18, 0xc6, 0xbf, 0x7e, 0xd3, 0xb9, 0xbd, 0x16, 0x1d, 0x71, 0x87, 0x21, 0x1e, 0x46, 0x4f, 0x5a,
-	0x71, 0x15, 0x3d, 0x7b, 0xd0, 0xd6, 0xc3, 0x0e, 0x56, 0x08, 0x9e, 0xbc, 0xa5, 0x36, 0xb8, 0xc2,
-	0x92, 0xb7, 0x24, 0xaf, 0x42, 0xff, 0x7b, 0x69, 0x92, 0x95, 0x9e, 0xc2, 0x43, 0x7e, 0x3c, 0xbf,
-	0x97, 0x07, 0x2e, 0xbe, 0x50, 0x29, 0xd4, 0x99, 0x7f, 0x36, 0xad, 0x41, 0x42, 0x36, 0xf5, 0x29,
-	0x49, 0x21, 0x2e, 0xb1, 0x96, 0x6f, 0x92, 0x44, 0x2d, 0x48, 0xb0, 0x4b, 0x98, 0xed, 0xd0, 0x92,
-	0x16, 0x4a, 0xae, 0xa2, 0x75, 0x74, 0x3d, 0xe7, 0x43, 0x4e, 0xae, 0xe0, 0xa8, 0xe8, 0x99, 0x25,
-	0x1c, 0x57, 0x1d, 0x49, 0xeb, 0x80, 0x98, 0xfb, 0x90, 0xd4, 0x10, 0xf7, 0xbf, 0x5c, 0xda, 0x16,
-	0xb5, 0x95, 0xec, 0x1e, 0x66, 0x2a, 0xb4, 0x3a, 0xf0, 0xf4, 0x61, 0xb9, 0x09, 0x27, 0x8c, 0x8d,
-	0x7c, 0xa0, 0x58, 0x72, 0xe8, 0xfd, 0xef, 0xf0, 0xf8, 0x80, 0xbb, 0xda, 0x60, 0x79, 0x82, 0xb9,
-	0xb7, 0xb4, 0xfb, 0xae, 0x57, 0x98, 0xa0, 0x5b, 0x45, 0xeb, 0xc9, 0x58, 0x31, 0x3e, 0x85, 0x0f,
-	0x54, 0x9a, 0xc2, 0x79, 0x8e, 0x9a, 0x44, 0xa3, 0xa5, 0x29, 0x4c, 0xf3, 0x2b, 0x0d, 0x3b, 0x03,
-	0xc8, 0xdf, 0xcb, 0x8f, 0xe7, 0x6d, 0xf9, 0xca, 0x8b, 0xc5, 0x3f, 0x76, 0x02, 0x93, 0x9c, 0x6f,
-	0x17, 0xd1, 0xcb, 0xed, 0xe7, 0xcd, 0x77, 0x43, 0xbb, 0x9f, 0xaa, 0xef, 0xcc, 0x48, 0xec, 0xd1,
-	0xde, 0xd9, 0xce, 0x92, 0x54, 0xd6, 0xa7, 0x4c, 0xb4, 0x4d, 0x58, 0xb5, 0x9a, 0xba, 0x59, 0x1f,
-	0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x4c, 0x3c, 0xf1, 0xf3, 0x6d, 0x01, 0x00, 0x00,
+	// 267 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xc1, 0x4b, 0xc3, 0x30,
+	0x14, 0xc6, 0xad, 0x73, 0x73, 0x7b, 0x16, 0x1d, 0x71, 0x87, 0xe2, 0x69, 0xf4, 0xa4, 0x13, 0x57,
+	0xd1, 0xb3, 0xa0, 0xb6, 0x1e, 0x76, 0xb0, 0x42, 0xf0, 0xe4, 0x2d, 0xb5, 0x0f, 0x57, 0x58, 0xf2,
+	0x4a, 0x12, 0x85, 0xfe, 0xf7, 0xd2, 0xa4, 0x2b, 0xf5, 0xe6, 0x29, 0x7c, 0xe4, 0xbd, 0xdf, 0xef,
+	0x83, 0x07, 0xe7, 0x9f, 0x24, 0x25, 0xa9, 0xc4, 0x3f, 0xeb, 0x5a, 0x93, 0x25, 0x36, 0xf1, 0x29,
+	0x7e, 0x84, 0x30, 0xa7, 0x12, 0x5f, 0xd1, 0x8a, 0x52, 0x58, 0xc1, 0x2e, 0x60, 0xba, 0x25, 0x63,
+	0x95, 0x90, 0x18, 0x05, 0xcb, 0xe0, 0x72, 0xc6, 0xfb, 0xcc, 0x16, 0x30, 0x46, 0xad, 0x49, 0x47,
+	0x87, 0xee, 0xc3, 0x87, 0x38, 0x87, 0xa3, 0xac, 0xdd, 0x5c, 0xc0, 0xb8, 0x68, 0x2c, 0x1a, 0xb7,
+	0x16, 0x72, 0x1f, 0xd8, 0x2d, 0x4c, 0x65, 0xc7, 0x76, 0x6b, 0x27, 0x77, 0x8b, 0x75, 0x57, 0x64,
+	0xe8, 0xe5, 0xfd, 0x54, 0x5c, 0x42, 0xd8, 0xf2, 0x38, 0x9a, 0x9a, 0x94, 0xc1, 0x3f, 0x84, 0xe0,
+	0x3f, 0x04, 0x16, 0xef, 0x9b, 0x78, 0x61, 0xb8, 0x1f, 0x77, 0x58, 0xff, 0x15, 0x3f, 0xc0, 0xcc,
+	0x5b, 0xea, 0x5d, 0xd3, 0x2a, 0x74, 0xa7, 0x8b, 0x82, 0xe5, 0x68, 0xa8, 0x18, 0x56, 0xe1, 0xfd,
+	0xd4, 0x6a, 0x05, 0x67, 0x29, 0x29, 0x2b, 0x2a, 0x85, 0x3a, 0xd3, 0xd5, 0x0f, 0x6a, 0x76, 0x0a,
+	0x90, 0xbe, 0xe5, 0xef, 0x4f, 0x9b, 0xfc, 0x85, 0x67, 0xf3, 0x03, 0x76, 0x0c, 0xa3, 0x94, 0x6f,
+	0xe6, 0xc1, 0xf3, 0xf5, 0xc7, 0xd5, 0x57, 0x65, 0xb7, 0xdf, 0x45, 0xcb, 0x4c, 0xac, 0xd8, 0x91,
+	0xb9, 0x31, 0x8d, 0xb1, 0x28, 0x8d, 0x4f, 0x89, 0xa8, 0xab, 0xee, 0x3a, 0xc5, 0xc4, 0x9d, 0xe7,
+	0xfe, 0x77, 0x00, 0x5e, 0x5a, 0x76, 0x92, 0xb5, 0x01, 0x00, 0x00,
 }
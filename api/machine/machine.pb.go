@@ -27,6 +27,87 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// rpc reset
+type ResetScope int32
+
+const (
+	// EPHEMERAL wipes the ephemeral partition, keeping the installation.
+	ResetScope_EPHEMERAL ResetScope = 0
+	// SYSTEM_DISK wipes the whole system disk.
+	ResetScope_SYSTEM_DISK ResetScope = 1
+)
+
+var ResetScope_name = map[int32]string{
+	0: "EPHEMERAL",
+	1: "SYSTEM_DISK",
+}
+
+var ResetScope_value = map[string]int32{
+	"EPHEMERAL":   0,
+	"SYSTEM_DISK": 1,
+}
+
+func (x ResetScope) String() string {
+	return proto.EnumName(ResetScope_name, int32(x))
+}
+
+func (ResetScope) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{0}
+}
+
+type EventType int32
+
+const (
+	EventType_SEQUENCE EventType = 0
+	EventType_PHASE    EventType = 1
+	EventType_SERVICE  EventType = 2
+)
+
+var EventType_name = map[int32]string{
+	0: "SEQUENCE",
+	1: "PHASE",
+	2: "SERVICE",
+}
+
+var EventType_value = map[string]int32{
+	"SEQUENCE": 0,
+	"PHASE":    1,
+	"SERVICE":  2,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{1}
+}
+
+type EventAction int32
+
+const (
+	EventAction_START EventAction = 0
+	EventAction_STOP  EventAction = 1
+)
+
+var EventAction_name = map[int32]string{
+	0: "START",
+	1: "STOP",
+}
+
+var EventAction_value = map[string]int32{
+	"START": 0,
+	"STOP":  1,
+}
+
+func (x EventAction) String() string {
+	return proto.EnumName(EventAction_name, int32(x))
+}
+
+func (EventAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{2}
+}
+
 // rpc reboot
 // The response message containing the reboot status.
 type RebootResponse struct {
@@ -115,7 +196,68 @@ func (m *RebootReply) GetResponse() []*RebootResponse {
 	return nil
 }
 
-// rpc reset
+type ResetRequest struct {
+	// force skips cordoning and draining the node and leaving etcd, the node
+	// is wiped even if the etcd cluster can't spare it.
+	Force bool `protobuf:"varint,1,opt,name=force,proto3" json:"force,omitempty"`
+	// reboot the node once the reset is done, it is powered off otherwise.
+	Reboot               bool       `protobuf:"varint,2,opt,name=reboot,proto3" json:"reboot,omitempty"`
+	Scope                ResetScope `protobuf:"varint,3,opt,name=scope,proto3,enum=machine.ResetScope" json:"scope,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ResetRequest) Reset()         { *m = ResetRequest{} }
+func (m *ResetRequest) String() string { return proto.CompactTextString(m) }
+func (*ResetRequest) ProtoMessage()    {}
+func (*ResetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{2}
+}
+
+func (m *ResetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetRequest.Unmarshal(m, b)
+}
+
+func (m *ResetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetRequest.Marshal(b, m, deterministic)
+}
+
+func (m *ResetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetRequest.Merge(m, src)
+}
+
+func (m *ResetRequest) XXX_Size() int {
+	return xxx_messageInfo_ResetRequest.Size(m)
+}
+
+func (m *ResetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetRequest proto.InternalMessageInfo
+
+func (m *ResetRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *ResetRequest) GetReboot() bool {
+	if m != nil {
+		return m.Reboot
+	}
+	return false
+}
+
+func (m *ResetRequest) GetScope() ResetScope {
+	if m != nil {
+		return m.Scope
+	}
+	return ResetScope_EPHEMERAL
+}
+
 // The response message containing the restart status.
 type ResetResponse struct {
 	Metadata             *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
@@ -128,7 +270,7 @@ func (m *ResetResponse) Reset()         { *m = ResetResponse{} }
 func (m *ResetResponse) String() string { return proto.CompactTextString(m) }
 func (*ResetResponse) ProtoMessage()    {}
 func (*ResetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{2}
+	return fileDescriptor_84b4f59d98cc997c, []int{3}
 }
 
 func (m *ResetResponse) XXX_Unmarshal(b []byte) error {
@@ -171,7 +313,7 @@ func (m *ResetReply) Reset()         { *m = ResetReply{} }
 func (m *ResetReply) String() string { return proto.CompactTextString(m) }
 func (*ResetReply) ProtoMessage()    {}
 func (*ResetReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{3}
+	return fileDescriptor_84b4f59d98cc997c, []int{4}
 }
 
 func (m *ResetReply) XXX_Unmarshal(b []byte) error {
@@ -203,6 +345,94 @@ func (m *ResetReply) GetResponse() []*ResetResponse {
 	return nil
 }
 
+// rpc rollback
+// The response message containing the rollback status.
+type RollbackResponse struct {
+	Metadata             *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RollbackResponse) Reset()         { *m = RollbackResponse{} }
+func (m *RollbackResponse) String() string { return proto.CompactTextString(m) }
+func (*RollbackResponse) ProtoMessage()    {}
+func (*RollbackResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{5}
+}
+
+func (m *RollbackResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackResponse.Unmarshal(m, b)
+}
+
+func (m *RollbackResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackResponse.Marshal(b, m, deterministic)
+}
+
+func (m *RollbackResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackResponse.Merge(m, src)
+}
+
+func (m *RollbackResponse) XXX_Size() int {
+	return xxx_messageInfo_RollbackResponse.Size(m)
+}
+
+func (m *RollbackResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackResponse proto.InternalMessageInfo
+
+func (m *RollbackResponse) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type RollbackReply struct {
+	Response             []*RollbackResponse `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RollbackReply) Reset()         { *m = RollbackReply{} }
+func (m *RollbackReply) String() string { return proto.CompactTextString(m) }
+func (*RollbackReply) ProtoMessage()    {}
+func (*RollbackReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{6}
+}
+
+func (m *RollbackReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackReply.Unmarshal(m, b)
+}
+
+func (m *RollbackReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackReply.Marshal(b, m, deterministic)
+}
+
+func (m *RollbackReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackReply.Merge(m, src)
+}
+
+func (m *RollbackReply) XXX_Size() int {
+	return xxx_messageInfo_RollbackReply.Size(m)
+}
+
+func (m *RollbackReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackReply proto.InternalMessageInfo
+
+func (m *RollbackReply) GetResponse() []*RollbackResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 // rpc shutdown
 // The response message containing the shutdown status.
 type ShutdownResponse struct {
@@ -216,7 +446,7 @@ func (m *ShutdownResponse) Reset()         { *m = ShutdownResponse{} }
 func (m *ShutdownResponse) String() string { return proto.CompactTextString(m) }
 func (*ShutdownResponse) ProtoMessage()    {}
 func (*ShutdownResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{4}
+	return fileDescriptor_84b4f59d98cc997c, []int{7}
 }
 
 func (m *ShutdownResponse) XXX_Unmarshal(b []byte) error {
@@ -259,7 +489,7 @@ func (m *ShutdownReply) Reset()         { *m = ShutdownReply{} }
 func (m *ShutdownReply) String() string { return proto.CompactTextString(m) }
 func (*ShutdownReply) ProtoMessage()    {}
 func (*ShutdownReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{5}
+	return fileDescriptor_84b4f59d98cc997c, []int{8}
 }
 
 func (m *ShutdownReply) XXX_Unmarshal(b []byte) error {
@@ -293,7 +523,13 @@ func (m *ShutdownReply) GetResponse() []*ShutdownResponse {
 
 // rpc upgrade
 type UpgradeRequest struct {
-	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Image string `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// force the upgrade even if etcd members are down, or the etcd cluster
+	// loses quorum once the node leaves it.
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// stage the upgrade: the installer image is pulled and validated right
+	// away, and the upgrade is applied on the next reboot.
+	Stage                bool     `protobuf:"varint,3,opt,name=stage,proto3" json:"stage,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -303,7 +539,7 @@ func (m *UpgradeRequest) Reset()         { *m = UpgradeRequest{} }
 func (m *UpgradeRequest) String() string { return proto.CompactTextString(m) }
 func (*UpgradeRequest) ProtoMessage()    {}
 func (*UpgradeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{6}
+	return fileDescriptor_84b4f59d98cc997c, []int{9}
 }
 
 func (m *UpgradeRequest) XXX_Unmarshal(b []byte) error {
@@ -335,6 +571,20 @@ func (m *UpgradeRequest) GetImage() string {
 	return ""
 }
 
+func (m *UpgradeRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *UpgradeRequest) GetStage() bool {
+	if m != nil {
+		return m.Stage
+	}
+	return false
+}
+
 type UpgradeResponse struct {
 	Metadata             *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Ack                  string               `protobuf:"bytes,2,opt,name=ack,proto3" json:"ack,omitempty"`
@@ -347,7 +597,7 @@ func (m *UpgradeResponse) Reset()         { *m = UpgradeResponse{} }
 func (m *UpgradeResponse) String() string { return proto.CompactTextString(m) }
 func (*UpgradeResponse) ProtoMessage()    {}
 func (*UpgradeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{7}
+	return fileDescriptor_84b4f59d98cc997c, []int{10}
 }
 
 func (m *UpgradeResponse) XXX_Unmarshal(b []byte) error {
@@ -397,7 +647,7 @@ func (m *UpgradeReply) Reset()         { *m = UpgradeReply{} }
 func (m *UpgradeReply) String() string { return proto.CompactTextString(m) }
 func (*UpgradeReply) ProtoMessage()    {}
 func (*UpgradeReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{8}
+	return fileDescriptor_84b4f59d98cc997c, []int{11}
 }
 
 func (m *UpgradeReply) XXX_Unmarshal(b []byte) error {
@@ -442,7 +692,7 @@ func (m *ServiceListResponse) Reset()         { *m = ServiceListResponse{} }
 func (m *ServiceListResponse) String() string { return proto.CompactTextString(m) }
 func (*ServiceListResponse) ProtoMessage()    {}
 func (*ServiceListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{9}
+	return fileDescriptor_84b4f59d98cc997c, []int{12}
 }
 
 func (m *ServiceListResponse) XXX_Unmarshal(b []byte) error {
@@ -492,7 +742,7 @@ func (m *ServiceListReply) Reset()         { *m = ServiceListReply{} }
 func (m *ServiceListReply) String() string { return proto.CompactTextString(m) }
 func (*ServiceListReply) ProtoMessage()    {}
 func (*ServiceListReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{10}
+	return fileDescriptor_84b4f59d98cc997c, []int{13}
 }
 
 func (m *ServiceListReply) XXX_Unmarshal(b []byte) error {
@@ -538,7 +788,7 @@ func (m *ServiceInfo) Reset()         { *m = ServiceInfo{} }
 func (m *ServiceInfo) String() string { return proto.CompactTextString(m) }
 func (*ServiceInfo) ProtoMessage()    {}
 func (*ServiceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{11}
+	return fileDescriptor_84b4f59d98cc997c, []int{14}
 }
 
 func (m *ServiceInfo) XXX_Unmarshal(b []byte) error {
@@ -602,7 +852,7 @@ func (m *ServiceEvents) Reset()         { *m = ServiceEvents{} }
 func (m *ServiceEvents) String() string { return proto.CompactTextString(m) }
 func (*ServiceEvents) ProtoMessage()    {}
 func (*ServiceEvents) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{12}
+	return fileDescriptor_84b4f59d98cc997c, []int{15}
 }
 
 func (m *ServiceEvents) XXX_Unmarshal(b []byte) error {
@@ -647,7 +897,7 @@ func (m *ServiceEvent) Reset()         { *m = ServiceEvent{} }
 func (m *ServiceEvent) String() string { return proto.CompactTextString(m) }
 func (*ServiceEvent) ProtoMessage()    {}
 func (*ServiceEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{13}
+	return fileDescriptor_84b4f59d98cc997c, []int{16}
 }
 
 func (m *ServiceEvent) XXX_Unmarshal(b []byte) error {
@@ -707,7 +957,7 @@ func (m *ServiceHealth) Reset()         { *m = ServiceHealth{} }
 func (m *ServiceHealth) String() string { return proto.CompactTextString(m) }
 func (*ServiceHealth) ProtoMessage()    {}
 func (*ServiceHealth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{14}
+	return fileDescriptor_84b4f59d98cc997c, []int{17}
 }
 
 func (m *ServiceHealth) XXX_Unmarshal(b []byte) error {
@@ -772,7 +1022,7 @@ func (m *ServiceStartRequest) Reset()         { *m = ServiceStartRequest{} }
 func (m *ServiceStartRequest) String() string { return proto.CompactTextString(m) }
 func (*ServiceStartRequest) ProtoMessage()    {}
 func (*ServiceStartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{15}
+	return fileDescriptor_84b4f59d98cc997c, []int{18}
 }
 
 func (m *ServiceStartRequest) XXX_Unmarshal(b []byte) error {
@@ -816,7 +1066,7 @@ func (m *ServiceStartResponse) Reset()         { *m = ServiceStartResponse{} }
 func (m *ServiceStartResponse) String() string { return proto.CompactTextString(m) }
 func (*ServiceStartResponse) ProtoMessage()    {}
 func (*ServiceStartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{16}
+	return fileDescriptor_84b4f59d98cc997c, []int{19}
 }
 
 func (m *ServiceStartResponse) XXX_Unmarshal(b []byte) error {
@@ -866,7 +1116,7 @@ func (m *ServiceStartReply) Reset()         { *m = ServiceStartReply{} }
 func (m *ServiceStartReply) String() string { return proto.CompactTextString(m) }
 func (*ServiceStartReply) ProtoMessage()    {}
 func (*ServiceStartReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{17}
+	return fileDescriptor_84b4f59d98cc997c, []int{20}
 }
 
 func (m *ServiceStartReply) XXX_Unmarshal(b []byte) error {
@@ -909,7 +1159,7 @@ func (m *ServiceStopRequest) Reset()         { *m = ServiceStopRequest{} }
 func (m *ServiceStopRequest) String() string { return proto.CompactTextString(m) }
 func (*ServiceStopRequest) ProtoMessage()    {}
 func (*ServiceStopRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{18}
+	return fileDescriptor_84b4f59d98cc997c, []int{21}
 }
 
 func (m *ServiceStopRequest) XXX_Unmarshal(b []byte) error {
@@ -953,7 +1203,7 @@ func (m *ServiceStopResponse) Reset()         { *m = ServiceStopResponse{} }
 func (m *ServiceStopResponse) String() string { return proto.CompactTextString(m) }
 func (*ServiceStopResponse) ProtoMessage()    {}
 func (*ServiceStopResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{19}
+	return fileDescriptor_84b4f59d98cc997c, []int{22}
 }
 
 func (m *ServiceStopResponse) XXX_Unmarshal(b []byte) error {
@@ -1003,7 +1253,7 @@ func (m *ServiceStopReply) Reset()         { *m = ServiceStopReply{} }
 func (m *ServiceStopReply) String() string { return proto.CompactTextString(m) }
 func (*ServiceStopReply) ProtoMessage()    {}
 func (*ServiceStopReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{20}
+	return fileDescriptor_84b4f59d98cc997c, []int{23}
 }
 
 func (m *ServiceStopReply) XXX_Unmarshal(b []byte) error {
@@ -1046,7 +1296,7 @@ func (m *ServiceRestartRequest) Reset()         { *m = ServiceRestartRequest{} }
 func (m *ServiceRestartRequest) String() string { return proto.CompactTextString(m) }
 func (*ServiceRestartRequest) ProtoMessage()    {}
 func (*ServiceRestartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{21}
+	return fileDescriptor_84b4f59d98cc997c, []int{24}
 }
 
 func (m *ServiceRestartRequest) XXX_Unmarshal(b []byte) error {
@@ -1090,7 +1340,7 @@ func (m *ServiceRestartResponse) Reset()         { *m = ServiceRestartResponse{}
 func (m *ServiceRestartResponse) String() string { return proto.CompactTextString(m) }
 func (*ServiceRestartResponse) ProtoMessage()    {}
 func (*ServiceRestartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{22}
+	return fileDescriptor_84b4f59d98cc997c, []int{25}
 }
 
 func (m *ServiceRestartResponse) XXX_Unmarshal(b []byte) error {
@@ -1140,7 +1390,7 @@ func (m *ServiceRestartReply) Reset()         { *m = ServiceRestartReply{} }
 func (m *ServiceRestartReply) String() string { return proto.CompactTextString(m) }
 func (*ServiceRestartReply) ProtoMessage()    {}
 func (*ServiceRestartReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{23}
+	return fileDescriptor_84b4f59d98cc997c, []int{26}
 }
 
 func (m *ServiceRestartReply) XXX_Unmarshal(b []byte) error {
@@ -1184,7 +1434,7 @@ func (m *StartRequest) Reset()         { *m = StartRequest{} }
 func (m *StartRequest) String() string { return proto.CompactTextString(m) }
 func (*StartRequest) ProtoMessage()    {}
 func (*StartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{24}
+	return fileDescriptor_84b4f59d98cc997c, []int{27}
 }
 
 func (m *StartRequest) XXX_Unmarshal(b []byte) error {
@@ -1228,7 +1478,7 @@ func (m *StartReply) Reset()         { *m = StartReply{} }
 func (m *StartReply) String() string { return proto.CompactTextString(m) }
 func (*StartReply) ProtoMessage()    {}
 func (*StartReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{25}
+	return fileDescriptor_84b4f59d98cc997c, []int{28}
 }
 
 func (m *StartReply) XXX_Unmarshal(b []byte) error {
@@ -1272,7 +1522,7 @@ func (m *StopRequest) Reset()         { *m = StopRequest{} }
 func (m *StopRequest) String() string { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()    {}
 func (*StopRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{26}
+	return fileDescriptor_84b4f59d98cc997c, []int{29}
 }
 
 func (m *StopRequest) XXX_Unmarshal(b []byte) error {
@@ -1316,7 +1566,7 @@ func (m *StopReply) Reset()         { *m = StopReply{} }
 func (m *StopReply) String() string { return proto.CompactTextString(m) }
 func (*StopReply) ProtoMessage()    {}
 func (*StopReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{27}
+	return fileDescriptor_84b4f59d98cc997c, []int{30}
 }
 
 func (m *StopReply) XXX_Unmarshal(b []byte) error {
@@ -1350,18 +1600,19 @@ func (m *StopReply) GetResp() string {
 
 // StreamingData is used to stream back responses
 type StreamingData struct {
-	Bytes                []byte   `protobuf:"bytes,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
-	Errors               string   `protobuf:"bytes,2,opt,name=errors,proto3" json:"errors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Bytes                []byte               `protobuf:"bytes,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Errors               string               `protobuf:"bytes,2,opt,name=errors,proto3" json:"errors,omitempty"`
+	Metadata             *common.NodeMetadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *StreamingData) Reset()         { *m = StreamingData{} }
 func (m *StreamingData) String() string { return proto.CompactTextString(m) }
 func (*StreamingData) ProtoMessage()    {}
 func (*StreamingData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{28}
+	return fileDescriptor_84b4f59d98cc997c, []int{31}
 }
 
 func (m *StreamingData) XXX_Unmarshal(b []byte) error {
@@ -1400,6 +1651,13 @@ func (m *StreamingData) GetErrors() string {
 	return ""
 }
 
+func (m *StreamingData) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // CopyOutRequest describes a request to copy data out of Talos node
 //
 // CopyOut produces .tar.gz archive which is streamed back to the caller
@@ -1415,7 +1673,7 @@ func (m *CopyOutRequest) Reset()         { *m = CopyOutRequest{} }
 func (m *CopyOutRequest) String() string { return proto.CompactTextString(m) }
 func (*CopyOutRequest) ProtoMessage()    {}
 func (*CopyOutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{29}
+	return fileDescriptor_84b4f59d98cc997c, []int{32}
 }
 
 func (m *CopyOutRequest) XXX_Unmarshal(b []byte) error {
@@ -1466,7 +1724,7 @@ func (m *LSRequest) Reset()         { *m = LSRequest{} }
 func (m *LSRequest) String() string { return proto.CompactTextString(m) }
 func (*LSRequest) ProtoMessage()    {}
 func (*LSRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{30}
+	return fileDescriptor_84b4f59d98cc997c, []int{33}
 }
 
 func (m *LSRequest) XXX_Unmarshal(b []byte) error {
@@ -1530,17 +1788,19 @@ type FileInfo struct {
 	// Link is filled with symlink target
 	Link string `protobuf:"bytes,7,opt,name=link,proto3" json:"link,omitempty"`
 	// RelativeName is the name of the file or directory relative to the RootPath
-	RelativeName         string   `protobuf:"bytes,8,opt,name=relative_name,json=relativeName,proto3" json:"relative_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	RelativeName string `protobuf:"bytes,8,opt,name=relative_name,json=relativeName,proto3" json:"relative_name,omitempty"`
+	// Metadata identifies the node the file was listed on
+	Metadata             *common.NodeMetadata `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *FileInfo) Reset()         { *m = FileInfo{} }
 func (m *FileInfo) String() string { return proto.CompactTextString(m) }
 func (*FileInfo) ProtoMessage()    {}
 func (*FileInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{31}
+	return fileDescriptor_84b4f59d98cc997c, []int{34}
 }
 
 func (m *FileInfo) XXX_Unmarshal(b []byte) error {
@@ -1621,6 +1881,13 @@ func (m *FileInfo) GetRelativeName() string {
 	return ""
 }
 
+func (m *FileInfo) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // The response message containing the requested df stats.
 type MountsResponse struct {
 	Metadata             *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
@@ -1634,7 +1901,7 @@ func (m *MountsResponse) Reset()         { *m = MountsResponse{} }
 func (m *MountsResponse) String() string { return proto.CompactTextString(m) }
 func (*MountsResponse) ProtoMessage()    {}
 func (*MountsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{32}
+	return fileDescriptor_84b4f59d98cc997c, []int{35}
 }
 
 func (m *MountsResponse) XXX_Unmarshal(b []byte) error {
@@ -1684,7 +1951,7 @@ func (m *MountsReply) Reset()         { *m = MountsReply{} }
 func (m *MountsReply) String() string { return proto.CompactTextString(m) }
 func (*MountsReply) ProtoMessage()    {}
 func (*MountsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{33}
+	return fileDescriptor_84b4f59d98cc997c, []int{36}
 }
 
 func (m *MountsReply) XXX_Unmarshal(b []byte) error {
@@ -1731,7 +1998,7 @@ func (m *MountStat) Reset()         { *m = MountStat{} }
 func (m *MountStat) String() string { return proto.CompactTextString(m) }
 func (*MountStat) ProtoMessage()    {}
 func (*MountStat) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{34}
+	return fileDescriptor_84b4f59d98cc997c, []int{37}
 }
 
 func (m *MountStat) XXX_Unmarshal(b []byte) error {
@@ -1797,7 +2064,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{35}
+	return fileDescriptor_84b4f59d98cc997c, []int{38}
 }
 
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
@@ -1854,7 +2121,7 @@ func (m *VersionReply) Reset()         { *m = VersionReply{} }
 func (m *VersionReply) String() string { return proto.CompactTextString(m) }
 func (*VersionReply) ProtoMessage()    {}
 func (*VersionReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{36}
+	return fileDescriptor_84b4f59d98cc997c, []int{39}
 }
 
 func (m *VersionReply) XXX_Unmarshal(b []byte) error {
@@ -1902,7 +2169,7 @@ func (m *VersionInfo) Reset()         { *m = VersionInfo{} }
 func (m *VersionInfo) String() string { return proto.CompactTextString(m) }
 func (*VersionInfo) ProtoMessage()    {}
 func (*VersionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{37}
+	return fileDescriptor_84b4f59d98cc997c, []int{40}
 }
 
 func (m *VersionInfo) XXX_Unmarshal(b []byte) error {
@@ -1981,7 +2248,7 @@ func (m *PlatformInfo) Reset()         { *m = PlatformInfo{} }
 func (m *PlatformInfo) String() string { return proto.CompactTextString(m) }
 func (*PlatformInfo) ProtoMessage()    {}
 func (*PlatformInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{38}
+	return fileDescriptor_84b4f59d98cc997c, []int{41}
 }
 
 func (m *PlatformInfo) XXX_Unmarshal(b []byte) error {
@@ -2026,17 +2293,22 @@ type LogsRequest struct {
 	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	// driver might be default "containerd" or "cri"
-	Driver               common.ContainerDriver `protobuf:"varint,3,opt,name=driver,proto3,enum=common.ContainerDriver" json:"driver,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Driver common.ContainerDriver `protobuf:"varint,3,opt,name=driver,proto3,enum=common.ContainerDriver" json:"driver,omitempty"`
+	// follow keeps the stream open and sends new log lines as they are written
+	Follow bool `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
+	// tail_lines starts the stream at the last N lines of the log, 0 streams
+	// the whole log
+	TailLines            int32    `protobuf:"varint,5,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LogsRequest) Reset()         { *m = LogsRequest{} }
 func (m *LogsRequest) String() string { return proto.CompactTextString(m) }
 func (*LogsRequest) ProtoMessage()    {}
 func (*LogsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84b4f59d98cc997c, []int{39}
+	return fileDescriptor_84b4f59d98cc997c, []int{42}
 }
 
 func (m *LogsRequest) XXX_Unmarshal(b []byte) error {
@@ -2082,11 +2354,342 @@ func (m *LogsRequest) GetDriver() common.ContainerDriver {
 	return common.ContainerDriver_CONTAINERD
 }
 
+func (m *LogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *LogsRequest) GetTailLines() int32 {
+	if m != nil {
+		return m.TailLines
+	}
+	return 0
+}
+
+type EventsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventsRequest) Reset()         { *m = EventsRequest{} }
+func (m *EventsRequest) String() string { return proto.CompactTextString(m) }
+func (*EventsRequest) ProtoMessage()    {}
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{43}
+}
+
+func (m *EventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventsRequest.Unmarshal(m, b)
+}
+
+func (m *EventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventsRequest.Marshal(b, m, deterministic)
+}
+
+func (m *EventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventsRequest.Merge(m, src)
+}
+
+func (m *EventsRequest) XXX_Size() int {
+	return xxx_messageInfo_EventsRequest.Size(m)
+}
+
+func (m *EventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventsRequest proto.InternalMessageInfo
+
+// Event is a single machine event, exactly one of sequence, phase and service
+// is set, according to the type.
+type Event struct {
+	Metadata             *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Ts                   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=ts,proto3" json:"ts,omitempty"`
+	Type                 EventType            `protobuf:"varint,3,opt,name=type,proto3,enum=machine.EventType" json:"type,omitempty"`
+	Sequence             *SequenceEvent       `protobuf:"bytes,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Phase                *PhaseEvent          `protobuf:"bytes,5,opt,name=phase,proto3" json:"phase,omitempty"`
+	Service              *ServiceStateEvent   `protobuf:"bytes,6,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{44}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Event) GetTs() *timestamp.Timestamp {
+	if m != nil {
+		return m.Ts
+	}
+	return nil
+}
+
+func (m *Event) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_SEQUENCE
+}
+
+func (m *Event) GetSequence() *SequenceEvent {
+	if m != nil {
+		return m.Sequence
+	}
+	return nil
+}
+
+func (m *Event) GetPhase() *PhaseEvent {
+	if m != nil {
+		return m.Phase
+	}
+	return nil
+}
+
+func (m *Event) GetService() *ServiceStateEvent {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+// SequenceEvent reports a sequence (boot, shutdown, reboot, upgrade, reset)
+// starting or finishing.
+type SequenceEvent struct {
+	Sequence string      `protobuf:"bytes,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Action   EventAction `protobuf:"varint,2,opt,name=action,proto3,enum=machine.EventAction" json:"action,omitempty"`
+	// error is set if the sequence failed
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SequenceEvent) Reset()         { *m = SequenceEvent{} }
+func (m *SequenceEvent) String() string { return proto.CompactTextString(m) }
+func (*SequenceEvent) ProtoMessage()    {}
+func (*SequenceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{45}
+}
+
+func (m *SequenceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SequenceEvent.Unmarshal(m, b)
+}
+
+func (m *SequenceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SequenceEvent.Marshal(b, m, deterministic)
+}
+
+func (m *SequenceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SequenceEvent.Merge(m, src)
+}
+
+func (m *SequenceEvent) XXX_Size() int {
+	return xxx_messageInfo_SequenceEvent.Size(m)
+}
+
+func (m *SequenceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SequenceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SequenceEvent proto.InternalMessageInfo
+
+func (m *SequenceEvent) GetSequence() string {
+	if m != nil {
+		return m.Sequence
+	}
+	return ""
+}
+
+func (m *SequenceEvent) GetAction() EventAction {
+	if m != nil {
+		return m.Action
+	}
+	return EventAction_START
+}
+
+func (m *SequenceEvent) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// PhaseEvent reports a phase of a sequence starting or finishing.
+type PhaseEvent struct {
+	Phase  string      `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	Action EventAction `protobuf:"varint,2,opt,name=action,proto3,enum=machine.EventAction" json:"action,omitempty"`
+	// error is set if the phase failed
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PhaseEvent) Reset()         { *m = PhaseEvent{} }
+func (m *PhaseEvent) String() string { return proto.CompactTextString(m) }
+func (*PhaseEvent) ProtoMessage()    {}
+func (*PhaseEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{46}
+}
+
+func (m *PhaseEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PhaseEvent.Unmarshal(m, b)
+}
+
+func (m *PhaseEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PhaseEvent.Marshal(b, m, deterministic)
+}
+
+func (m *PhaseEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PhaseEvent.Merge(m, src)
+}
+
+func (m *PhaseEvent) XXX_Size() int {
+	return xxx_messageInfo_PhaseEvent.Size(m)
+}
+
+func (m *PhaseEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_PhaseEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PhaseEvent proto.InternalMessageInfo
+
+func (m *PhaseEvent) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *PhaseEvent) GetAction() EventAction {
+	if m != nil {
+		return m.Action
+	}
+	return EventAction_START
+}
+
+func (m *PhaseEvent) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// ServiceStateEvent reports a service changing state or health.
+type ServiceStateEvent struct {
+	Service              string         `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	State                string         `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Message              string         `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Health               *ServiceHealth `protobuf:"bytes,4,opt,name=health,proto3" json:"health,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ServiceStateEvent) Reset()         { *m = ServiceStateEvent{} }
+func (m *ServiceStateEvent) String() string { return proto.CompactTextString(m) }
+func (*ServiceStateEvent) ProtoMessage()    {}
+func (*ServiceStateEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84b4f59d98cc997c, []int{47}
+}
+
+func (m *ServiceStateEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceStateEvent.Unmarshal(m, b)
+}
+
+func (m *ServiceStateEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceStateEvent.Marshal(b, m, deterministic)
+}
+
+func (m *ServiceStateEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceStateEvent.Merge(m, src)
+}
+
+func (m *ServiceStateEvent) XXX_Size() int {
+	return xxx_messageInfo_ServiceStateEvent.Size(m)
+}
+
+func (m *ServiceStateEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceStateEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceStateEvent proto.InternalMessageInfo
+
+func (m *ServiceStateEvent) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ServiceStateEvent) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ServiceStateEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ServiceStateEvent) GetHealth() *ServiceHealth {
+	if m != nil {
+		return m.Health
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("machine.ResetScope", ResetScope_name, ResetScope_value)
+	proto.RegisterEnum("machine.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("machine.EventAction", EventAction_name, EventAction_value)
 	proto.RegisterType((*RebootResponse)(nil), "machine.RebootResponse")
 	proto.RegisterType((*RebootReply)(nil), "machine.RebootReply")
+	proto.RegisterType((*ResetRequest)(nil), "machine.ResetRequest")
 	proto.RegisterType((*ResetResponse)(nil), "machine.ResetResponse")
 	proto.RegisterType((*ResetReply)(nil), "machine.ResetReply")
+	proto.RegisterType((*RollbackResponse)(nil), "machine.RollbackResponse")
+	proto.RegisterType((*RollbackReply)(nil), "machine.RollbackReply")
 	proto.RegisterType((*ShutdownResponse)(nil), "machine.ShutdownResponse")
 	proto.RegisterType((*ShutdownReply)(nil), "machine.ShutdownReply")
 	proto.RegisterType((*UpgradeRequest)(nil), "machine.UpgradeRequest")
@@ -2123,106 +2726,137 @@ func init() {
 	proto.RegisterType((*VersionInfo)(nil), "machine.VersionInfo")
 	proto.RegisterType((*PlatformInfo)(nil), "machine.PlatformInfo")
 	proto.RegisterType((*LogsRequest)(nil), "machine.LogsRequest")
+	proto.RegisterType((*EventsRequest)(nil), "machine.EventsRequest")
+	proto.RegisterType((*Event)(nil), "machine.Event")
+	proto.RegisterType((*SequenceEvent)(nil), "machine.SequenceEvent")
+	proto.RegisterType((*PhaseEvent)(nil), "machine.PhaseEvent")
+	proto.RegisterType((*ServiceStateEvent)(nil), "machine.ServiceStateEvent")
 }
 
 func init() { proto.RegisterFile("machine/machine.proto", fileDescriptor_84b4f59d98cc997c) }
 
 var fileDescriptor_84b4f59d98cc997c = []byte{
-	// 1496 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdb, 0x6e, 0x1b, 0x37,
-	0x13, 0xc6, 0xca, 0xb6, 0x0e, 0x23, 0x59, 0x4e, 0xe8, 0x43, 0x14, 0xc5, 0x39, 0x6d, 0xf2, 0xff,
-	0x09, 0x82, 0xdf, 0x72, 0x7e, 0xe7, 0x80, 0x1c, 0xda, 0x20, 0x71, 0x9c, 0x20, 0x45, 0x6c, 0x27,
-	0x58, 0x37, 0xbd, 0x68, 0x8b, 0x0a, 0x94, 0x44, 0x4b, 0x44, 0x76, 0x97, 0xdb, 0x25, 0xe5, 0x40,
-	0x45, 0x1f, 0xa0, 0xe8, 0x6d, 0x1f, 0xa1, 0x40, 0x9f, 0xa5, 0x77, 0x7d, 0xa5, 0x82, 0x87, 0xa5,
-	0xa8, 0x95, 0xe5, 0x04, 0x71, 0xae, 0x44, 0x0e, 0x67, 0x67, 0xbe, 0x6f, 0x38, 0x1c, 0x0e, 0x05,
-	0xab, 0x11, 0xee, 0x0e, 0x68, 0x4c, 0x36, 0xcd, 0x6f, 0x2b, 0x49, 0x99, 0x60, 0xa8, 0x64, 0xa6,
-	0xcd, 0x0b, 0x7d, 0xc6, 0xfa, 0x21, 0xd9, 0x54, 0xe2, 0xce, 0xf0, 0x70, 0x93, 0x44, 0x89, 0x18,
-	0x69, 0xad, 0xe6, 0xe5, 0xfc, 0xa2, 0xa0, 0x11, 0xe1, 0x02, 0x47, 0x89, 0x51, 0x58, 0xee, 0xb2,
-	0x28, 0x62, 0xf1, 0xa6, 0xfe, 0xd1, 0x42, 0x7f, 0x1b, 0xea, 0x01, 0xe9, 0x30, 0x26, 0x02, 0xc2,
-	0x13, 0x16, 0x73, 0x82, 0x6e, 0x43, 0x39, 0x22, 0x02, 0xf7, 0xb0, 0xc0, 0x0d, 0xef, 0x8a, 0x77,
-	0xb3, 0xba, 0xb5, 0xd2, 0x32, 0x9f, 0xec, 0xb3, 0x1e, 0xd9, 0x33, 0x6b, 0x81, 0xd5, 0xf2, 0xb7,
-	0xa1, 0x9a, 0xd9, 0x48, 0xc2, 0x11, 0xba, 0x03, 0xe5, 0xd4, 0x18, 0x6b, 0x78, 0x57, 0xe6, 0x6e,
-	0x56, 0xb7, 0xce, 0xb5, 0x32, 0x42, 0x93, 0xbe, 0x02, 0xab, 0xe8, 0x3f, 0x83, 0xc5, 0x80, 0x70,
-	0x72, 0x1a, 0x18, 0x4f, 0x01, 0x8c, 0x09, 0x89, 0x62, 0x6b, 0x0a, 0xc5, 0x9a, 0x83, 0xc2, 0xf1,
-	0xe4, 0x80, 0xd8, 0x81, 0x33, 0x07, 0x83, 0xa1, 0xe8, 0xb1, 0x0f, 0xf1, 0x29, 0x70, 0xbc, 0x84,
-	0xc5, 0xb1, 0x15, 0x09, 0xe5, 0xde, 0x14, 0x94, 0xf3, 0x16, 0x4a, 0xde, 0x9f, 0x83, 0xe6, 0xbf,
-	0x50, 0x7f, 0x97, 0xf4, 0x53, 0xdc, 0x23, 0x01, 0xf9, 0x79, 0x48, 0xb8, 0x40, 0x2b, 0xb0, 0x40,
-	0x23, 0xdc, 0x27, 0x0a, 0x48, 0x25, 0xd0, 0x13, 0xff, 0x1d, 0x2c, 0x59, 0xbd, 0xcf, 0x05, 0x8d,
-	0xce, 0xc0, 0x1c, 0xee, 0xbe, 0x6f, 0x14, 0x94, 0x61, 0x39, 0xf4, 0x77, 0xa0, 0x66, 0xcd, 0x4a,
-	0x16, 0x77, 0xa7, 0x58, 0x34, 0x2c, 0x8b, 0x9c, 0x7f, 0x87, 0xc4, 0x08, 0x96, 0x0f, 0x48, 0x7a,
-	0x44, 0xbb, 0x64, 0x97, 0xf2, 0x53, 0xec, 0xae, 0xfc, 0x82, 0x6b, 0x43, 0xbc, 0x51, 0x50, 0xee,
-	0x57, 0xc6, 0x41, 0xd4, 0x0b, 0xdf, 0xc4, 0x87, 0x2c, 0xb0, 0x5a, 0xfe, 0x2e, 0x9c, 0x99, 0x70,
-	0x2d, 0x49, 0x3c, 0x98, 0x22, 0xb1, 0x9e, 0xb7, 0xe2, 0xe2, 0x74, 0x88, 0xfc, 0xe1, 0x41, 0xd5,
-	0xf1, 0x83, 0xea, 0x50, 0xa0, 0x3d, 0xb3, 0x11, 0x05, 0xda, 0x93, 0x7b, 0xc3, 0x05, 0x16, 0xc4,
-	0x84, 0x50, 0x4f, 0x50, 0x0b, 0x8a, 0xe4, 0x88, 0xc4, 0x82, 0x37, 0xe6, 0x14, 0xcb, 0xb5, 0xbc,
-	0xb7, 0x17, 0x6a, 0x35, 0x30, 0x5a, 0x52, 0x7f, 0x40, 0x70, 0x28, 0x06, 0x8d, 0xf9, 0xe3, 0xf5,
-	0x5f, 0xa9, 0xd5, 0xc0, 0x68, 0xf9, 0x4f, 0x60, 0x71, 0xc2, 0x10, 0xda, 0xb0, 0x0e, 0x35, 0xbd,
-	0xd5, 0x63, 0x1d, 0x66, 0xfe, 0xfc, 0x0e, 0xd4, 0x5c, 0xb9, 0x4c, 0x83, 0x88, 0xf7, 0x0d, 0x2d,
-	0x39, 0x9c, 0xc1, 0xeb, 0x16, 0x14, 0x2c, 0xa7, 0x66, 0x4b, 0x57, 0x9e, 0x56, 0x56, 0x79, 0x5a,
-	0xdf, 0x66, 0x95, 0x27, 0x28, 0x08, 0xee, 0xff, 0xe9, 0x59, 0x90, 0x1a, 0x3d, 0x6a, 0x40, 0x69,
-	0x18, 0xbf, 0x8f, 0xd9, 0x87, 0x58, 0x79, 0x2a, 0x07, 0xd9, 0x54, 0xae, 0x68, 0x66, 0x23, 0xe5,
-	0xaf, 0x1c, 0x64, 0x53, 0x74, 0x15, 0x6a, 0x21, 0xe6, 0xa2, 0x1d, 0x11, 0xce, 0xe5, 0x11, 0x98,
-	0x53, 0x70, 0xaa, 0x52, 0xb6, 0xa7, 0x45, 0xe8, 0x31, 0xa8, 0x69, 0xbb, 0x3b, 0xc0, 0x71, 0x9f,
-	0x98, 0x08, 0x9e, 0x84, 0x0e, 0xa4, 0xfa, 0x73, 0xa5, 0xed, 0xff, 0xc7, 0x26, 0xea, 0x81, 0xc0,
-	0xa9, 0xc8, 0x8e, 0x5c, 0x6e, 0x9b, 0xfd, 0x1f, 0x61, 0x65, 0x52, 0xed, 0xb3, 0x13, 0x1a, 0xc1,
-	0xbc, 0x4c, 0x2e, 0x13, 0x57, 0x35, 0xf6, 0xf7, 0xe1, 0xec, 0xa4, 0x75, 0x99, 0xb3, 0x0f, 0xa7,
-	0x72, 0xf6, 0x62, 0x7e, 0x53, 0x27, 0xb0, 0x38, 0x49, 0x7b, 0x1d, 0x90, 0xd5, 0x60, 0xc9, 0x2c,
-	0x4e, 0x3f, 0x38, 0xd4, 0xa5, 0xd6, 0x17, 0xa5, 0x34, 0x3e, 0x85, 0xda, 0xf8, 0x27, 0x9e, 0x42,
-	0x17, 0x89, 0x43, 0xe8, 0x06, 0xac, 0x1a, 0x85, 0x40, 0xee, 0xe1, 0xec, 0x7d, 0xfa, 0x09, 0xd6,
-	0xf2, 0x8a, 0x5f, 0x94, 0x56, 0x60, 0x63, 0x66, 0xed, 0x4b, 0x66, 0x8f, 0xa7, 0x98, 0x5d, 0xce,
-	0x33, 0xcb, 0xe1, 0x71, 0xc8, 0xf9, 0x50, 0x3b, 0x29, 0xf7, 0x1e, 0x15, 0x1a, 0x9e, 0x7f, 0x1d,
-	0xc0, 0x49, 0x8d, 0x0c, 0x99, 0x37, 0x46, 0xa6, 0xb4, 0xae, 0x42, 0xf5, 0x84, 0x0d, 0x57, 0x2a,
-	0xd7, 0xa0, 0x32, 0xde, 0x90, 0x59, 0x76, 0xbe, 0x86, 0xc5, 0x03, 0x91, 0x12, 0x1c, 0xd1, 0xb8,
-	0xbf, 0x23, 0x43, 0xb1, 0x02, 0x0b, 0x9d, 0x91, 0x20, 0x5c, 0x69, 0xd6, 0x02, 0x3d, 0x41, 0x6b,
-	0x50, 0x24, 0x69, 0xca, 0x52, 0x6e, 0x42, 0x64, 0x66, 0xfe, 0x06, 0xd4, 0x9f, 0xb3, 0x64, 0xf4,
-	0x66, 0x68, 0x29, 0x5d, 0x80, 0x4a, 0xca, 0x98, 0x68, 0x27, 0x58, 0x0c, 0x8c, 0xb7, 0xb2, 0x14,
-	0xbc, 0xc5, 0x62, 0xe0, 0x77, 0xa0, 0xb2, 0x7b, 0x90, 0x69, 0x4a, 0x48, 0x8c, 0x09, 0x0b, 0x89,
-	0x31, 0x21, 0xab, 0x43, 0x4a, 0xba, 0xc3, 0x94, 0x93, 0xac, 0x3a, 0x98, 0x29, 0xba, 0x01, 0x4b,
-	0x7a, 0x48, 0x59, 0xdc, 0xee, 0x91, 0x44, 0x0c, 0x54, 0x81, 0x58, 0x08, 0xea, 0x56, 0xbc, 0x23,
-	0xa5, 0xfe, 0xdf, 0x1e, 0x94, 0x5f, 0xd2, 0x50, 0xd7, 0x70, 0x04, 0xf3, 0x31, 0x8e, 0xb2, 0xeb,
-	0x54, 0x8d, 0xa5, 0x8c, 0xd3, 0x5f, 0xb4, 0x83, 0xb9, 0x40, 0x8d, 0xa5, 0x2c, 0x62, 0x3d, 0x5d,
-	0x73, 0x16, 0x03, 0x35, 0x46, 0x4d, 0x28, 0x47, 0xac, 0x47, 0x0f, 0x29, 0xe9, 0xa9, 0x4a, 0x33,
-	0x17, 0xd8, 0x39, 0x5a, 0x85, 0x22, 0xe5, 0xed, 0x1e, 0x4d, 0x1b, 0x0b, 0x0a, 0xe6, 0x02, 0xe5,
-	0x3b, 0x34, 0x95, 0xc1, 0x53, 0x81, 0x69, 0x14, 0x75, 0x29, 0x55, 0x13, 0x69, 0x3c, 0xa4, 0xf1,
-	0xfb, 0x46, 0x49, 0x83, 0x90, 0x63, 0x74, 0x0d, 0x16, 0x53, 0x12, 0x62, 0x41, 0x8f, 0x48, 0x5b,
-	0x21, 0x2c, 0xab, 0xc5, 0x5a, 0x26, 0xdc, 0xc7, 0x11, 0xf1, 0x43, 0xa8, 0xef, 0xb1, 0xa1, 0xbc,
-	0x3d, 0x3e, 0x3f, 0xb5, 0x6f, 0xea, 0xea, 0x9e, 0x5d, 0xa9, 0xc8, 0x26, 0xab, 0xb2, 0x7c, 0x20,
-	0xb0, 0xd0, 0x15, 0x9f, 0xcb, 0x26, 0x2f, 0xf3, 0xf6, 0xb1, 0x26, 0x6f, 0x12, 0x95, 0x93, 0xe0,
-	0xbf, 0x42, 0xc5, 0xda, 0x45, 0x97, 0x00, 0x0e, 0x69, 0x48, 0xf8, 0x88, 0x0b, 0x12, 0x99, 0x2d,
-	0x70, 0x24, 0x13, 0x1b, 0x31, 0x6f, 0x36, 0x62, 0x1d, 0x2a, 0xf8, 0x08, 0xd3, 0x10, 0x77, 0x42,
-	0xbd, 0x1b, 0xf3, 0xc1, 0x58, 0x80, 0x2e, 0x02, 0x44, 0xd2, 0x3c, 0xe9, 0xb5, 0x59, 0xac, 0x36,
-	0xa5, 0x12, 0x54, 0x8c, 0xe4, 0x4d, 0xec, 0xff, 0xe5, 0xc1, 0xd2, 0x77, 0x44, 0xe5, 0xc2, 0x29,
-	0x22, 0xd6, 0x82, 0xd2, 0x91, 0x36, 0xa2, 0x90, 0xb9, 0x6d, 0x88, 0x31, 0xae, 0xda, 0x90, 0x4c,
-	0x09, 0xfd, 0x1f, 0xca, 0x49, 0x88, 0xc5, 0x21, 0x4b, 0x23, 0x73, 0x5f, 0x8e, 0xaf, 0xe4, 0xb7,
-	0x66, 0x41, 0x37, 0x2e, 0x99, 0x9a, 0xec, 0xbc, 0x2c, 0xce, 0x8f, 0x75, 0x5e, 0x39, 0x42, 0x4e,
-	0xb0, 0x7f, 0xf7, 0xa0, 0xea, 0x20, 0x92, 0x57, 0xbb, 0xc0, 0xf6, 0x6a, 0x17, 0xb8, 0x2f, 0x25,
-	0x7c, 0x80, 0xb3, 0x9e, 0x8f, 0x0f, 0xf4, 0xf1, 0x1e, 0xd2, 0x50, 0x98, 0xdb, 0x55, 0x4f, 0x64,
-	0x5c, 0xfb, 0xac, 0x9d, 0xb1, 0x36, 0x71, 0xed, 0x33, 0x63, 0x5c, 0x56, 0x17, 0xc6, 0x55, 0xa6,
-	0x57, 0x82, 0x02, 0xe3, 0x72, 0xe3, 0x70, 0xda, 0x1d, 0x98, 0x2c, 0x57, 0x63, 0xff, 0x3e, 0xd4,
-	0x5c, 0xb2, 0xb3, 0x4e, 0x9e, 0x3a, 0x65, 0xa6, 0xcc, 0xca, 0xb1, 0x1f, 0x42, 0x75, 0x97, 0xf5,
-	0x79, 0x56, 0x14, 0xd6, 0xa1, 0x22, 0x55, 0x79, 0x82, 0xbb, 0xd9, 0xb7, 0x63, 0x81, 0x29, 0x73,
-	0x05, 0xdb, 0x92, 0x6d, 0x42, 0xb1, 0x97, 0xd2, 0x23, 0x92, 0x2a, 0x3a, 0xf5, 0xad, 0x73, 0xd9,
-	0xd6, 0x3e, 0x67, 0xb1, 0xc0, 0x34, 0x26, 0xe9, 0x8e, 0x5a, 0x0e, 0x8c, 0xda, 0xd6, 0x3f, 0x25,
-	0x28, 0xed, 0xe9, 0xc0, 0xa2, 0xaf, 0xa0, 0x64, 0x6a, 0x17, 0x1a, 0x67, 0xf6, 0x64, 0x35, 0x6b,
-	0x3a, 0xdd, 0x99, 0x5b, 0x25, 0x6f, 0x7b, 0xe8, 0x09, 0xc0, 0xeb, 0x61, 0x87, 0x74, 0x59, 0x7c,
-	0x48, 0xfb, 0x68, 0x6d, 0xaa, 0x07, 0x79, 0x21, 0x1f, 0x6e, 0x27, 0x7c, 0xbf, 0x01, 0x85, 0xdd,
-	0x03, 0x34, 0x3e, 0x8e, 0xb6, 0x2e, 0x36, 0xcf, 0x5a, 0x59, 0x56, 0xc6, 0x6e, 0x7b, 0xe8, 0x7f,
-	0x30, 0x2f, 0xc3, 0x84, 0xc6, 0xb9, 0xe8, 0x44, 0xad, 0x59, 0xcb, 0x78, 0x1b, 0xe3, 0xf7, 0xa1,
-	0xa8, 0x8f, 0xe8, 0x4c, 0x60, 0x2b, 0x53, 0x67, 0x59, 0xe6, 0xe1, 0x7d, 0x28, 0xea, 0xf7, 0xdb,
-	0x27, 0x7c, 0xe7, 0x3e, 0x08, 0xef, 0xc2, 0x82, 0x7a, 0x71, 0xcd, 0xfc, 0x6c, 0x39, 0xff, 0x32,
-	0x93, 0x5f, 0x3d, 0xb5, 0xfd, 0xb6, 0xec, 0xc8, 0x67, 0x7e, 0x7b, 0xfe, 0xf8, 0xfe, 0x5d, 0x5a,
-	0xd8, 0x87, 0xfa, 0xe4, 0x9d, 0x8b, 0x2e, 0xcd, 0xbc, 0x8c, 0x75, 0xa4, 0xd6, 0x67, 0xae, 0x4b,
-	0x7b, 0xaf, 0x6c, 0xb3, 0xac, 0xae, 0x60, 0xb4, 0x3e, 0xa3, 0x0d, 0xd3, 0xb6, 0x9a, 0x33, 0x56,
-	0xa5, 0xa5, 0x17, 0x96, 0x9b, 0xbc, 0x83, 0xd1, 0x85, 0xe3, 0xbb, 0x1f, 0x6d, 0xe7, 0xfc, 0xf1,
-	0x8b, 0xd2, 0xcc, 0x23, 0x28, 0x67, 0xef, 0xc7, 0x4f, 0xc9, 0xb1, 0x89, 0x47, 0xe9, 0x43, 0x28,
-	0x99, 0x57, 0x9b, 0x93, 0xdf, 0x93, 0xef, 0xcd, 0xe6, 0xea, 0xf4, 0x82, 0x6e, 0xdf, 0x16, 0x74,
-	0x00, 0x9c, 0xc7, 0x85, 0xcb, 0x7c, 0x39, 0x2f, 0x4e, 0xc2, 0x91, 0x3f, 0xf7, 0x5b, 0xc1, 0x43,
-	0xf7, 0x60, 0x5e, 0x11, 0x76, 0x9e, 0x6e, 0x0e, 0x53, 0x94, 0x93, 0xda, 0xcf, 0x1e, 0x40, 0x29,
-	0x2b, 0x36, 0xb3, 0x68, 0xae, 0x4e, 0x57, 0xc4, 0x24, 0x1c, 0x6d, 0xbf, 0x86, 0xa5, 0x2e, 0x8b,
-	0xec, 0x1a, 0x4e, 0xe8, 0x36, 0x98, 0x13, 0xfe, 0x2c, 0xa1, 0x6f, 0xbd, 0xef, 0x6f, 0xf5, 0xa9,
-	0x18, 0x0c, 0x3b, 0xf2, 0x84, 0x6c, 0x0a, 0x1c, 0x32, 0xbe, 0xa1, 0xaf, 0x1f, 0xae, 0x67, 0x9b,
-	0x38, 0xa1, 0xd9, 0xbf, 0x31, 0x9d, 0xa2, 0xf2, 0x79, 0xe7, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xf4, 0x19, 0x0e, 0x69, 0xa7, 0x11, 0x00, 0x00,
+	// 1905 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x7b, 0x6f, 0x1b, 0xc7,
+	0x11, 0xf7, 0x51, 0x7c, 0x0e, 0x29, 0x8a, 0x5e, 0x3d, 0x42, 0xd3, 0x4a, 0xe2, 0x5c, 0xd2, 0xc6,
+	0x15, 0x6c, 0xc9, 0x51, 0x12, 0x23, 0x8f, 0x22, 0x88, 0x2c, 0x31, 0xb0, 0x61, 0x49, 0x56, 0x97,
+	0x72, 0x80, 0x3e, 0x50, 0x62, 0x49, 0xae, 0xc8, 0x83, 0xef, 0x6e, 0xaf, 0xb7, 0x4b, 0x19, 0x2c,
+	0xfa, 0x01, 0x8a, 0x02, 0xfd, 0xab, 0xdf, 0xa0, 0x05, 0xfa, 0x31, 0xfa, 0xc1, 0xfa, 0x57, 0xb1,
+	0xaf, 0x7b, 0x90, 0xa2, 0xad, 0xca, 0xfe, 0xeb, 0x6e, 0x67, 0x67, 0x67, 0xe6, 0x37, 0x3b, 0x33,
+	0x3b, 0xbb, 0xb0, 0x19, 0x90, 0xe1, 0xc4, 0x0b, 0xe9, 0x9e, 0xf9, 0xee, 0x46, 0x31, 0x13, 0x0c,
+	0x55, 0xcc, 0xb0, 0x73, 0x77, 0xcc, 0xd8, 0xd8, 0xa7, 0x7b, 0x8a, 0x3c, 0x98, 0x5e, 0xec, 0xd1,
+	0x20, 0x12, 0x33, 0xcd, 0xd5, 0xf9, 0x78, 0x7e, 0x52, 0x78, 0x01, 0xe5, 0x82, 0x04, 0x91, 0x61,
+	0x58, 0x1f, 0xb2, 0x20, 0x60, 0xe1, 0x9e, 0xfe, 0x68, 0xa2, 0xfb, 0x04, 0x9a, 0x98, 0x0e, 0x18,
+	0x13, 0x98, 0xf2, 0x88, 0x85, 0x9c, 0xa2, 0x47, 0x50, 0x0d, 0xa8, 0x20, 0x23, 0x22, 0x48, 0xdb,
+	0xb9, 0xe7, 0xdc, 0xaf, 0xef, 0x6f, 0xec, 0x9a, 0x25, 0xa7, 0x6c, 0x44, 0x4f, 0xcc, 0x1c, 0x4e,
+	0xb8, 0xdc, 0x27, 0x50, 0xb7, 0x32, 0x22, 0x7f, 0x86, 0xbe, 0x84, 0x6a, 0x6c, 0x84, 0xb5, 0x9d,
+	0x7b, 0x2b, 0xf7, 0xeb, 0xfb, 0x1f, 0xec, 0x5a, 0x40, 0x79, 0x5d, 0x38, 0x61, 0x74, 0xc7, 0xd0,
+	0xc0, 0x94, 0x53, 0x81, 0xe9, 0x9f, 0xa6, 0x94, 0x0b, 0xb4, 0x01, 0xa5, 0x0b, 0x16, 0x0f, 0xa9,
+	0x32, 0xa1, 0x8a, 0xf5, 0x00, 0x6d, 0x41, 0x39, 0x56, 0x12, 0xda, 0x05, 0x45, 0x36, 0x23, 0xf4,
+	0x2b, 0x28, 0xf1, 0x21, 0x8b, 0x68, 0x7b, 0xe5, 0x9e, 0x73, 0xbf, 0xb9, 0xbf, 0x9e, 0xd1, 0xc7,
+	0xa9, 0xe8, 0xc9, 0x29, 0xac, 0x39, 0xdc, 0x03, 0x58, 0x35, 0x8a, 0x6e, 0x8c, 0xf7, 0x47, 0x00,
+	0x23, 0x42, 0xc2, 0xdd, 0x5f, 0x80, 0xbb, 0x95, 0x57, 0x7f, 0x05, 0xda, 0x23, 0x68, 0x61, 0xe6,
+	0xfb, 0x03, 0x32, 0x7c, 0xf5, 0x0e, 0x76, 0xfc, 0x04, 0xab, 0xa9, 0x14, 0x69, 0xca, 0xd7, 0x0b,
+	0xa6, 0xdc, 0x49, 0x4d, 0x99, 0xd3, 0x97, 0xb7, 0xa6, 0x37, 0x99, 0x8a, 0x11, 0x7b, 0x1d, 0xbe,
+	0x9b, 0x35, 0xa9, 0x94, 0xb7, 0x59, 0x33, 0xaf, 0x2f, 0x63, 0x0d, 0x86, 0xe6, 0xcb, 0x68, 0x1c,
+	0x93, 0x11, 0xcd, 0xc4, 0x82, 0x17, 0x90, 0xb1, 0x8e, 0x85, 0x1a, 0xd6, 0x83, 0x34, 0x42, 0x0a,
+	0xd9, 0x08, 0xd9, 0x80, 0x12, 0x17, 0x64, 0xac, 0x23, 0xa1, 0x8a, 0xf5, 0xc0, 0x7d, 0x09, 0x6b,
+	0x89, 0xcc, 0x9b, 0x02, 0x44, 0x2d, 0x58, 0x21, 0xc3, 0x57, 0x4a, 0x5d, 0x0d, 0xcb, 0x5f, 0xf7,
+	0x08, 0x1a, 0x89, 0x58, 0x89, 0xf8, 0xab, 0x05, 0xc4, 0xed, 0x04, 0xf1, 0x9c, 0xfe, 0x0c, 0xe0,
+	0x19, 0xac, 0xf7, 0x68, 0x7c, 0xe9, 0x0d, 0xe9, 0xb1, 0xc7, 0xdf, 0x21, 0x2e, 0xe5, 0x0a, 0xae,
+	0x05, 0xf1, 0x76, 0x41, 0xa9, 0xdf, 0x48, 0x1d, 0xae, 0x27, 0x9e, 0x85, 0x17, 0x0c, 0x27, 0x5c,
+	0xee, 0x31, 0xb4, 0x72, 0xaa, 0x25, 0x88, 0x6f, 0x16, 0x40, 0x6c, 0xcf, 0x4b, 0xc9, 0xda, 0x99,
+	0x01, 0xf2, 0x0f, 0x07, 0xea, 0x19, 0x3d, 0xa8, 0x09, 0x05, 0x6f, 0x64, 0x36, 0xad, 0xe0, 0x8d,
+	0xcc, 0xde, 0x08, 0x6a, 0x5c, 0xa8, 0x07, 0x68, 0x17, 0xca, 0xf4, 0x92, 0x86, 0x82, 0xab, 0x2d,
+	0xcb, 0x66, 0x8f, 0x91, 0xd5, 0x55, 0xb3, 0xd8, 0x70, 0x49, 0xfe, 0x09, 0x25, 0xbe, 0x98, 0xb4,
+	0x8b, 0x57, 0xf3, 0x3f, 0x55, 0xb3, 0xd8, 0x70, 0xb9, 0x3f, 0xc0, 0x6a, 0x4e, 0x10, 0x7a, 0x98,
+	0x28, 0xd4, 0xf0, 0x36, 0xaf, 0x54, 0x68, 0xf5, 0xb9, 0x03, 0x68, 0x64, 0xe9, 0x32, 0x0c, 0x02,
+	0x3e, 0x36, 0xb0, 0xe4, 0xef, 0x12, 0x5c, 0x3b, 0x50, 0x48, 0x30, 0x75, 0x76, 0x75, 0x71, 0xde,
+	0xb5, 0xc5, 0x79, 0xf7, 0xdc, 0x16, 0x67, 0x5c, 0x10, 0xdc, 0xfd, 0x97, 0x03, 0xab, 0x39, 0xeb,
+	0x51, 0x1b, 0x2a, 0xd3, 0xf0, 0x55, 0xc8, 0x5e, 0x87, 0xa6, 0x02, 0xda, 0xa1, 0x9c, 0xd1, 0xc8,
+	0x66, 0x26, 0xf2, 0xed, 0x10, 0x7d, 0x02, 0x0d, 0x9f, 0x70, 0xd1, 0x0f, 0x28, 0xe7, 0x36, 0x05,
+	0x6a, 0xb8, 0x2e, 0x69, 0x27, 0x9a, 0x84, 0xbe, 0x07, 0x35, 0xec, 0x0f, 0x27, 0x24, 0x1c, 0xd3,
+	0x76, 0xf1, 0xad, 0xd6, 0x81, 0x64, 0x3f, 0x54, 0xdc, 0xee, 0x2f, 0x92, 0x40, 0xed, 0x09, 0x12,
+	0x27, 0xa5, 0x7a, 0x6e, 0x9b, 0xdd, 0x3f, 0xc0, 0x46, 0x9e, 0xed, 0xc6, 0x01, 0x8d, 0xa0, 0x28,
+	0x83, 0xcb, 0xf8, 0x55, 0xfd, 0xbb, 0xa7, 0x70, 0x3b, 0x2f, 0x5d, 0xc6, 0xec, 0xb7, 0x0b, 0x31,
+	0xfb, 0xe1, 0xfc, 0xa6, 0xe6, 0x6c, 0xc9, 0x04, 0xed, 0x67, 0x80, 0x12, 0x0e, 0x16, 0x2d, 0xc3,
+	0xf4, 0x7b, 0x58, 0xcf, 0x71, 0xbd, 0x57, 0x48, 0x69, 0x16, 0x6a, 0xe1, 0xd7, 0xcc, 0xc2, 0xac,
+	0x25, 0x19, 0x40, 0x9f, 0xc3, 0xa6, 0x61, 0xc0, 0x94, 0x6b, 0xd0, 0x57, 0x63, 0xfa, 0x23, 0x6c,
+	0xcd, 0x33, 0xbe, 0x57, 0x58, 0x18, 0xd6, 0xe7, 0xe5, 0x4b, 0x64, 0xdf, 0x2f, 0x20, 0xfb, 0x78,
+	0x1e, 0xd9, 0x9c, 0x3d, 0x19, 0x70, 0x2e, 0x34, 0xde, 0x14, 0x7b, 0xdf, 0x15, 0xda, 0x8e, 0xfb,
+	0x19, 0x40, 0x26, 0x34, 0xac, 0x65, 0x4e, 0x6a, 0x99, 0xe2, 0xfa, 0x04, 0xea, 0x6f, 0xd8, 0x70,
+	0xc5, 0xf2, 0x29, 0xd4, 0xd2, 0x0d, 0x59, 0x26, 0x87, 0xc1, 0x6a, 0x4f, 0xc4, 0x94, 0x04, 0x5e,
+	0x38, 0x3e, 0x92, 0xae, 0xd8, 0x80, 0xd2, 0x60, 0x26, 0x28, 0x57, 0x9c, 0x0d, 0xac, 0x07, 0xb2,
+	0x73, 0xa1, 0x71, 0xcc, 0x62, 0x6e, 0x5c, 0x64, 0x46, 0x39, 0x57, 0xaf, 0x5c, 0xeb, 0x9c, 0x7d,
+	0x08, 0xcd, 0x43, 0x16, 0xcd, 0x5e, 0x4c, 0x13, 0x27, 0xdc, 0x85, 0x5a, 0xcc, 0x98, 0xe8, 0x47,
+	0x44, 0x4c, 0x8c, 0x7d, 0x55, 0x49, 0x38, 0x23, 0x62, 0xe2, 0x0e, 0xa0, 0x76, 0xdc, 0xb3, 0x9c,
+	0x12, 0x84, 0xec, 0x9e, 0x2c, 0x08, 0xd9, 0x3b, 0xb5, 0xa1, 0x12, 0xd3, 0xe1, 0x34, 0xe6, 0xf6,
+	0x24, 0xb5, 0x43, 0xf4, 0x39, 0xac, 0xe9, 0x5f, 0x8f, 0x85, 0xfd, 0x11, 0x8d, 0xc4, 0x44, 0x99,
+	0x58, 0xc2, 0xcd, 0x84, 0x7c, 0x24, 0xa9, 0xee, 0x7f, 0x1d, 0xa8, 0xfe, 0xe4, 0xf9, 0xba, 0xea,
+	0x23, 0x28, 0x86, 0x24, 0xb0, 0x87, 0xb5, 0xfa, 0x97, 0x34, 0xee, 0xfd, 0x59, 0x2b, 0x58, 0xc1,
+	0xea, 0x5f, 0xd2, 0x02, 0x36, 0xd2, 0x55, 0x6a, 0x15, 0xab, 0x7f, 0xd4, 0x81, 0x6a, 0xc0, 0x46,
+	0xde, 0x85, 0x47, 0x47, 0xaa, 0x36, 0xad, 0xe0, 0x64, 0x8c, 0x36, 0xa1, 0xec, 0xf1, 0xfe, 0xc8,
+	0x8b, 0xdb, 0x25, 0x7d, 0xb4, 0x7b, 0xfc, 0xc8, 0x8b, 0xa5, 0xbb, 0x95, 0x2b, 0xdb, 0x65, 0x5d,
+	0x7c, 0xd5, 0x40, 0x0a, 0xf7, 0xbd, 0xf0, 0x55, 0xbb, 0xa2, 0x8d, 0x90, 0xff, 0xe8, 0x53, 0x58,
+	0x8d, 0xa9, 0x4f, 0x84, 0x77, 0x49, 0xfb, 0xca, 0xc2, 0xaa, 0x9a, 0x6c, 0x58, 0xe2, 0xa9, 0xb4,
+	0x34, 0xbb, 0x1f, 0xb5, 0x6b, 0xed, 0x87, 0x0f, 0xcd, 0x13, 0x36, 0x95, 0x27, 0xd4, 0xcd, 0xd3,
+	0xe7, 0xbe, 0x3e, 0x41, 0xec, 0xb1, 0x8d, 0x92, 0x84, 0x50, 0x92, 0x7b, 0x82, 0x08, 0x7d, 0xaa,
+	0x70, 0xd9, 0x6b, 0x5b, 0x6d, 0x6f, 0xeb, 0xb5, 0xf3, 0x56, 0x65, 0x92, 0xe8, 0x2f, 0x50, 0x4b,
+	0xe4, 0xa2, 0x8f, 0x00, 0x2e, 0x3c, 0x9f, 0xf2, 0x19, 0x17, 0x34, 0x30, 0x9b, 0x96, 0xa1, 0xe4,
+	0xb6, 0xae, 0x68, 0xb6, 0x6e, 0x1b, 0x6a, 0xe4, 0x92, 0x78, 0x3e, 0x19, 0xf8, 0x7a, 0xff, 0x8a,
+	0x38, 0x25, 0xa0, 0x0f, 0x01, 0x02, 0x29, 0x9e, 0x8e, 0xfa, 0x2c, 0x54, 0xdb, 0x58, 0xc3, 0x35,
+	0x43, 0x79, 0x11, 0xba, 0xff, 0x76, 0x60, 0xed, 0x67, 0xaa, 0xa2, 0xe7, 0x1d, 0x3c, 0xb6, 0x0b,
+	0x95, 0x4b, 0x2d, 0xa4, 0x5d, 0x30, 0x0b, 0x2c, 0x6e, 0x23, 0x5c, 0xb5, 0x3a, 0x96, 0x09, 0x7d,
+	0x01, 0xd5, 0xc8, 0x27, 0xe2, 0x82, 0xc5, 0x81, 0xc9, 0xb3, 0xf4, 0xd8, 0x3f, 0x33, 0x13, 0x6a,
+	0x45, 0xc2, 0x26, 0xbb, 0xbb, 0xc4, 0xce, 0xb7, 0x75, 0x77, 0x73, 0x80, 0x32, 0xce, 0xfe, 0x9b,
+	0x03, 0xf5, 0x8c, 0x45, 0xb2, 0x7d, 0x10, 0x24, 0x69, 0x1f, 0x04, 0x19, 0x4b, 0x0a, 0x9f, 0x10,
+	0xdb, 0x57, 0xf2, 0x89, 0x2e, 0x21, 0x53, 0xcf, 0x17, 0xe6, 0x04, 0xd7, 0x03, 0xe9, 0xd7, 0x31,
+	0xeb, 0x5b, 0xd4, 0xc6, 0xaf, 0x63, 0x66, 0x84, 0xcb, 0x0a, 0xc6, 0xb8, 0xca, 0x8d, 0x1a, 0x2e,
+	0x30, 0x2e, 0x37, 0x8e, 0xc4, 0xc3, 0x89, 0xc9, 0x0b, 0xf5, 0xef, 0x3e, 0x86, 0x46, 0x16, 0xec,
+	0xb2, 0x5c, 0x55, 0x79, 0x69, 0x4a, 0xb9, 0xfc, 0x97, 0xfd, 0x49, 0xfd, 0x98, 0x8d, 0xb9, 0xad,
+	0x23, 0xdb, 0x50, 0x93, 0xbc, 0x3c, 0x22, 0x43, 0xbb, 0x38, 0x25, 0x98, 0x5a, 0x5a, 0x48, 0xfa,
+	0xbe, 0x3d, 0x28, 0x8f, 0x62, 0xef, 0x92, 0xc6, 0xe6, 0x7a, 0xf6, 0x81, 0xdd, 0xdb, 0x43, 0x16,
+	0x0a, 0xe2, 0x85, 0x34, 0x3e, 0x52, 0xd3, 0xd8, 0xb0, 0xc9, 0x62, 0x79, 0xc1, 0x7c, 0x9f, 0xbd,
+	0x56, 0x28, 0xab, 0xd8, 0x8c, 0xa4, 0x07, 0x04, 0xf1, 0xfc, 0xbe, 0xef, 0x85, 0x54, 0x43, 0x2d,
+	0xe1, 0x9a, 0xa4, 0x1c, 0x4b, 0x82, 0xbb, 0x06, 0xab, 0xa6, 0x57, 0xd4, 0x66, 0xba, 0xff, 0x2c,
+	0x40, 0x49, 0x51, 0x6e, 0x10, 0x60, 0xba, 0x7d, 0x2b, 0x5c, 0xa7, 0x7d, 0x43, 0xbf, 0x84, 0xa2,
+	0x98, 0x25, 0xb7, 0xcf, 0x34, 0x7b, 0x95, 0xee, 0xf3, 0x59, 0x44, 0xb1, 0x9a, 0x97, 0x57, 0x45,
+	0x2e, 0x4d, 0x0b, 0x87, 0xf4, 0x8a, 0xe6, 0x55, 0x4f, 0xa8, 0x35, 0x38, 0xe1, 0x93, 0x57, 0xdb,
+	0x68, 0x42, 0x38, 0x55, 0x70, 0xeb, 0x99, 0xab, 0xed, 0x99, 0xa4, 0x6a, 0x6e, 0xcd, 0x81, 0xbe,
+	0x82, 0x8a, 0xe9, 0xec, 0xdb, 0x65, 0x63, 0xf7, 0x62, 0x13, 0x24, 0xcc, 0x1a, 0xcb, 0xaa, 0x0e,
+	0xb0, 0xac, 0x6e, 0x59, 0x84, 0x13, 0x2b, 0xcd, 0x69, 0x92, 0x58, 0xf3, 0x00, 0xca, 0x64, 0x28,
+	0x6c, 0xd6, 0x35, 0x33, 0x59, 0xa7, 0xd6, 0x1e, 0xa8, 0x39, 0x6c, 0x78, 0xd2, 0xda, 0xbc, 0x92,
+	0xa9, 0xcd, 0xee, 0x05, 0x40, 0x6a, 0xbb, 0xe4, 0xd1, 0xf8, 0xcc, 0xe5, 0x4e, 0x43, 0x79, 0x1f,
+	0x7a, 0xfe, 0xee, 0xc0, 0xed, 0x05, 0xdc, 0xf2, 0xb8, 0xb3, 0x4e, 0xd2, 0x1a, 0xed, 0x70, 0x49,
+	0x1b, 0xdf, 0x86, 0x4a, 0xbe, 0x9f, 0xb6, 0xc3, 0xff, 0xf7, 0x22, 0xb2, 0xf3, 0x00, 0x20, 0x7d,
+	0x8e, 0x40, 0xab, 0x50, 0xeb, 0x9e, 0x3d, 0xed, 0x9e, 0x74, 0xf1, 0xc1, 0x71, 0xeb, 0x16, 0x5a,
+	0x83, 0x7a, 0xef, 0xb7, 0xbd, 0xf3, 0xee, 0x49, 0xff, 0xe8, 0x59, 0xef, 0x79, 0xcb, 0xd9, 0xf9,
+	0x02, 0x6a, 0x49, 0xf8, 0xa0, 0x06, 0x54, 0x7b, 0xdd, 0xdf, 0xbc, 0xec, 0x9e, 0x1e, 0x76, 0x5b,
+	0xb7, 0x50, 0x0d, 0x4a, 0x67, 0x4f, 0x0f, 0x7a, 0xdd, 0x96, 0x83, 0xea, 0x50, 0xe9, 0x75, 0xf1,
+	0xcf, 0xcf, 0x0e, 0xbb, 0xad, 0xc2, 0x8e, 0x0b, 0xf5, 0x8c, 0x77, 0x24, 0x5b, 0xef, 0xfc, 0x00,
+	0x9f, 0xb7, 0x6e, 0xa1, 0x2a, 0x14, 0x7b, 0xe7, 0x2f, 0xce, 0x5a, 0xce, 0xfe, 0x7f, 0xaa, 0x50,
+	0x39, 0xd1, 0x66, 0xa2, 0x5f, 0x43, 0xc5, 0x74, 0x12, 0x28, 0x3d, 0x35, 0xf2, 0xbd, 0x45, 0x27,
+	0x03, 0x2a, 0xdb, 0xe5, 0x3c, 0x72, 0xd0, 0x3e, 0x94, 0xcd, 0x85, 0x6a, 0x2b, 0xbf, 0x39, 0x36,
+	0xfd, 0x3a, 0xcd, 0x3c, 0xfd, 0x91, 0x83, 0x7e, 0x00, 0x78, 0x3e, 0x1d, 0xd0, 0x21, 0x0b, 0x2f,
+	0xbc, 0x31, 0xda, 0x5a, 0x48, 0xab, 0xae, 0x7c, 0xcf, 0x7a, 0x83, 0xce, 0x87, 0x50, 0x38, 0xee,
+	0xa1, 0x34, 0xc1, 0x92, 0xce, 0xa6, 0x73, 0x3b, 0xa1, 0xd9, 0x46, 0xe4, 0x91, 0x83, 0x1e, 0x40,
+	0x51, 0x56, 0x2d, 0x94, 0x46, 0x4f, 0xa6, 0x88, 0x75, 0x1a, 0xb6, 0x02, 0x18, 0xe1, 0x8f, 0xa1,
+	0xac, 0x8f, 0xcc, 0xa5, 0x86, 0x6d, 0x2c, 0x9c, 0xad, 0xf2, 0x5c, 0x78, 0x0c, 0x65, 0xfd, 0xac,
+	0x75, 0x8d, 0x75, 0xf9, 0x77, 0xb2, 0x92, 0x8a, 0x07, 0xb4, 0x39, 0xff, 0x5e, 0xa4, 0xed, 0x5b,
+	0x9f, 0x27, 0xcb, 0x45, 0xdf, 0x41, 0xd5, 0xbe, 0xe4, 0x5c, 0xc3, 0x7f, 0xf9, 0xe7, 0xa1, 0x1f,
+	0x93, 0xeb, 0xb9, 0xbc, 0xc0, 0x2f, 0x5d, 0x7e, 0xe7, 0xea, 0xeb, 0xbe, 0x94, 0x70, 0x0a, 0xcd,
+	0x7c, 0x8b, 0x8e, 0x3e, 0x5a, 0xda, 0xbb, 0x6b, 0x10, 0xdb, 0x4b, 0xe7, 0xa5, 0xbc, 0xa7, 0xc9,
+	0xdd, 0x5a, 0x75, 0xec, 0x68, 0x7b, 0xc9, 0xad, 0x4d, 0xcb, 0xea, 0x2c, 0x99, 0x95, 0x92, 0xba,
+	0x09, 0x36, 0xd9, 0xb2, 0xa3, 0xbb, 0x57, 0x5f, 0x96, 0xb4, 0x9c, 0x3b, 0x57, 0x4f, 0x1a, 0xf7,
+	0xda, 0xa7, 0xa9, 0xeb, 0x84, 0x67, 0xee, 0xbd, 0xeb, 0x5b, 0xa8, 0x98, 0x47, 0x9e, 0x4c, 0x3a,
+	0xe5, 0x9f, 0xb2, 0x3a, 0x9b, 0x8b, 0x13, 0xfa, 0xb6, 0x57, 0xd2, 0x0e, 0x48, 0xe7, 0x73, 0xc8,
+	0xd7, 0xe7, 0xc9, 0x91, 0x3f, 0x73, 0x57, 0xfe, 0x5a, 0x70, 0xd0, 0xd7, 0x50, 0x54, 0x80, 0x33,
+	0x2f, 0x3d, 0x19, 0xa4, 0x68, 0x8e, 0x9a, 0x2c, 0xfb, 0x06, 0x2a, 0xb6, 0x6f, 0x58, 0x06, 0x73,
+	0x73, 0xb1, 0xb9, 0x89, 0xfc, 0xd9, 0x93, 0xe7, 0xb0, 0x36, 0x64, 0x41, 0x32, 0x47, 0x22, 0xef,
+	0x09, 0x98, 0x82, 0x72, 0x10, 0x79, 0x67, 0xce, 0xef, 0x76, 0xc6, 0x9e, 0x98, 0x4c, 0x07, 0x32,
+	0xb9, 0xf6, 0x04, 0xf1, 0x19, 0x7f, 0xa8, 0x3b, 0x49, 0xae, 0x47, 0x7b, 0x24, 0xf2, 0xec, 0xfb,
+	0xf6, 0xa0, 0xac, 0x74, 0x7e, 0xf9, 0xbf, 0x01, 0x00, 0xe1, 0x1a, 0x9f, 0x84, 0xf9, 0x16, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2238,12 +2872,14 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type MachineClient interface {
 	CopyOut(ctx context.Context, in *CopyOutRequest, opts ...grpc.CallOption) (Machine_CopyOutClient, error)
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Machine_EventsClient, error)
 	Kubeconfig(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (Machine_KubeconfigClient, error)
 	LS(ctx context.Context, in *LSRequest, opts ...grpc.CallOption) (Machine_LSClient, error)
 	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Machine_LogsClient, error)
 	Mounts(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MountsReply, error)
 	Reboot(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RebootReply, error)
-	Reset(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ResetReply, error)
+	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetReply, error)
+	Rollback(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RollbackReply, error)
 	ServiceList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ServiceListReply, error)
 	ServiceRestart(ctx context.Context, in *ServiceRestartRequest, opts ...grpc.CallOption) (*ServiceRestartReply, error)
 	ServiceStart(ctx context.Context, in *ServiceStartRequest, opts ...grpc.CallOption) (*ServiceStartReply, error)
@@ -2295,8 +2931,40 @@ func (x *machineCopyOutClient) Recv() (*StreamingData, error) {
 	return m, nil
 }
 
+func (c *machineClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Machine_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[1], "/machine.Machine/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machineEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Machine_EventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type machineEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *machineEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *machineClient) Kubeconfig(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (Machine_KubeconfigClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[1], "/machine.Machine/Kubeconfig", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[2], "/machine.Machine/Kubeconfig", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2328,7 +2996,7 @@ func (x *machineKubeconfigClient) Recv() (*StreamingData, error) {
 }
 
 func (c *machineClient) LS(ctx context.Context, in *LSRequest, opts ...grpc.CallOption) (Machine_LSClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[2], "/machine.Machine/LS", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[3], "/machine.Machine/LS", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2360,7 +3028,7 @@ func (x *machineLSClient) Recv() (*FileInfo, error) {
 }
 
 func (c *machineClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Machine_LogsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[3], "/machine.Machine/Logs", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[4], "/machine.Machine/Logs", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2409,7 +3077,7 @@ func (c *machineClient) Reboot(ctx context.Context, in *empty.Empty, opts ...grp
 	return out, nil
 }
 
-func (c *machineClient) Reset(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ResetReply, error) {
+func (c *machineClient) Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetReply, error) {
 	out := new(ResetReply)
 	err := c.cc.Invoke(ctx, "/machine.Machine/Reset", in, out, opts...)
 	if err != nil {
@@ -2418,6 +3086,15 @@ func (c *machineClient) Reset(ctx context.Context, in *empty.Empty, opts ...grpc
 	return out, nil
 }
 
+func (c *machineClient) Rollback(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RollbackReply, error) {
+	out := new(RollbackReply)
+	err := c.cc.Invoke(ctx, "/machine.Machine/Rollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineClient) ServiceList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ServiceListReply, error) {
 	out := new(ServiceListReply)
 	err := c.cc.Invoke(ctx, "/machine.Machine/ServiceList", in, out, opts...)
@@ -2504,12 +3181,14 @@ func (c *machineClient) Version(ctx context.Context, in *empty.Empty, opts ...gr
 // MachineServer is the server API for Machine service.
 type MachineServer interface {
 	CopyOut(*CopyOutRequest, Machine_CopyOutServer) error
+	Events(*EventsRequest, Machine_EventsServer) error
 	Kubeconfig(*empty.Empty, Machine_KubeconfigServer) error
 	LS(*LSRequest, Machine_LSServer) error
 	Logs(*LogsRequest, Machine_LogsServer) error
 	Mounts(context.Context, *empty.Empty) (*MountsReply, error)
 	Reboot(context.Context, *empty.Empty) (*RebootReply, error)
-	Reset(context.Context, *empty.Empty) (*ResetReply, error)
+	Reset(context.Context, *ResetRequest) (*ResetReply, error)
+	Rollback(context.Context, *empty.Empty) (*RollbackReply, error)
 	ServiceList(context.Context, *empty.Empty) (*ServiceListReply, error)
 	ServiceRestart(context.Context, *ServiceRestartRequest) (*ServiceRestartReply, error)
 	ServiceStart(context.Context, *ServiceStartRequest) (*ServiceStartReply, error)
@@ -2546,6 +3225,27 @@ func (x *machineCopyOutServer) Send(m *StreamingData) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Machine_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MachineServer).Events(m, &machineEventsServer{stream})
+}
+
+type Machine_EventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type machineEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *machineEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Machine_Kubeconfig_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(empty.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -2646,7 +3346,7 @@ func _Machine_Reboot_Handler(srv interface{}, ctx context.Context, dec func(inte
 }
 
 func _Machine_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(ResetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -2658,7 +3358,25 @@ func _Machine_Reset_Handler(srv interface{}, ctx context.Context, dec func(inter
 		FullMethod: "/machine.Machine/Reset",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MachineServer).Reset(ctx, req.(*empty.Empty))
+		return srv.(MachineServer).Reset(ctx, req.(*ResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.Machine/Rollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).Rollback(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -2841,6 +3559,10 @@ var _Machine_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Reset",
 			Handler:    _Machine_Reset_Handler,
 		},
+		{
+			MethodName: "Rollback",
+			Handler:    _Machine_Rollback_Handler,
+		},
 		{
 			MethodName: "ServiceList",
 			Handler:    _Machine_ServiceList_Handler,
@@ -2884,6 +3606,11 @@ var _Machine_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Machine_CopyOut_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Events",
+			Handler:       _Machine_Events_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Kubeconfig",
 			Handler:       _Machine_Kubeconfig_Handler,
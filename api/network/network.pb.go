@@ -10,6 +10,7 @@ import (
 
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 
 	common "github.com/talos-systems/talos/api/common"
@@ -164,6 +165,144 @@ func (InterfaceFlags) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_96ad937ae012c472, []int{2}
 }
 
+// OperState is the operational state of an interface, see RFC2863
+type OperState int32
+
+const (
+	OperState_OPER_UNKNOWN          OperState = 0
+	OperState_OPER_NOT_PRESENT      OperState = 1
+	OperState_OPER_DOWN             OperState = 2
+	OperState_OPER_LOWER_LAYER_DOWN OperState = 3
+	OperState_OPER_TESTING          OperState = 4
+	OperState_OPER_DORMANT          OperState = 5
+	OperState_OPER_UP               OperState = 6
+)
+
+var OperState_name = map[int32]string{
+	0: "OPER_UNKNOWN",
+	1: "OPER_NOT_PRESENT",
+	2: "OPER_DOWN",
+	3: "OPER_LOWER_LAYER_DOWN",
+	4: "OPER_TESTING",
+	5: "OPER_DORMANT",
+	6: "OPER_UP",
+}
+
+var OperState_value = map[string]int32{
+	"OPER_UNKNOWN":          0,
+	"OPER_NOT_PRESENT":      1,
+	"OPER_DOWN":             2,
+	"OPER_LOWER_LAYER_DOWN": 3,
+	"OPER_TESTING":          4,
+	"OPER_DORMANT":          5,
+	"OPER_UP":               6,
+}
+
+func (x OperState) String() string {
+	return proto.EnumName(OperState_name, int32(x))
+}
+
+func (OperState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{3}
+}
+
+// LeaseState is the state of the client side of a DHCP lease, see RFC2131 section 4.4
+type LeaseState int32
+
+const (
+	LeaseState_LEASE_INIT       LeaseState = 0
+	LeaseState_LEASE_SELECTING  LeaseState = 1
+	LeaseState_LEASE_REQUESTING LeaseState = 2
+	LeaseState_LEASE_BOUND      LeaseState = 3
+	LeaseState_LEASE_RENEWING   LeaseState = 4
+	LeaseState_LEASE_REBINDING  LeaseState = 5
+	LeaseState_LEASE_RELEASED   LeaseState = 6
+)
+
+var LeaseState_name = map[int32]string{
+	0: "LEASE_INIT",
+	1: "LEASE_SELECTING",
+	2: "LEASE_REQUESTING",
+	3: "LEASE_BOUND",
+	4: "LEASE_RENEWING",
+	5: "LEASE_REBINDING",
+	6: "LEASE_RELEASED",
+}
+
+var LeaseState_value = map[string]int32{
+	"LEASE_INIT":       0,
+	"LEASE_SELECTING":  1,
+	"LEASE_REQUESTING": 2,
+	"LEASE_BOUND":      3,
+	"LEASE_RENEWING":   4,
+	"LEASE_REBINDING":  5,
+	"LEASE_RELEASED":   6,
+}
+
+func (x LeaseState) String() string {
+	return proto.EnumName(LeaseState_name, int32(x))
+}
+
+func (LeaseState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{4}
+}
+
+// WatchType is the type of the watched changes
+type WatchType int32
+
+const (
+	WatchType_LINK    WatchType = 0
+	WatchType_ADDRESS WatchType = 1
+	WatchType_ROUTE   WatchType = 2
+)
+
+var WatchType_name = map[int32]string{
+	0: "LINK",
+	1: "ADDRESS",
+	2: "ROUTE",
+}
+
+var WatchType_value = map[string]int32{
+	"LINK":    0,
+	"ADDRESS": 1,
+	"ROUTE":   2,
+}
+
+func (x WatchType) String() string {
+	return proto.EnumName(WatchType_name, int32(x))
+}
+
+func (WatchType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{5}
+}
+
+// WatchAction is the kind of a watched change
+type WatchAction int32
+
+const (
+	// Update is a new or changed link, address or route
+	WatchAction_UPDATE WatchAction = 0
+	WatchAction_DELETE WatchAction = 1
+)
+
+var WatchAction_name = map[int32]string{
+	0: "UPDATE",
+	1: "DELETE",
+}
+
+var WatchAction_value = map[string]int32{
+	"UPDATE": 0,
+	"DELETE": 1,
+}
+
+func (x WatchAction) String() string {
+	return proto.EnumName(WatchAction_name, int32(x))
+}
+
+func (WatchAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{6}
+}
+
 // The response message containing the routes.
 type RoutesReply struct {
 	Response             []*RoutesResponse `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
@@ -472,15 +611,18 @@ func (m *InterfacesResponse) GetInterfaces() []*Interface {
 
 // Interface represents a net.Interface
 type Interface struct {
-	Index                uint32         `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	Mtu                  uint32         `protobuf:"varint,2,opt,name=mtu,proto3" json:"mtu,omitempty"`
-	Name                 string         `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Hardwareaddr         string         `protobuf:"bytes,4,opt,name=hardwareaddr,proto3" json:"hardwareaddr,omitempty"`
-	Flags                InterfaceFlags `protobuf:"varint,5,opt,name=flags,proto3,enum=network.InterfaceFlags" json:"flags,omitempty"`
-	Ipaddress            []string       `protobuf:"bytes,6,rep,name=ipaddress,proto3" json:"ipaddress,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Index        uint32              `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Mtu          uint32              `protobuf:"varint,2,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Name         string              `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Hardwareaddr string              `protobuf:"bytes,4,opt,name=hardwareaddr,proto3" json:"hardwareaddr,omitempty"`
+	Flags        InterfaceFlags      `protobuf:"varint,5,opt,name=flags,proto3,enum=network.InterfaceFlags" json:"flags,omitempty"`
+	Ipaddress    []string            `protobuf:"bytes,6,rep,name=ipaddress,proto3" json:"ipaddress,omitempty"`
+	Addresses    []*InterfaceAddress `protobuf:"bytes,7,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// OperState is the operational state of the interface, e.g. whether its carrier is up
+	Operstate            OperState `protobuf:"varint,8,opt,name=operstate,proto3,enum=network.OperState" json:"operstate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *Interface) Reset()         { *m = Interface{} }
@@ -554,177 +696,1115 @@ func (m *Interface) GetIpaddress() []string {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("network.AddressFamily", AddressFamily_name, AddressFamily_value)
-	proto.RegisterEnum("network.RouteProtocol", RouteProtocol_name, RouteProtocol_value)
-	proto.RegisterEnum("network.InterfaceFlags", InterfaceFlags_name, InterfaceFlags_value)
-	proto.RegisterType((*RoutesReply)(nil), "network.RoutesReply")
-	proto.RegisterType((*RoutesResponse)(nil), "network.RoutesResponse")
-	proto.RegisterType((*Route)(nil), "network.Route")
-	proto.RegisterType((*InterfacesReply)(nil), "network.InterfacesReply")
-	proto.RegisterType((*InterfacesResponse)(nil), "network.InterfacesResponse")
-	proto.RegisterType((*Interface)(nil), "network.Interface")
+func (m *Interface) GetAddresses() []*InterfaceAddress {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
 }
 
-func init() { proto.RegisterFile("network/network.proto", fileDescriptor_96ad937ae012c472) }
+func (m *Interface) GetOperstate() OperState {
+	if m != nil {
+		return m.Operstate
+	}
+	return OperState_OPER_UNKNOWN
+}
 
-var fileDescriptor_96ad937ae012c472 = []byte{
-	// 830 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x4d, 0x6f, 0xeb, 0x44,
-	0x14, 0xc5, 0xf9, 0xce, 0x4d, 0x9d, 0x4c, 0xa7, 0xa5, 0xb5, 0xfa, 0x58, 0x44, 0x59, 0xa0, 0x2a,
-	0xd2, 0x4b, 0x50, 0x1e, 0x2a, 0x3b, 0x24, 0x3b, 0x71, 0x1e, 0x21, 0xa9, 0x6d, 0xa6, 0x2e, 0xa0,
-	0xb7, 0xa9, 0xa6, 0xf1, 0x24, 0xcf, 0x10, 0x7f, 0xc8, 0x76, 0x54, 0xc2, 0x0e, 0xf1, 0x4b, 0xf8,
-	0x05, 0x6c, 0xf9, 0x79, 0xc8, 0xe3, 0xb1, 0xeb, 0x10, 0xd8, 0xb0, 0xf2, 0xdc, 0x73, 0xce, 0xdc,
-	0x7b, 0x67, 0xce, 0xcc, 0x18, 0x3e, 0xf5, 0x59, 0xf2, 0x12, 0x44, 0x3f, 0x8f, 0xc5, 0x77, 0x14,
-	0x46, 0x41, 0x12, 0xe0, 0xa6, 0x08, 0x6f, 0xde, 0x6c, 0x83, 0x60, 0xbb, 0x63, 0x63, 0x0e, 0x3f,
-	0xef, 0x37, 0x63, 0xe6, 0x85, 0xc9, 0x21, 0x53, 0xdd, 0x5c, 0xac, 0x03, 0xcf, 0x0b, 0xfc, 0x71,
-	0xf6, 0xc9, 0xc0, 0x81, 0x06, 0x1d, 0x12, 0xec, 0x13, 0x16, 0x13, 0x16, 0xee, 0x0e, 0xf8, 0x1d,
-	0xb4, 0x22, 0x16, 0x87, 0x81, 0x1f, 0x33, 0x45, 0xea, 0x57, 0x6f, 0x3b, 0x93, 0xeb, 0x51, 0x5e,
-	0x2b, 0xd7, 0x65, 0x34, 0x29, 0x84, 0x83, 0x9f, 0xa0, 0x7b, 0xcc, 0xe1, 0x2f, 0xa0, 0xe5, 0xb1,
-	0x84, 0x3a, 0x34, 0xa1, 0x8a, 0xd4, 0x97, 0x6e, 0x3b, 0x93, 0xcb, 0x91, 0x28, 0x6b, 0x04, 0x0e,
-	0xbb, 0x17, 0x1c, 0x29, 0x54, 0xf8, 0x73, 0x68, 0x44, 0x3c, 0x87, 0x52, 0xe1, 0x65, 0xbb, 0xc7,
-	0x65, 0x89, 0x60, 0x07, 0x7f, 0x54, 0xa0, 0xce, 0x11, 0xfc, 0x19, 0xb4, 0x5d, 0x3f, 0x61, 0xd1,
-	0x86, 0xae, 0x19, 0x2f, 0xd2, 0x26, 0xaf, 0x00, 0xee, 0x43, 0xc7, 0x61, 0x71, 0xe2, 0xfa, 0x34,
-	0x71, 0x03, 0x5f, 0xa9, 0x70, 0xbe, 0x0c, 0x61, 0x05, 0x9a, 0x5b, 0x9a, 0xb0, 0x17, 0x7a, 0x50,
-	0xaa, 0x9c, 0xcd, 0x43, 0x7c, 0x05, 0x0d, 0x8f, 0x25, 0x91, 0xbb, 0x56, 0x6a, 0x7d, 0xe9, 0x56,
-	0x26, 0x22, 0xc2, 0x97, 0x50, 0x8f, 0xd7, 0x41, 0xc8, 0x94, 0x3a, 0x87, 0xb3, 0x20, 0x55, 0xc7,
-	0xc1, 0x3e, 0x5a, 0x33, 0xa5, 0xc1, 0xd3, 0x88, 0x08, 0x8f, 0xa0, 0xb1, 0xa1, 0x9e, 0xbb, 0x3b,
-	0x28, 0xcd, 0xbe, 0x74, 0xdb, 0x9d, 0x5c, 0x15, 0x2b, 0x52, 0x1d, 0x27, 0x62, 0x71, 0x3c, 0xe7,
-	0x2c, 0x11, 0x2a, 0x3c, 0x81, 0x16, 0xb7, 0x64, 0x1d, 0xec, 0x94, 0xd6, 0x3f, 0x66, 0xf0, 0x15,
-	0x5b, 0x82, 0x25, 0x85, 0x2e, 0xed, 0x68, 0xb3, 0xa3, 0xdb, 0x58, 0x69, 0x67, 0x1d, 0xf1, 0x60,
-	0xf0, 0x2d, 0xf4, 0x16, 0xf9, 0x46, 0x08, 0x5f, 0xbf, 0x3a, 0xf1, 0xf5, 0x4d, 0x91, 0xbc, 0xac,
-	0x3d, 0xf1, 0xf6, 0x57, 0xc0, 0xa7, 0xfc, 0xff, 0xf0, 0x77, 0x02, 0x50, 0x98, 0x93, 0x7b, 0x8c,
-	0x4f, 0x5b, 0x20, 0x25, 0xd5, 0xe0, 0x2f, 0x09, 0xda, 0x05, 0x93, 0xae, 0xd5, 0xf5, 0x1d, 0xf6,
-	0x0b, 0x2f, 0x28, 0x93, 0x2c, 0xc0, 0x08, 0xaa, 0x5e, 0xb2, 0xe7, 0xfe, 0xca, 0x24, 0x1d, 0x62,
-	0x0c, 0x35, 0x9f, 0x7a, 0x4c, 0x98, 0xca, 0xc7, 0x78, 0x00, 0x67, 0x1f, 0x69, 0xe4, 0xbc, 0xd0,
-	0x88, 0x51, 0xc7, 0x89, 0xb8, 0xaf, 0x6d, 0x72, 0x84, 0xe1, 0xb7, 0xf9, 0x5e, 0xd6, 0xf9, 0xe6,
-	0x5f, 0x9f, 0x36, 0x37, 0x4f, 0x69, 0xb1, 0xc9, 0xfc, 0xf8, 0x85, 0x34, 0x73, 0x52, 0x69, 0xf4,
-	0xab, 0xfc, 0xf8, 0xe5, 0xc0, 0xf0, 0x3b, 0x90, 0x8f, 0x5c, 0xc6, 0x32, 0xb4, 0xd5, 0xf9, 0xd3,
-	0xa3, 0xf1, 0x60, 0xe9, 0x53, 0xf4, 0x09, 0xee, 0x40, 0x53, 0x9d, 0x3f, 0x2d, 0x0c, 0xdd, 0x46,
-	0x15, 0xdc, 0x82, 0xda, 0xc2, 0xfa, 0xfe, 0x4b, 0x54, 0xc1, 0x67, 0xd0, 0x12, 0xf0, 0x1d, 0x02,
-	0x81, 0xdf, 0x21, 0xb8, 0xa9, 0x20, 0x69, 0xf8, 0x67, 0x05, 0xe4, 0xa3, 0x73, 0x80, 0xcf, 0x41,
-	0x26, 0xb6, 0x45, 0x4c, 0xfb, 0x35, 0xef, 0x05, 0xf4, 0x04, 0x44, 0xf4, 0xd9, 0x82, 0xe8, 0x53,
-	0x1b, 0x49, 0x25, 0xdd, 0x52, 0x27, 0x86, 0xbe, 0x42, 0x15, 0xdc, 0x83, 0x8e, 0x80, 0x34, 0xd3,
-	0xb4, 0x51, 0xb5, 0xa4, 0x79, 0xb0, 0x55, 0x7b, 0x31, 0x45, 0x35, 0x8c, 0xe0, 0x4c, 0x40, 0xef,
-	0x55, 0x5b, 0x9f, 0xa1, 0x56, 0xba, 0x88, 0x3c, 0xbb, 0x8a, 0xda, 0xb8, 0x0b, 0x20, 0xc2, 0x7b,
-	0x62, 0x23, 0x28, 0x4d, 0xf8, 0xa0, 0x6b, 0x44, 0x45, 0x9d, 0x72, 0x99, 0x05, 0x99, 0xa1, 0xb3,
-	0x52, 0x7f, 0x33, 0x83, 0x98, 0x8f, 0x69, 0x5a, 0xb9, 0xa4, 0xfa, 0xd1, 0x24, 0x16, 0xea, 0x96,
-	0x12, 0x1b, 0xf6, 0x12, 0xf5, 0x4a, 0x82, 0xd9, 0x37, 0x53, 0x0b, 0x21, 0x8c, 0xa1, 0x5b, 0x54,
-	0xce, 0xb2, 0x9c, 0x97, 0xaa, 0x6b, 0xaa, 0xa6, 0xaf, 0xd0, 0x70, 0xf8, 0xbb, 0x04, 0xdd, 0x63,
-	0xf3, 0x52, 0xd1, 0x7c, 0xa5, 0xbe, 0x7f, 0x7a, 0x34, 0x96, 0x86, 0xf9, 0x83, 0x91, 0x39, 0x91,
-	0x21, 0x16, 0x92, 0xd2, 0xbc, 0x3c, 0xd0, 0x88, 0xa9, 0xce, 0xa6, 0xea, 0x43, 0xea, 0xce, 0x39,
-	0xc8, 0x1c, 0x5b, 0x99, 0xa6, 0xa5, 0xa9, 0xd3, 0x25, 0xaa, 0xe2, 0x6b, 0xb8, 0xe0, 0x90, 0x65,
-	0x2e, 0x0c, 0xfb, 0xc9, 0x36, 0xb3, 0x01, 0xaa, 0x15, 0xf3, 0xef, 0x1f, 0x57, 0xf6, 0x82, 0xcf,
-	0xaf, 0x4f, 0x7e, 0x93, 0xa0, 0x69, 0x64, 0x47, 0x09, 0xdf, 0x41, 0x23, 0x7b, 0x29, 0xf1, 0xd5,
-	0x28, 0x7b, 0xaa, 0x47, 0xf9, 0x53, 0x3d, 0xd2, 0xd3, 0xa7, 0xfa, 0xe6, 0xf2, 0xe4, 0xb9, 0x4d,
-	0xaf, 0xef, 0xd7, 0x00, 0xaf, 0xb7, 0xf0, 0x3f, 0xe7, 0x2a, 0xff, 0x7a, 0xa5, 0xc3, 0xdd, 0x41,
-	0x5b, 0x42, 0x6f, 0x1d, 0x78, 0x05, 0x4d, 0x43, 0x57, 0x03, 0xd1, 0x93, 0x1a, 0xba, 0x96, 0xf4,
-	0x61, 0xb8, 0x75, 0x93, 0x8f, 0xfb, 0xe7, 0xf4, 0x12, 0x8f, 0x13, 0xba, 0x0b, 0xe2, 0xb7, 0xf1,
-	0x21, 0x4e, 0x98, 0x17, 0x67, 0xd1, 0x98, 0x86, 0x6e, 0xfe, 0xcf, 0x79, 0x6e, 0xf0, 0xb2, 0xef,
-	0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xf3, 0xb1, 0x46, 0x21, 0x8d, 0x06, 0x00, 0x00,
+// InterfaceAddress represents an address configured on an interface
+type InterfaceAddress struct {
+	// Address is the address in CIDR notation
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Family is the address family of the address, either AF_INET (IPV4) or AF_INET6 (IPV6)
+	Family               AddressFamily `protobuf:"varint,2,opt,name=family,proto3,enum=network.AddressFamily" json:"family,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *InterfaceAddress) Reset()         { *m = InterfaceAddress{} }
+func (m *InterfaceAddress) String() string { return proto.CompactTextString(m) }
+func (*InterfaceAddress) ProtoMessage()    {}
+func (*InterfaceAddress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{6}
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *InterfaceAddress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InterfaceAddress.Unmarshal(m, b)
+}
 
-// NetworkClient is the client API for Network service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NetworkClient interface {
-	Routes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RoutesReply, error)
-	Interfaces(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*InterfacesReply, error)
+func (m *InterfaceAddress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InterfaceAddress.Marshal(b, m, deterministic)
 }
 
-type networkClient struct {
-	cc *grpc.ClientConn
+func (m *InterfaceAddress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InterfaceAddress.Merge(m, src)
 }
 
-func NewNetworkClient(cc *grpc.ClientConn) NetworkClient {
-	return &networkClient{cc}
+func (m *InterfaceAddress) XXX_Size() int {
+	return xxx_messageInfo_InterfaceAddress.Size(m)
 }
 
-func (c *networkClient) Routes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RoutesReply, error) {
-	out := new(RoutesReply)
-	err := c.cc.Invoke(ctx, "/network.Network/Routes", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *InterfaceAddress) XXX_DiscardUnknown() {
+	xxx_messageInfo_InterfaceAddress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InterfaceAddress proto.InternalMessageInfo
+
+func (m *InterfaceAddress) GetAddress() string {
+	if m != nil {
+		return m.Address
 	}
-	return out, nil
+	return ""
 }
 
-func (c *networkClient) Interfaces(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*InterfacesReply, error) {
-	out := new(InterfacesReply)
-	err := c.cc.Invoke(ctx, "/network.Network/Interfaces", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *InterfaceAddress) GetFamily() AddressFamily {
+	if m != nil {
+		return m.Family
 	}
-	return out, nil
+	return AddressFamily_AF_UNSPEC
 }
 
-// NetworkServer is the server API for Network service.
-type NetworkServer interface {
-	Routes(context.Context, *empty.Empty) (*RoutesReply, error)
-	Interfaces(context.Context, *empty.Empty) (*InterfacesReply, error)
+// The response message containing the DHCP leases.
+type LeasesReply struct {
+	Response             []*LeasesResponse `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func RegisterNetworkServer(s *grpc.Server, srv NetworkServer) {
-	s.RegisterService(&_Network_serviceDesc, srv)
+func (m *LeasesReply) Reset()         { *m = LeasesReply{} }
+func (m *LeasesReply) String() string { return proto.CompactTextString(m) }
+func (*LeasesReply) ProtoMessage()    {}
+func (*LeasesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{7}
 }
 
-func _Network_Routes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *LeasesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeasesReply.Unmarshal(m, b)
+}
+
+func (m *LeasesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeasesReply.Marshal(b, m, deterministic)
+}
+
+func (m *LeasesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeasesReply.Merge(m, src)
+}
+
+func (m *LeasesReply) XXX_Size() int {
+	return xxx_messageInfo_LeasesReply.Size(m)
+}
+
+func (m *LeasesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeasesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeasesReply proto.InternalMessageInfo
+
+func (m *LeasesReply) GetResponse() []*LeasesResponse {
+	if m != nil {
+		return m.Response
 	}
-	if interceptor == nil {
-		return srv.(NetworkServer).Routes(ctx, in)
+	return nil
+}
+
+type LeasesResponse struct {
+	Metadata *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Leases   []*Lease             `protobuf:"bytes,2,rep,name=leases,proto3" json:"leases,omitempty"`
+	// Events are the recent changes of the leases, oldest first
+	Events               []*LeaseEvent `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *LeasesResponse) Reset()         { *m = LeasesResponse{} }
+func (m *LeasesResponse) String() string { return proto.CompactTextString(m) }
+func (*LeasesResponse) ProtoMessage()    {}
+func (*LeasesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{8}
+}
+
+func (m *LeasesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeasesResponse.Unmarshal(m, b)
+}
+
+func (m *LeasesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeasesResponse.Marshal(b, m, deterministic)
+}
+
+func (m *LeasesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeasesResponse.Merge(m, src)
+}
+
+func (m *LeasesResponse) XXX_Size() int {
+	return xxx_messageInfo_LeasesResponse.Size(m)
+}
+
+func (m *LeasesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeasesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeasesResponse proto.InternalMessageInfo
+
+func (m *LeasesResponse) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/network.Network/Routes",
+	return nil
+}
+
+func (m *LeasesResponse) GetLeases() []*Lease {
+	if m != nil {
+		return m.Leases
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServer).Routes(ctx, req.(*empty.Empty))
+	return nil
+}
+
+func (m *LeasesResponse) GetEvents() []*LeaseEvent {
+	if m != nil {
+		return m.Events
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Network_Interfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+// Lease represents the DHCP lease of an interface
+type Lease struct {
+	Interface string     `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	State     LeaseState `protobuf:"varint,2,opt,name=state,proto3,enum=network.LeaseState" json:"state,omitempty"`
+	// Address is the leased address in CIDR notation
+	Address string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// Server is the address of the DHCP server which granted the lease
+	Server string `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
+	// Acquired is the time the lease was granted or last extended
+	Acquired *timestamp.Timestamp `protobuf:"bytes,5,opt,name=acquired,proto3" json:"acquired,omitempty"`
+	// Renew is the time the lease is renewed with the server which granted it (T1)
+	Renew *timestamp.Timestamp `protobuf:"bytes,6,opt,name=renew,proto3" json:"renew,omitempty"`
+	// Rebind is the time the lease is extended with any server (T2)
+	Rebind *timestamp.Timestamp `protobuf:"bytes,7,opt,name=rebind,proto3" json:"rebind,omitempty"`
+	// Expiry is the time the lease expires
+	Expiry               *timestamp.Timestamp `protobuf:"bytes,8,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Lease) Reset()         { *m = Lease{} }
+func (m *Lease) String() string { return proto.CompactTextString(m) }
+func (*Lease) ProtoMessage()    {}
+func (*Lease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{9}
+}
+
+func (m *Lease) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lease.Unmarshal(m, b)
+}
+
+func (m *Lease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lease.Marshal(b, m, deterministic)
+}
+
+func (m *Lease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lease.Merge(m, src)
+}
+
+func (m *Lease) XXX_Size() int {
+	return xxx_messageInfo_Lease.Size(m)
+}
+
+func (m *Lease) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lease proto.InternalMessageInfo
+
+func (m *Lease) GetInterface() string {
+	if m != nil {
+		return m.Interface
 	}
-	if interceptor == nil {
-		return srv.(NetworkServer).Interfaces(ctx, in)
+	return ""
+}
+
+func (m *Lease) GetState() LeaseState {
+	if m != nil {
+		return m.State
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/network.Network/Interfaces",
+	return LeaseState_LEASE_INIT
+}
+
+func (m *Lease) GetAddress() string {
+	if m != nil {
+		return m.Address
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServer).Interfaces(ctx, req.(*empty.Empty))
+	return ""
+}
+
+func (m *Lease) GetServer() string {
+	if m != nil {
+		return m.Server
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _Network_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "network.Network",
-	HandlerType: (*NetworkServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Routes",
-			Handler:    _Network_Routes_Handler,
-		},
-		{
-			MethodName: "Interfaces",
-			Handler:    _Network_Interfaces_Handler,
+func (m *Lease) GetAcquired() *timestamp.Timestamp {
+	if m != nil {
+		return m.Acquired
+	}
+	return nil
+}
+
+func (m *Lease) GetRenew() *timestamp.Timestamp {
+	if m != nil {
+		return m.Renew
+	}
+	return nil
+}
+
+func (m *Lease) GetRebind() *timestamp.Timestamp {
+	if m != nil {
+		return m.Rebind
+	}
+	return nil
+}
+
+func (m *Lease) GetExpiry() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expiry
+	}
+	return nil
+}
+
+// LeaseEvent represents a change of a DHCP lease
+type LeaseEvent struct {
+	Ts    *timestamp.Timestamp `protobuf:"bytes,1,opt,name=ts,proto3" json:"ts,omitempty"`
+	Lease *Lease               `protobuf:"bytes,2,opt,name=lease,proto3" json:"lease,omitempty"`
+	// Error is the error which caused the change, if any
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaseEvent) Reset()         { *m = LeaseEvent{} }
+func (m *LeaseEvent) String() string { return proto.CompactTextString(m) }
+func (*LeaseEvent) ProtoMessage()    {}
+func (*LeaseEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{10}
+}
+
+func (m *LeaseEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseEvent.Unmarshal(m, b)
+}
+
+func (m *LeaseEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseEvent.Marshal(b, m, deterministic)
+}
+
+func (m *LeaseEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseEvent.Merge(m, src)
+}
+
+func (m *LeaseEvent) XXX_Size() int {
+	return xxx_messageInfo_LeaseEvent.Size(m)
+}
+
+func (m *LeaseEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseEvent proto.InternalMessageInfo
+
+func (m *LeaseEvent) GetTs() *timestamp.Timestamp {
+	if m != nil {
+		return m.Ts
+	}
+	return nil
+}
+
+func (m *LeaseEvent) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+func (m *LeaseEvent) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// ApplyRequest describes a request to reconfigure the network, the change is
+// rolled back unless it is confirmed within the timeout
+type ApplyRequest struct {
+	// Network is the network section of the machine config in YAML
+	Network []byte `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	// Timeout is the time in seconds to confirm the change, defaults to 60
+	Timeout              uint32   `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyRequest) Reset()         { *m = ApplyRequest{} }
+func (m *ApplyRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyRequest) ProtoMessage()    {}
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{11}
+}
+
+func (m *ApplyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyRequest.Unmarshal(m, b)
+}
+
+func (m *ApplyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyRequest.Marshal(b, m, deterministic)
+}
+
+func (m *ApplyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyRequest.Merge(m, src)
+}
+
+func (m *ApplyRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyRequest.Size(m)
+}
+
+func (m *ApplyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyRequest proto.InternalMessageInfo
+
+func (m *ApplyRequest) GetNetwork() []byte {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *ApplyRequest) GetTimeout() uint32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+// The response message containing the applied network changes.
+type ApplyReply struct {
+	Response             []*ApplyResponse `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ApplyReply) Reset()         { *m = ApplyReply{} }
+func (m *ApplyReply) String() string { return proto.CompactTextString(m) }
+func (*ApplyReply) ProtoMessage()    {}
+func (*ApplyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{12}
+}
+
+func (m *ApplyReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyReply.Unmarshal(m, b)
+}
+
+func (m *ApplyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyReply.Marshal(b, m, deterministic)
+}
+
+func (m *ApplyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyReply.Merge(m, src)
+}
+
+func (m *ApplyReply) XXX_Size() int {
+	return xxx_messageInfo_ApplyReply.Size(m)
+}
+
+func (m *ApplyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyReply proto.InternalMessageInfo
+
+func (m *ApplyReply) GetResponse() []*ApplyResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type ApplyResponse struct {
+	Metadata *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Changes are the changes applied to the links, in order
+	Changes              []string `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyResponse) Reset()         { *m = ApplyResponse{} }
+func (m *ApplyResponse) String() string { return proto.CompactTextString(m) }
+func (*ApplyResponse) ProtoMessage()    {}
+func (*ApplyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{13}
+}
+
+func (m *ApplyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyResponse.Unmarshal(m, b)
+}
+
+func (m *ApplyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyResponse.Marshal(b, m, deterministic)
+}
+
+func (m *ApplyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyResponse.Merge(m, src)
+}
+
+func (m *ApplyResponse) XXX_Size() int {
+	return xxx_messageInfo_ApplyResponse.Size(m)
+}
+
+func (m *ApplyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyResponse proto.InternalMessageInfo
+
+func (m *ApplyResponse) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ApplyResponse) GetChanges() []string {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// The response message to the confirmation of a network change.
+type ConfirmReply struct {
+	Response             []*ConfirmResponse `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ConfirmReply) Reset()         { *m = ConfirmReply{} }
+func (m *ConfirmReply) String() string { return proto.CompactTextString(m) }
+func (*ConfirmReply) ProtoMessage()    {}
+func (*ConfirmReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{14}
+}
+
+func (m *ConfirmReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmReply.Unmarshal(m, b)
+}
+
+func (m *ConfirmReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmReply.Marshal(b, m, deterministic)
+}
+
+func (m *ConfirmReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmReply.Merge(m, src)
+}
+
+func (m *ConfirmReply) XXX_Size() int {
+	return xxx_messageInfo_ConfirmReply.Size(m)
+}
+
+func (m *ConfirmReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmReply proto.InternalMessageInfo
+
+func (m *ConfirmReply) GetResponse() []*ConfirmResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type ConfirmResponse struct {
+	Metadata             *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConfirmResponse) Reset()         { *m = ConfirmResponse{} }
+func (m *ConfirmResponse) String() string { return proto.CompactTextString(m) }
+func (*ConfirmResponse) ProtoMessage()    {}
+func (*ConfirmResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{15}
+}
+
+func (m *ConfirmResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmResponse.Unmarshal(m, b)
+}
+
+func (m *ConfirmResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmResponse.Marshal(b, m, deterministic)
+}
+
+func (m *ConfirmResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmResponse.Merge(m, src)
+}
+
+func (m *ConfirmResponse) XXX_Size() int {
+	return xxx_messageInfo_ConfirmResponse.Size(m)
+}
+
+func (m *ConfirmResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmResponse proto.InternalMessageInfo
+
+func (m *ConfirmResponse) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// WatchRequest describes the changes to stream
+type WatchRequest struct {
+	// Types are the types of the changes to stream, defaults to all types
+	Types                []WatchType `protobuf:"varint,1,rep,packed,name=types,proto3,enum=network.WatchType" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{16}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetTypes() []WatchType {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+// WatchEvent represents a change of a link, an address or a route
+type WatchEvent struct {
+	Metadata *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Ts       *timestamp.Timestamp `protobuf:"bytes,2,opt,name=ts,proto3" json:"ts,omitempty"`
+	Type     WatchType            `protobuf:"varint,3,opt,name=type,proto3,enum=network.WatchType" json:"type,omitempty"`
+	Action   WatchAction          `protobuf:"varint,4,opt,name=action,proto3,enum=network.WatchAction" json:"action,omitempty"`
+	// Interface is the changed interface, or the interface of the changed address
+	Interface *Interface `protobuf:"bytes,5,opt,name=interface,proto3" json:"interface,omitempty"`
+	// Address is the changed address
+	Address *InterfaceAddress `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	// Route is the changed route
+	Route                *Route   `protobuf:"bytes,7,opt,name=route,proto3" json:"route,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEvent) Reset()         { *m = WatchEvent{} }
+func (m *WatchEvent) String() string { return proto.CompactTextString(m) }
+func (*WatchEvent) ProtoMessage()    {}
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96ad937ae012c472, []int{17}
+}
+
+func (m *WatchEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEvent.Unmarshal(m, b)
+}
+
+func (m *WatchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEvent.Marshal(b, m, deterministic)
+}
+
+func (m *WatchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEvent.Merge(m, src)
+}
+
+func (m *WatchEvent) XXX_Size() int {
+	return xxx_messageInfo_WatchEvent.Size(m)
+}
+
+func (m *WatchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEvent proto.InternalMessageInfo
+
+func (m *WatchEvent) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *WatchEvent) GetTs() *timestamp.Timestamp {
+	if m != nil {
+		return m.Ts
+	}
+	return nil
+}
+
+func (m *WatchEvent) GetType() WatchType {
+	if m != nil {
+		return m.Type
+	}
+	return WatchType_LINK
+}
+
+func (m *WatchEvent) GetAction() WatchAction {
+	if m != nil {
+		return m.Action
+	}
+	return WatchAction_UPDATE
+}
+
+func (m *WatchEvent) GetInterface() *Interface {
+	if m != nil {
+		return m.Interface
+	}
+	return nil
+}
+
+func (m *WatchEvent) GetAddress() *InterfaceAddress {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *WatchEvent) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("network.AddressFamily", AddressFamily_name, AddressFamily_value)
+	proto.RegisterEnum("network.RouteProtocol", RouteProtocol_name, RouteProtocol_value)
+	proto.RegisterEnum("network.InterfaceFlags", InterfaceFlags_name, InterfaceFlags_value)
+	proto.RegisterEnum("network.OperState", OperState_name, OperState_value)
+	proto.RegisterEnum("network.LeaseState", LeaseState_name, LeaseState_value)
+	proto.RegisterEnum("network.WatchType", WatchType_name, WatchType_value)
+	proto.RegisterEnum("network.WatchAction", WatchAction_name, WatchAction_value)
+	proto.RegisterType((*RoutesReply)(nil), "network.RoutesReply")
+	proto.RegisterType((*RoutesResponse)(nil), "network.RoutesResponse")
+	proto.RegisterType((*Route)(nil), "network.Route")
+	proto.RegisterType((*InterfacesReply)(nil), "network.InterfacesReply")
+	proto.RegisterType((*InterfacesResponse)(nil), "network.InterfacesResponse")
+	proto.RegisterType((*Interface)(nil), "network.Interface")
+	proto.RegisterType((*InterfaceAddress)(nil), "network.InterfaceAddress")
+	proto.RegisterType((*LeasesReply)(nil), "network.LeasesReply")
+	proto.RegisterType((*LeasesResponse)(nil), "network.LeasesResponse")
+	proto.RegisterType((*Lease)(nil), "network.Lease")
+	proto.RegisterType((*LeaseEvent)(nil), "network.LeaseEvent")
+	proto.RegisterType((*ApplyRequest)(nil), "network.ApplyRequest")
+	proto.RegisterType((*ApplyReply)(nil), "network.ApplyReply")
+	proto.RegisterType((*ApplyResponse)(nil), "network.ApplyResponse")
+	proto.RegisterType((*ConfirmReply)(nil), "network.ConfirmReply")
+	proto.RegisterType((*ConfirmResponse)(nil), "network.ConfirmResponse")
+	proto.RegisterType((*WatchRequest)(nil), "network.WatchRequest")
+	proto.RegisterType((*WatchEvent)(nil), "network.WatchEvent")
+}
+
+func init() { proto.RegisterFile("network/network.proto", fileDescriptor_96ad937ae012c472) }
+
+var fileDescriptor_96ad937ae012c472 = []byte{
+	// 1549 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xcd, 0x72, 0xdb, 0xc8,
+	0x11, 0x36, 0xc0, 0xff, 0xe6, 0x8f, 0xc6, 0x23, 0xc9, 0x86, 0xe5, 0x54, 0x45, 0xc5, 0x4a, 0x5c,
+	0x0a, 0x13, 0x4b, 0x2a, 0xda, 0x91, 0x7d, 0x4a, 0x05, 0x24, 0x20, 0x87, 0x11, 0x05, 0xd0, 0x43,
+	0x28, 0x4a, 0x9c, 0x54, 0xa9, 0x20, 0x72, 0x24, 0x31, 0x21, 0x09, 0x18, 0x00, 0x2d, 0x33, 0xd7,
+	0xdc, 0x73, 0x4b, 0xe5, 0xbc, 0xb5, 0x0f, 0xb0, 0x4f, 0xb2, 0xb7, 0xbd, 0xef, 0xab, 0x6c, 0xcd,
+	0x0f, 0x40, 0x90, 0xb4, 0x57, 0xb2, 0x2f, 0x24, 0xfa, 0xeb, 0x6f, 0xba, 0x1b, 0xdd, 0x3d, 0x3d,
+	0x03, 0xd8, 0x9e, 0xd2, 0xe8, 0xd6, 0x0b, 0xfe, 0x75, 0x20, 0xff, 0xf7, 0xfd, 0xc0, 0x8b, 0x3c,
+	0x5c, 0x90, 0xe2, 0xce, 0xd3, 0x6b, 0xcf, 0xbb, 0x1e, 0xd3, 0x03, 0x0e, 0x5f, 0xce, 0xae, 0x0e,
+	0xe8, 0xc4, 0x8f, 0xe6, 0x82, 0xb5, 0xf3, 0xcb, 0x55, 0x65, 0x34, 0x9a, 0xd0, 0x30, 0x72, 0x27,
+	0xbe, 0x24, 0x6c, 0x0e, 0xbc, 0xc9, 0xc4, 0x9b, 0x1e, 0x88, 0x3f, 0x01, 0xd6, 0x5b, 0x50, 0x26,
+	0xde, 0x2c, 0xa2, 0x21, 0xa1, 0xfe, 0x78, 0x8e, 0x5f, 0x40, 0x31, 0xa0, 0xa1, 0xef, 0x4d, 0x43,
+	0xaa, 0x29, 0xbb, 0x99, 0xbd, 0x72, 0xf3, 0xf1, 0x7e, 0x1c, 0x4c, 0xcc, 0x13, 0x6a, 0x92, 0x10,
+	0xeb, 0xff, 0x84, 0xda, 0xb2, 0x0e, 0x1f, 0x42, 0x71, 0x42, 0x23, 0x77, 0xe8, 0x46, 0xae, 0xa6,
+	0xec, 0x2a, 0x7b, 0xe5, 0xe6, 0xd6, 0xbe, 0x74, 0x6b, 0x79, 0x43, 0x7a, 0x2a, 0x75, 0x24, 0x61,
+	0xe1, 0x67, 0x90, 0x0f, 0xb8, 0x0d, 0x4d, 0xe5, 0x6e, 0x6b, 0xcb, 0x6e, 0x89, 0xd4, 0xd6, 0xbf,
+	0x51, 0x21, 0xc7, 0x11, 0xfc, 0x0b, 0x28, 0x8d, 0xa6, 0x11, 0x0d, 0xae, 0xdc, 0x01, 0xe5, 0x4e,
+	0x4a, 0x64, 0x01, 0xe0, 0x5d, 0x28, 0x0f, 0x69, 0x18, 0x8d, 0xa6, 0x6e, 0x34, 0xf2, 0xa6, 0x9a,
+	0xca, 0xf5, 0x69, 0x08, 0x6b, 0x50, 0xb8, 0x76, 0x23, 0x7a, 0xeb, 0xce, 0xb5, 0x0c, 0xd7, 0xc6,
+	0x22, 0x7e, 0x04, 0xf9, 0x09, 0x8d, 0x82, 0xd1, 0x40, 0xcb, 0xee, 0x2a, 0x7b, 0x55, 0x22, 0x25,
+	0xbc, 0x05, 0xb9, 0x70, 0xe0, 0xf9, 0x54, 0xcb, 0x71, 0x58, 0x08, 0x8c, 0x1d, 0x7a, 0xb3, 0x60,
+	0x40, 0xb5, 0x3c, 0x37, 0x23, 0x25, 0xbc, 0x0f, 0xf9, 0x2b, 0x77, 0x32, 0x1a, 0xcf, 0xb5, 0xc2,
+	0xae, 0xb2, 0x57, 0x6b, 0x3e, 0x4a, 0xde, 0x48, 0x1f, 0x0e, 0x03, 0x1a, 0x86, 0xc7, 0x5c, 0x4b,
+	0x24, 0x0b, 0x37, 0xa1, 0xc8, 0x4b, 0x32, 0xf0, 0xc6, 0x5a, 0x71, 0x65, 0x05, 0x7f, 0xe3, 0x9e,
+	0xd4, 0x92, 0x84, 0xc7, 0x22, 0xba, 0x1a, 0xbb, 0xd7, 0xa1, 0x56, 0x12, 0x11, 0x71, 0xa1, 0xfe,
+	0x67, 0xd8, 0xe8, 0xc4, 0x89, 0x90, 0x75, 0x7d, 0xb5, 0x56, 0xd7, 0xa7, 0x89, 0xf1, 0x34, 0x77,
+	0xad, 0xb6, 0xff, 0x06, 0xbc, 0xae, 0xff, 0x8a, 0xfa, 0x36, 0x01, 0x92, 0xe2, 0xc4, 0x35, 0xc6,
+	0xeb, 0x21, 0x90, 0x14, 0xab, 0xfe, 0xad, 0x0a, 0xa5, 0x44, 0xc3, 0xde, 0x75, 0x34, 0x1d, 0xd2,
+	0x8f, 0xdc, 0x61, 0x95, 0x08, 0x01, 0x23, 0xc8, 0x4c, 0xa2, 0x19, 0xaf, 0x6f, 0x95, 0xb0, 0x47,
+	0x8c, 0x21, 0x3b, 0x75, 0x27, 0x54, 0x16, 0x95, 0x3f, 0xe3, 0x3a, 0x54, 0x6e, 0xdc, 0x60, 0x78,
+	0xeb, 0x06, 0xd4, 0x1d, 0x0e, 0x03, 0x5e, 0xd7, 0x12, 0x59, 0xc2, 0xf0, 0xf3, 0x38, 0x97, 0x39,
+	0x9e, 0xfc, 0xc7, 0xeb, 0xc1, 0x1d, 0x33, 0xb5, 0x4c, 0x32, 0x6f, 0x3f, 0xdf, 0x15, 0x95, 0xd4,
+	0xf2, 0xbb, 0x19, 0xde, 0x7e, 0x31, 0x80, 0x5f, 0x41, 0x49, 0x3e, 0xd2, 0x50, 0x2b, 0xf0, 0xb7,
+	0x7d, 0xb2, 0x6e, 0x50, 0x36, 0x02, 0x59, 0x70, 0xf1, 0x21, 0x94, 0x3c, 0x9f, 0x06, 0x61, 0xe4,
+	0x46, 0x54, 0xb6, 0xc1, 0x22, 0x4d, 0xb6, 0x4f, 0x83, 0x3e, 0xd3, 0x90, 0x05, 0xa9, 0xfe, 0x0f,
+	0x40, 0xab, 0x06, 0x59, 0x6f, 0xc7, 0xa1, 0x89, 0x9d, 0x11, 0x8b, 0xa9, 0xae, 0x54, 0xef, 0xd3,
+	0x95, 0x6c, 0x3e, 0x74, 0xa9, 0x1b, 0xde, 0x67, 0x3e, 0xc4, 0xbc, 0xb5, 0x1e, 0xfa, 0xbf, 0x02,
+	0xb5, 0x65, 0xe5, 0xd7, 0x0d, 0x88, 0x31, 0xb7, 0xb1, 0x36, 0x20, 0xb8, 0x69, 0x22, 0xb5, 0xf8,
+	0xb7, 0x90, 0xa7, 0x1f, 0xe8, 0x34, 0x0a, 0xb5, 0x0c, 0xe7, 0x6d, 0x2e, 0xf3, 0x4c, 0xa6, 0x23,
+	0x92, 0x52, 0xff, 0x41, 0x85, 0x1c, 0x87, 0xef, 0x98, 0x26, 0xbf, 0x81, 0x9c, 0xa8, 0x88, 0x48,
+	0xda, 0x8a, 0x4d, 0x51, 0x12, 0xc1, 0x48, 0xa7, 0x3e, 0xb3, 0x9c, 0x7a, 0x36, 0x28, 0x68, 0xf0,
+	0x81, 0xc6, 0xed, 0x27, 0x25, 0x7c, 0x04, 0x45, 0x77, 0xf0, 0x7e, 0x36, 0x0a, 0xe8, 0x90, 0xf7,
+	0x5e, 0xb9, 0xb9, 0xb3, 0x2f, 0x66, 0xf9, 0x7e, 0x3c, 0xcb, 0xf7, 0x9d, 0x78, 0x96, 0x93, 0x84,
+	0x8b, 0x0f, 0x21, 0x17, 0xd0, 0x29, 0xbd, 0xd5, 0xf2, 0x77, 0x2e, 0x12, 0x44, 0xdc, 0x84, 0x7c,
+	0x40, 0x2f, 0x47, 0xd3, 0xa1, 0x56, 0xb8, 0x73, 0x89, 0x64, 0xb2, 0x35, 0xf4, 0xa3, 0x3f, 0x0a,
+	0xe6, 0x5a, 0xf1, 0xee, 0x35, 0x82, 0x59, 0x8f, 0x00, 0x16, 0xc9, 0xc6, 0x0d, 0x50, 0xa3, 0x50,
+	0x53, 0xee, 0x5c, 0xad, 0x46, 0x21, 0xfe, 0x15, 0xe4, 0x78, 0x1d, 0x79, 0xa2, 0xd7, 0x8b, 0x2c,
+	0x94, 0x6c, 0x14, 0xd0, 0x20, 0xf0, 0x02, 0x99, 0x61, 0x21, 0xd4, 0x5b, 0x50, 0xd1, 0x7d, 0x7f,
+	0x3c, 0x27, 0xf4, 0xfd, 0x8c, 0x86, 0x11, 0xab, 0x84, 0x5c, 0xcd, 0x9d, 0x57, 0x48, 0x2c, 0x32,
+	0x0d, 0x3b, 0x1c, 0xbd, 0x59, 0x24, 0x07, 0x47, 0x2c, 0xd6, 0xff, 0x08, 0x20, 0x6d, 0xf8, 0x62,
+	0x24, 0xaf, 0x74, 0x7b, 0x6a, 0xbb, 0x08, 0xda, 0x5a, 0xb3, 0xff, 0x1d, 0xaa, 0x4b, 0xaa, 0xaf,
+	0x68, 0x75, 0x0d, 0x0a, 0x83, 0x1b, 0x77, 0x7a, 0x2d, 0x7b, 0xbd, 0x44, 0x62, 0xb1, 0x6e, 0x40,
+	0xa5, 0xed, 0x4d, 0xaf, 0x46, 0xc1, 0x44, 0x04, 0xf8, 0x72, 0x2d, 0x40, 0x2d, 0x09, 0x30, 0x21,
+	0xae, 0x85, 0xd8, 0x86, 0x8d, 0x15, 0xe5, 0x97, 0x07, 0x59, 0x7f, 0x0d, 0x95, 0x73, 0x37, 0x1a,
+	0xdc, 0xc4, 0xd9, 0xde, 0x83, 0x5c, 0x34, 0xf7, 0x69, 0xc8, 0xe3, 0x48, 0x0f, 0x2d, 0xce, 0x72,
+	0xe6, 0x3e, 0x25, 0x82, 0x50, 0xff, 0x5e, 0x05, 0xe0, 0xa0, 0x68, 0x8f, 0x2f, 0xcf, 0x8f, 0x68,
+	0x28, 0xf5, 0x5e, 0x0d, 0xf5, 0x0c, 0xb2, 0xcc, 0x2b, 0xef, 0x94, 0x4f, 0x47, 0xc5, 0xf5, 0xf8,
+	0x77, 0x90, 0x77, 0x07, 0xfc, 0xaa, 0x90, 0xe5, 0xcc, 0xad, 0x65, 0xa6, 0xce, 0x75, 0x44, 0x72,
+	0xd8, 0x94, 0x5e, 0x4c, 0x0b, 0xb1, 0x67, 0x3f, 0x75, 0x98, 0x2d, 0x48, 0xf8, 0xc5, 0x62, 0x2c,
+	0x88, 0xed, 0xfa, 0x33, 0xc7, 0x41, 0xcc, 0x64, 0xbb, 0x81, 0x5f, 0x7b, 0xb4, 0xc2, 0xca, 0x6e,
+	0x10, 0x77, 0x22, 0xa1, 0x6c, 0xbc, 0x85, 0xea, 0xd2, 0xec, 0xc6, 0x55, 0x28, 0xe9, 0xc7, 0x17,
+	0x67, 0x56, 0xbf, 0x67, 0xb6, 0xd1, 0x03, 0x5c, 0x86, 0x82, 0x7e, 0x7c, 0xd1, 0xb1, 0x4c, 0x07,
+	0xa9, 0xb8, 0x08, 0xd9, 0x4e, 0xef, 0x2f, 0x2f, 0x91, 0x8a, 0x2b, 0x50, 0x94, 0xf0, 0x11, 0x02,
+	0x89, 0x1f, 0x21, 0xd8, 0x51, 0x91, 0xd2, 0xf8, 0x4e, 0x85, 0xea, 0xd2, 0x9d, 0x03, 0x3f, 0x84,
+	0x2a, 0x71, 0x7a, 0xc4, 0x76, 0x16, 0x76, 0x37, 0x61, 0x43, 0x42, 0xc4, 0x34, 0x3a, 0xc4, 0x6c,
+	0x3b, 0x48, 0x49, 0xf1, 0x4e, 0x4c, 0x62, 0x99, 0x5d, 0xa4, 0xe2, 0x0d, 0x28, 0x4b, 0xa8, 0x65,
+	0xdb, 0x0e, 0xca, 0xa4, 0x38, 0x7d, 0x47, 0x77, 0x3a, 0x6d, 0x94, 0xc5, 0x08, 0x2a, 0x12, 0x7a,
+	0xa3, 0x3b, 0xa6, 0x81, 0x8a, 0xec, 0x25, 0x62, 0xeb, 0x3a, 0x2a, 0xe1, 0x1a, 0x80, 0x14, 0x4f,
+	0x89, 0x83, 0x20, 0xb5, 0xe0, 0x9d, 0xd9, 0x22, 0x3a, 0x2a, 0xa7, 0xdd, 0x74, 0x88, 0x81, 0x2a,
+	0xa9, 0xf8, 0x0c, 0x8b, 0xd8, 0x67, 0xcc, 0x6c, 0x35, 0xc5, 0xfa, 0xab, 0x4d, 0x7a, 0xa8, 0x96,
+	0x32, 0x6c, 0x39, 0x27, 0x68, 0x23, 0x45, 0x30, 0xfe, 0xd4, 0xee, 0x21, 0x84, 0x31, 0xd4, 0x12,
+	0xcf, 0xc2, 0xca, 0xc3, 0x94, 0xf7, 0x96, 0xde, 0x32, 0xbb, 0xa8, 0xd1, 0xf8, 0x8f, 0x02, 0xb5,
+	0xe5, 0x8b, 0x02, 0x23, 0x1d, 0x77, 0xf5, 0x37, 0x17, 0x67, 0xd6, 0x89, 0x65, 0x9f, 0x5b, 0xa2,
+	0x12, 0x02, 0xe9, 0x21, 0x85, 0xd9, 0xe5, 0x42, 0x8b, 0xd8, 0xba, 0xd1, 0xd6, 0xfb, 0xac, 0x3a,
+	0x0f, 0xa1, 0xca, 0xb1, 0xae, 0x6d, 0xf7, 0x5a, 0x7a, 0xfb, 0x04, 0x65, 0xf0, 0x63, 0xd8, 0xe4,
+	0x50, 0xcf, 0xee, 0x58, 0xce, 0x85, 0x63, 0x8b, 0x07, 0x94, 0x4d, 0xd6, 0x9f, 0x9e, 0x75, 0x9d,
+	0x0e, 0x5f, 0x9f, 0x6b, 0xfc, 0x57, 0x81, 0x52, 0x72, 0x49, 0x60, 0x01, 0xd8, 0x3d, 0x93, 0xa4,
+	0x02, 0xd8, 0x02, 0xc4, 0x11, 0xcb, 0x76, 0x2e, 0x7a, 0xc4, 0xec, 0x9b, 0x16, 0xab, 0x59, 0x15,
+	0x4a, 0x1c, 0x35, 0x18, 0x49, 0xc5, 0x4f, 0x60, 0x9b, 0x8b, 0x5d, 0xfb, 0x9c, 0xfd, 0xea, 0x7f,
+	0x8b, 0x55, 0x99, 0xc4, 0xa2, 0x63, 0xf6, 0x9d, 0x8e, 0xf5, 0x06, 0x65, 0x13, 0xc4, 0xb0, 0xc9,
+	0xa9, 0x6e, 0x39, 0x28, 0xc7, 0x5e, 0x52, 0x78, 0xed, 0xa1, 0x7c, 0xe3, 0x7f, 0x8a, 0x3c, 0x0a,
+	0x44, 0x44, 0x35, 0x80, 0xae, 0xa9, 0xf7, 0xcd, 0x8b, 0x8e, 0xd5, 0x71, 0x44, 0x0b, 0x09, 0xb9,
+	0x6f, 0x76, 0xcd, 0x36, 0x37, 0xa9, 0xb0, 0x20, 0x05, 0x48, 0xcc, 0xb7, 0x67, 0xd2, 0x11, 0xef,
+	0x22, 0x81, 0xb6, 0xec, 0x33, 0xcb, 0x40, 0x19, 0xf6, 0xfe, 0x31, 0xcd, 0x32, 0xcf, 0x45, 0x34,
+	0x89, 0x3d, 0x62, 0xb6, 0x3a, 0x96, 0xc1, 0xc0, 0x5c, 0x9a, 0xc8, 0xff, 0x0d, 0x94, 0x6f, 0x3c,
+	0x87, 0x52, 0x32, 0x01, 0x58, 0xef, 0x77, 0x3b, 0xd6, 0x89, 0xdc, 0x2a, 0x86, 0x41, 0xcc, 0x7e,
+	0x1f, 0x29, 0xb8, 0x04, 0x39, 0x5e, 0x70, 0xa4, 0x36, 0x7e, 0x0d, 0xe5, 0xd4, 0x18, 0xc0, 0x00,
+	0xf9, 0xb3, 0x9e, 0xa1, 0x3b, 0x26, 0x7a, 0xc0, 0x9e, 0x0d, 0xb3, 0x6b, 0x3a, 0x26, 0x52, 0x9a,
+	0x3f, 0xaa, 0x50, 0xb0, 0xe4, 0x19, 0x73, 0x04, 0x79, 0xf1, 0x51, 0x84, 0x1f, 0xad, 0x8d, 0x28,
+	0x93, 0x7d, 0xb6, 0xed, 0x6c, 0xad, 0x7d, 0x59, 0xb1, 0x91, 0xfe, 0x07, 0x80, 0xc5, 0x85, 0xfb,
+	0xb3, 0x6b, 0xb5, 0x4f, 0xde, 0xde, 0xd9, 0xfa, 0x23, 0xc8, 0x8b, 0xbb, 0xd6, 0x3d, 0xfc, 0x2e,
+	0xdf, 0xec, 0x72, 0xfc, 0xdc, 0xc2, 0xdb, 0xab, 0x47, 0x1c, 0x9f, 0xef, 0x3b, 0x9b, 0xab, 0x30,
+	0xe3, 0xbe, 0x86, 0x82, 0x3c, 0x49, 0x3e, 0xeb, 0x6d, 0x7b, 0xfd, 0x40, 0x62, 0x2b, 0x7f, 0x0f,
+	0x39, 0x9e, 0xd1, 0x94, 0xbb, 0xf4, 0x71, 0xb2, 0xb3, 0xb9, 0x0c, 0xf3, 0xa3, 0xe2, 0x50, 0x69,
+	0x9d, 0xc0, 0xc6, 0xc0, 0x9b, 0x24, 0x3a, 0xd7, 0x1f, 0xb5, 0x40, 0x66, 0x5c, 0xf7, 0x47, 0x3d,
+	0xe5, 0x5d, 0xe3, 0x7a, 0x14, 0xdd, 0xcc, 0x2e, 0xd9, 0x09, 0x72, 0x10, 0xb9, 0x63, 0x2f, 0x7c,
+	0x1e, 0xce, 0xc3, 0x88, 0x4e, 0x42, 0x21, 0x1d, 0xb8, 0xfe, 0x28, 0xfe, 0xba, 0xbe, 0xcc, 0xf3,
+	0x50, 0x5f, 0xfc, 0x34, 0x00, 0xc9, 0x36, 0xd3, 0xb8, 0x77, 0x0f, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NetworkClient is the client API for Network service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NetworkClient interface {
+	Routes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RoutesReply, error)
+	Interfaces(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*InterfacesReply, error)
+	Leases(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*LeasesReply, error)
+	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyReply, error)
+	Confirm(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ConfirmReply, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Network_WatchClient, error)
+}
+
+type networkClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNetworkClient(cc *grpc.ClientConn) NetworkClient {
+	return &networkClient{cc}
+}
+
+func (c *networkClient) Routes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RoutesReply, error) {
+	out := new(RoutesReply)
+	err := c.cc.Invoke(ctx, "/network.Network/Routes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Interfaces(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*InterfacesReply, error) {
+	out := new(InterfacesReply)
+	err := c.cc.Invoke(ctx, "/network.Network/Interfaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Leases(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*LeasesReply, error) {
+	out := new(LeasesReply)
+	err := c.cc.Invoke(ctx, "/network.Network/Leases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyReply, error) {
+	out := new(ApplyReply)
+	err := c.cc.Invoke(ctx, "/network.Network/Apply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Confirm(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ConfirmReply, error) {
+	out := new(ConfirmReply)
+	err := c.cc.Invoke(ctx, "/network.Network/Confirm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Network_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Network_serviceDesc.Streams[0], "/network.Network/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Network_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type networkWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NetworkServer is the server API for Network service.
+type NetworkServer interface {
+	Routes(context.Context, *empty.Empty) (*RoutesReply, error)
+	Interfaces(context.Context, *empty.Empty) (*InterfacesReply, error)
+	Leases(context.Context, *empty.Empty) (*LeasesReply, error)
+	Apply(context.Context, *ApplyRequest) (*ApplyReply, error)
+	Confirm(context.Context, *empty.Empty) (*ConfirmReply, error)
+	Watch(*WatchRequest, Network_WatchServer) error
+}
+
+func RegisterNetworkServer(s *grpc.Server, srv NetworkServer) {
+	s.RegisterService(&_Network_serviceDesc, srv)
+}
+
+func _Network_Routes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Routes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Routes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Routes(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Interfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Interfaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Interfaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Interfaces(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Leases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Leases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Leases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Leases(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Apply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Apply(ctx, req.(*ApplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Confirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServer).Confirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Network/Confirm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServer).Confirm(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Network_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServer).Watch(m, &networkWatchServer{stream})
+}
+
+type Network_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type networkWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Network_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "network.Network",
+	HandlerType: (*NetworkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Routes",
+			Handler:    _Network_Routes_Handler,
+		},
+		{
+			MethodName: "Interfaces",
+			Handler:    _Network_Interfaces_Handler,
+		},
+		{
+			MethodName: "Leases",
+			Handler:    _Network_Leases_Handler,
+		},
+		{
+			MethodName: "Apply",
+			Handler:    _Network_Apply_Handler,
+		},
+		{
+			MethodName: "Confirm",
+			Handler:    _Network_Confirm_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Network_Watch_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "network/network.proto",
 }
@@ -10,6 +10,7 @@ import (
 
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 
 	common "github.com/talos-systems/talos/api/common"
@@ -265,6 +266,152 @@ func (m *ContainersReply) GetResponse() []*ContainerResponse {
 	return nil
 }
 
+type DmesgRequest struct {
+	// keep streaming new messages once the end of the ring buffer is reached
+	Follow bool `protobuf:"varint,1,opt,name=follow,proto3" json:"follow,omitempty"`
+	// skip the messages already in the ring buffer
+	Tail                 bool     `protobuf:"varint,2,opt,name=tail,proto3" json:"tail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DmesgRequest) Reset()         { *m = DmesgRequest{} }
+func (m *DmesgRequest) String() string { return proto.CompactTextString(m) }
+func (*DmesgRequest) ProtoMessage()    {}
+func (*DmesgRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b20a722d09fd3254, []int{4}
+}
+
+func (m *DmesgRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DmesgRequest.Unmarshal(m, b)
+}
+
+func (m *DmesgRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DmesgRequest.Marshal(b, m, deterministic)
+}
+
+func (m *DmesgRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DmesgRequest.Merge(m, src)
+}
+
+func (m *DmesgRequest) XXX_Size() int {
+	return xxx_messageInfo_DmesgRequest.Size(m)
+}
+
+func (m *DmesgRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DmesgRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DmesgRequest proto.InternalMessageInfo
+
+func (m *DmesgRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *DmesgRequest) GetTail() bool {
+	if m != nil {
+		return m.Tail
+	}
+	return false
+}
+
+// The response message containing a single kernel log record.
+type KernelMessage struct {
+	Metadata *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Facility int32                `protobuf:"varint,2,opt,name=facility,proto3" json:"facility,omitempty"`
+	Priority int32                `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	Sequence int64                `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// time since boot, in microseconds
+	Clock                int64                `protobuf:"varint,5,opt,name=clock,proto3" json:"clock,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Message              string               `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *KernelMessage) Reset()         { *m = KernelMessage{} }
+func (m *KernelMessage) String() string { return proto.CompactTextString(m) }
+func (*KernelMessage) ProtoMessage()    {}
+func (*KernelMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b20a722d09fd3254, []int{5}
+}
+
+func (m *KernelMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KernelMessage.Unmarshal(m, b)
+}
+
+func (m *KernelMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KernelMessage.Marshal(b, m, deterministic)
+}
+
+func (m *KernelMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KernelMessage.Merge(m, src)
+}
+
+func (m *KernelMessage) XXX_Size() int {
+	return xxx_messageInfo_KernelMessage.Size(m)
+}
+
+func (m *KernelMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_KernelMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KernelMessage proto.InternalMessageInfo
+
+func (m *KernelMessage) GetMetadata() *common.NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *KernelMessage) GetFacility() int32 {
+	if m != nil {
+		return m.Facility
+	}
+	return 0
+}
+
+func (m *KernelMessage) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *KernelMessage) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *KernelMessage) GetClock() int64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *KernelMessage) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *KernelMessage) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 // rpc processes
 type ProcessesRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -276,7 +423,7 @@ func (m *ProcessesRequest) Reset()         { *m = ProcessesRequest{} }
 func (m *ProcessesRequest) String() string { return proto.CompactTextString(m) }
 func (*ProcessesRequest) ProtoMessage()    {}
 func (*ProcessesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{4}
+	return fileDescriptor_b20a722d09fd3254, []int{6}
 }
 
 func (m *ProcessesRequest) XXX_Unmarshal(b []byte) error {
@@ -312,7 +459,7 @@ func (m *ProcessesReply) Reset()         { *m = ProcessesReply{} }
 func (m *ProcessesReply) String() string { return proto.CompactTextString(m) }
 func (*ProcessesReply) ProtoMessage()    {}
 func (*ProcessesReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{5}
+	return fileDescriptor_b20a722d09fd3254, []int{7}
 }
 
 func (m *ProcessesReply) XXX_Unmarshal(b []byte) error {
@@ -356,7 +503,7 @@ func (m *ProcessResponse) Reset()         { *m = ProcessResponse{} }
 func (m *ProcessResponse) String() string { return proto.CompactTextString(m) }
 func (*ProcessResponse) ProtoMessage()    {}
 func (*ProcessResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{6}
+	return fileDescriptor_b20a722d09fd3254, []int{8}
 }
 
 func (m *ProcessResponse) XXX_Unmarshal(b []byte) error {
@@ -415,7 +562,7 @@ func (m *Process) Reset()         { *m = Process{} }
 func (m *Process) String() string { return proto.CompactTextString(m) }
 func (*Process) ProtoMessage()    {}
 func (*Process) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{7}
+	return fileDescriptor_b20a722d09fd3254, []int{9}
 }
 
 func (m *Process) XXX_Unmarshal(b []byte) error {
@@ -526,7 +673,7 @@ func (m *RestartRequest) Reset()         { *m = RestartRequest{} }
 func (m *RestartRequest) String() string { return proto.CompactTextString(m) }
 func (*RestartRequest) ProtoMessage()    {}
 func (*RestartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{8}
+	return fileDescriptor_b20a722d09fd3254, []int{10}
 }
 
 func (m *RestartRequest) XXX_Unmarshal(b []byte) error {
@@ -583,7 +730,7 @@ func (m *RestartResponse) Reset()         { *m = RestartResponse{} }
 func (m *RestartResponse) String() string { return proto.CompactTextString(m) }
 func (*RestartResponse) ProtoMessage()    {}
 func (*RestartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{9}
+	return fileDescriptor_b20a722d09fd3254, []int{11}
 }
 
 func (m *RestartResponse) XXX_Unmarshal(b []byte) error {
@@ -627,7 +774,7 @@ func (m *RestartReply) Reset()         { *m = RestartReply{} }
 func (m *RestartReply) String() string { return proto.CompactTextString(m) }
 func (*RestartReply) ProtoMessage()    {}
 func (*RestartReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{10}
+	return fileDescriptor_b20a722d09fd3254, []int{12}
 }
 
 func (m *RestartReply) XXX_Unmarshal(b []byte) error {
@@ -673,7 +820,7 @@ func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{11}
+	return fileDescriptor_b20a722d09fd3254, []int{13}
 }
 
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
@@ -725,7 +872,7 @@ func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
 func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()    {}
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{12}
+	return fileDescriptor_b20a722d09fd3254, []int{14}
 }
 
 func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
@@ -775,7 +922,7 @@ func (m *StatsReply) Reset()         { *m = StatsReply{} }
 func (m *StatsReply) String() string { return proto.CompactTextString(m) }
 func (*StatsReply) ProtoMessage()    {}
 func (*StatsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{13}
+	return fileDescriptor_b20a722d09fd3254, []int{15}
 }
 
 func (m *StatsReply) XXX_Unmarshal(b []byte) error {
@@ -824,7 +971,7 @@ func (m *Stat) Reset()         { *m = Stat{} }
 func (m *Stat) String() string { return proto.CompactTextString(m) }
 func (*Stat) ProtoMessage()    {}
 func (*Stat) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{14}
+	return fileDescriptor_b20a722d09fd3254, []int{16}
 }
 
 func (m *Stat) XXX_Unmarshal(b []byte) error {
@@ -903,7 +1050,7 @@ func (m *MemInfoResponse) Reset()         { *m = MemInfoResponse{} }
 func (m *MemInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*MemInfoResponse) ProtoMessage()    {}
 func (*MemInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{15}
+	return fileDescriptor_b20a722d09fd3254, []int{17}
 }
 
 func (m *MemInfoResponse) XXX_Unmarshal(b []byte) error {
@@ -953,7 +1100,7 @@ func (m *MemInfoReply) Reset()         { *m = MemInfoReply{} }
 func (m *MemInfoReply) String() string { return proto.CompactTextString(m) }
 func (*MemInfoReply) ProtoMessage()    {}
 func (*MemInfoReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{16}
+	return fileDescriptor_b20a722d09fd3254, []int{18}
 }
 
 func (m *MemInfoReply) XXX_Unmarshal(b []byte) error {
@@ -1043,7 +1190,7 @@ func (m *MemInfo) Reset()         { *m = MemInfo{} }
 func (m *MemInfo) String() string { return proto.CompactTextString(m) }
 func (*MemInfo) ProtoMessage()    {}
 func (*MemInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b20a722d09fd3254, []int{17}
+	return fileDescriptor_b20a722d09fd3254, []int{19}
 }
 
 func (m *MemInfo) XXX_Unmarshal(b []byte) error {
@@ -1409,6 +1556,8 @@ func init() {
 	proto.RegisterType((*Container)(nil), "os.Container")
 	proto.RegisterType((*ContainerResponse)(nil), "os.ContainerResponse")
 	proto.RegisterType((*ContainersReply)(nil), "os.ContainersReply")
+	proto.RegisterType((*DmesgRequest)(nil), "os.DmesgRequest")
+	proto.RegisterType((*KernelMessage)(nil), "os.KernelMessage")
 	proto.RegisterType((*ProcessesRequest)(nil), "os.ProcessesRequest")
 	proto.RegisterType((*ProcessesReply)(nil), "os.ProcessesReply")
 	proto.RegisterType((*ProcessResponse)(nil), "os.ProcessResponse")
@@ -1428,95 +1577,104 @@ func init() {
 func init() { proto.RegisterFile("os/os.proto", fileDescriptor_b20a722d09fd3254) }
 
 var fileDescriptor_b20a722d09fd3254 = []byte{
-	// 1400 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdb, 0x6e, 0x1b, 0xb7,
-	0x16, 0x85, 0x64, 0xc9, 0xb2, 0x68, 0x5b, 0xb6, 0xe9, 0x5c, 0x18, 0x27, 0x27, 0xc7, 0xd1, 0x89,
-	0x13, 0xe7, 0x9c, 0xd8, 0x4a, 0x7c, 0x0a, 0xb4, 0x40, 0x0b, 0x14, 0x49, 0xdc, 0x87, 0x3c, 0xa4,
-	0x09, 0x26, 0xed, 0x4b, 0x81, 0xc2, 0xa0, 0x66, 0x28, 0x89, 0xf5, 0x70, 0x38, 0x1d, 0x72, 0x94,
-	0xba, 0xbf, 0xd0, 0x1f, 0xe8, 0x5b, 0x5f, 0xfa, 0x17, 0xfd, 0xb9, 0x62, 0x73, 0x73, 0x46, 0x1c,
-	0xe5, 0xd2, 0x24, 0x45, 0x9f, 0x34, 0x7b, 0xed, 0xc5, 0x45, 0xee, 0x0b, 0x2f, 0x22, 0xeb, 0xda,
-	0x8c, 0xb4, 0x39, 0xce, 0x0b, 0x6d, 0x35, 0x6d, 0x6b, 0xb3, 0x77, 0x7d, 0xaa, 0xf5, 0x34, 0x15,
-	0x23, 0x87, 0x8c, 0xcb, 0xc9, 0x48, 0xa8, 0xdc, 0x5e, 0x20, 0x61, 0x6f, 0x37, 0xd6, 0x4a, 0xe9,
-	0x6c, 0x84, 0x3f, 0x08, 0x0e, 0xc7, 0x64, 0xe7, 0x89, 0xce, 0x2c, 0x97, 0x99, 0x28, 0x4c, 0x24,
-	0x7e, 0x2c, 0x85, 0xb1, 0xf4, 0x06, 0xe9, 0x67, 0x5c, 0x09, 0x93, 0xf3, 0x58, 0xb0, 0xd6, 0x7e,
-	0xeb, 0xb0, 0x1f, 0x2d, 0x00, 0x3a, 0x22, 0xab, 0x49, 0x21, 0xe7, 0xa2, 0x60, 0xed, 0xfd, 0xd6,
-	0xe1, 0xe0, 0xe4, 0xea, 0xb1, 0x57, 0xac, 0x85, 0x4e, 0x9d, 0x3b, 0xf2, 0xb4, 0xe1, 0xef, 0x2d,
-	0xd2, 0xaf, 0x7d, 0x7f, 0x21, 0x3e, 0x20, 0x6d, 0x99, 0x38, 0xe1, 0x7e, 0xd4, 0x96, 0x09, 0xbd,
-	0x44, 0xba, 0x52, 0xf1, 0xa9, 0x60, 0x2b, 0x0e, 0x42, 0x83, 0x6e, 0x93, 0x95, 0x5c, 0x26, 0xac,
-	0xb3, 0xdf, 0x3a, 0xdc, 0x8c, 0xe0, 0x93, 0x5e, 0x21, 0xab, 0xc6, 0x72, 0x5b, 0x1a, 0xd6, 0x75,
-	0x44, 0x6f, 0xd1, 0xcb, 0x64, 0x35, 0xd7, 0xc9, 0x99, 0x4c, 0xd8, 0x2a, 0x0a, 0xe4, 0x3a, 0x79,
-	0x9a, 0x50, 0x4a, 0x3a, 0x30, 0x27, 0xeb, 0x39, 0xd0, 0x7d, 0x0f, 0x6d, 0x90, 0x8a, 0x48, 0x98,
-	0x5c, 0x67, 0x46, 0xd0, 0x07, 0x64, 0x4d, 0x09, 0xcb, 0x13, 0x6e, 0xb9, 0x5b, 0xec, 0xfa, 0xc9,
-	0xa5, 0x2a, 0xdc, 0xaf, 0x75, 0x22, 0x9e, 0x79, 0x5f, 0x54, 0xb3, 0xe8, 0x11, 0x21, 0x71, 0x9d,
-	0x51, 0xd6, 0xde, 0x5f, 0x39, 0x5c, 0x3f, 0xd9, 0x3c, 0xd6, 0x66, 0x91, 0x9e, 0x28, 0x20, 0x0c,
-	0x4f, 0xc9, 0x56, 0x58, 0x80, 0x3c, 0xbd, 0xa0, 0x0f, 0xc9, 0x5a, 0xe1, 0xe7, 0x67, 0x2d, 0x37,
-	0xfe, 0x72, 0x73, 0xbc, 0x77, 0x46, 0x35, 0x6d, 0x48, 0xc9, 0xf6, 0x8b, 0x42, 0xc7, 0xc2, 0x18,
-	0x51, 0x55, 0x71, 0xf8, 0x88, 0x0c, 0x02, 0x0c, 0x84, 0x47, 0xaf, 0x09, 0xef, 0x82, 0xb0, 0x67,
-	0xbd, 0x41, 0x36, 0x23, 0x5b, 0x4b, 0xce, 0x8f, 0x48, 0xc8, 0x3d, 0xd2, 0xcf, 0xab, 0x75, 0xf8,
-	0x7c, 0xac, 0x87, 0xd3, 0x2e, 0xbc, 0xc3, 0x5f, 0xdb, 0xa4, 0xe7, 0xe1, 0xaa, 0xc6, 0x30, 0x47,
-	0x17, 0x6b, 0x4c, 0x49, 0x27, 0xcf, 0x7d, 0x77, 0x74, 0x23, 0xf7, 0x0d, 0xfd, 0x01, 0x95, 0xae,
-	0xfb, 0xc3, 0x19, 0x94, 0x91, 0x9e, 0x9d, 0x15, 0x82, 0x27, 0xc6, 0xf5, 0x48, 0x37, 0xaa, 0x4c,
-	0x7a, 0x8d, 0xac, 0xc5, 0x79, 0x79, 0x66, 0xa5, 0x12, 0xae, 0x53, 0x5a, 0x51, 0x2f, 0xce, 0xcb,
-	0x6f, 0xa4, 0x12, 0xf4, 0x80, 0x0c, 0xe6, 0xb2, 0xb0, 0x25, 0x4f, 0xcf, 0x94, 0x50, 0xba, 0xb8,
-	0x70, 0x2d, 0xd3, 0x89, 0x36, 0x3d, 0xfa, 0xcc, 0x81, 0xf4, 0x2e, 0xd9, 0x2a, 0x84, 0x91, 0x89,
-	0xc8, 0x6c, 0xc5, 0xeb, 0x39, 0xde, 0xa0, 0x82, 0x3d, 0x91, 0x91, 0x1e, 0x24, 0x86, 0x67, 0x09,
-	0x5b, 0x73, 0x8b, 0xab, 0x4c, 0x7a, 0x93, 0x10, 0xf1, 0x93, 0x88, 0x4b, 0xcb, 0xc7, 0xa9, 0x60,
-	0x7d, 0xe7, 0x0c, 0x10, 0x08, 0x94, 0x17, 0x53, 0xc3, 0x08, 0x76, 0x27, 0x7c, 0x0f, 0x35, 0x19,
-	0x44, 0xc2, 0x58, 0x5e, 0xd8, 0xf7, 0xdb, 0xa5, 0xcb, 0x1b, 0x69, 0xb1, 0x6b, 0x57, 0xde, 0x6f,
-	0xd7, 0x3e, 0x21, 0x5b, 0xf5, 0x84, 0x1f, 0x5b, 0xfb, 0xe1, 0x97, 0x64, 0xa3, 0x16, 0x79, 0x47,
-	0x07, 0x2e, 0x4d, 0x14, 0x74, 0xe0, 0xf7, 0x64, 0xe3, 0xa5, 0xe5, 0xf6, 0x9f, 0x3a, 0x9a, 0x38,
-	0xd9, 0xf4, 0xf2, 0x1f, 0xdd, 0xde, 0x37, 0xb1, 0x03, 0xab, 0xd6, 0x5e, 0x83, 0x78, 0x40, 0x13,
-	0x7b, 0xd1, 0x0c, 0x3f, 0x27, 0xc4, 0x4f, 0x01, 0x09, 0x38, 0x7a, 0x2d, 0x01, 0x3b, 0xd5, 0x80,
-	0x37, 0x6d, 0xc0, 0xdf, 0x5a, 0xa4, 0x03, 0xbe, 0x0f, 0x2c, 0xf6, 0x2d, 0xb2, 0x81, 0xad, 0x79,
-	0x56, 0x1a, 0x38, 0x3c, 0x3b, 0xae, 0x41, 0xd7, 0x11, 0xfb, 0x16, 0x20, 0x7a, 0x9d, 0xf4, 0x61,
-	0x23, 0xa0, 0xbf, 0xeb, 0xfc, 0xb0, 0x33, 0xd0, 0xf9, 0x01, 0xa7, 0xe6, 0x0f, 0x64, 0xeb, 0x99,
-	0x50, 0x4f, 0xb3, 0x89, 0xfe, 0x1b, 0x39, 0x3c, 0x20, 0x3d, 0x25, 0x94, 0xcc, 0x26, 0xda, 0x05,
-	0xe1, 0x0f, 0x88, 0x4a, 0xb7, 0xf2, 0x41, 0x37, 0xd5, 0x73, 0xbd, 0xa3, 0x9b, 0x96, 0xd6, 0x13,
-	0xa4, 0xf3, 0x97, 0x0d, 0xd2, 0xf3, 0x5e, 0xba, 0x07, 0xab, 0x54, 0x56, 0x5b, 0x9e, 0xba, 0x55,
-	0x76, 0xa2, 0xda, 0x86, 0xad, 0xab, 0x84, 0x9a, 0x14, 0x42, 0xb8, 0xf5, 0x74, 0xa2, 0xca, 0xa4,
-	0x43, 0x97, 0x59, 0x3e, 0xe7, 0x32, 0x75, 0x9b, 0x77, 0xc5, 0xb9, 0x1b, 0x18, 0x8c, 0x1e, 0x97,
-	0x93, 0x09, 0x1c, 0xff, 0x98, 0xf8, 0xca, 0x84, 0x5b, 0x2a, 0xe6, 0xf1, 0x4c, 0x24, 0x3e, 0xe3,
-	0xde, 0x82, 0x03, 0xc1, 0xbc, 0xe2, 0xb9, 0xf7, 0xe1, 0xb1, 0x13, 0x20, 0x30, 0x8e, 0xc7, 0x56,
-	0xce, 0x85, 0x3f, 0x6a, 0xbc, 0x05, 0x31, 0xc8, 0xcc, 0x7b, 0xd6, 0x30, 0x86, 0xca, 0x06, 0x4d,
-	0xfc, 0xe2, 0x99, 0xce, 0xdc, 0x21, 0xd3, 0x89, 0x02, 0x04, 0x22, 0xa9, 0xb8, 0x8e, 0x41, 0x30,
-	0x92, 0x10, 0x5b, 0x68, 0x4c, 0x64, 0x2a, 0xd8, 0x7a, 0xa8, 0x01, 0x48, 0xa8, 0xe1, 0x18, 0x1b,
-	0x4d, 0x0d, 0xc7, 0xd9, 0x27, 0xeb, 0x65, 0x26, 0xe6, 0x32, 0xc6, 0xd3, 0x6e, 0x13, 0x5b, 0x31,
-	0x80, 0x5c, 0xb6, 0x53, 0x1d, 0x9f, 0x8b, 0x84, 0x0d, 0x7c, 0xb6, 0xd1, 0x84, 0xae, 0x87, 0x2c,
-	0x60, 0x91, 0xb6, 0x9c, 0x6f, 0x01, 0x40, 0xf4, 0x60, 0xb8, 0x32, 0x6d, 0x63, 0xf4, 0x95, 0x0d,
-	0xf7, 0x42, 0x22, 0x0b, 0x7b, 0xc1, 0x76, 0x9c, 0x03, 0x0d, 0xd0, 0x7b, 0x55, 0x48, 0x2b, 0xc6,
-	0x3c, 0x3e, 0x67, 0x14, 0xf5, 0x6a, 0x00, 0xbc, 0x10, 0x75, 0xce, 0xa7, 0xc2, 0xb0, 0x5d, 0xf4,
-	0xd6, 0x00, 0xd4, 0x40, 0xf1, 0x3c, 0x17, 0x09, 0xbb, 0x84, 0x35, 0x40, 0xcb, 0xdd, 0x40, 0x33,
-	0x25, 0x14, 0xbb, 0x8c, 0x33, 0x39, 0x03, 0xb6, 0x8a, 0x49, 0xf9, 0x98, 0x5d, 0x71, 0xa0, 0xfb,
-	0x86, 0x6c, 0x99, 0x42, 0xc4, 0x29, 0x97, 0xca, 0xa5, 0xe2, 0x2a, 0x66, 0x2b, 0xc4, 0x5c, 0x27,
-	0x94, 0x99, 0x47, 0x18, 0xf3, 0x9d, 0x50, 0x23, 0x90, 0xcd, 0x73, 0x51, 0x64, 0x22, 0x35, 0x16,
-	0x62, 0xb8, 0x86, 0xd9, 0x0c, 0x20, 0x50, 0x80, 0x05, 0xbb, 0xd4, 0x1a, 0xb6, 0x87, 0x0a, 0x0b,
-	0x04, 0x14, 0xb2, 0x89, 0x29, 0x33, 0x83, 0xf5, 0xb8, 0x8e, 0x0a, 0x01, 0x04, 0x91, 0x8e, 0x75,
-	0x99, 0xc5, 0x82, 0xdd, 0xc0, 0x48, 0xd1, 0x82, 0xf5, 0xd7, 0xc9, 0xb2, 0x2a, 0x67, 0xff, 0xc2,
-	0xf5, 0x87, 0x18, 0xa8, 0xc3, 0x56, 0x97, 0x36, 0x95, 0x4a, 0x5a, 0x76, 0x13, 0xd5, 0x03, 0x68,
-	0xc1, 0xb0, 0x22, 0xe1, 0x86, 0xfd, 0x3b, 0x64, 0x38, 0x08, 0xe6, 0x99, 0x2b, 0x9e, 0xa6, 0x3a,
-	0xc6, 0xc2, 0xef, 0xe3, 0x3c, 0x21, 0x06, 0x2a, 0xde, 0x2e, 0x8d, 0x48, 0xd8, 0x2d, 0x54, 0x09,
-	0xa0, 0x40, 0x25, 0x9e, 0x95, 0xd9, 0x39, 0x1b, 0x36, 0x54, 0x1c, 0x46, 0xef, 0x93, 0x9d, 0x19,
-	0x2f, 0x92, 0x57, 0xbc, 0x10, 0xb1, 0x2e, 0x8a, 0x32, 0xb7, 0x22, 0x61, 0xff, 0x71, 0xc4, 0xd7,
-	0x1d, 0xf4, 0x36, 0xd9, 0x84, 0x76, 0x98, 0x95, 0x53, 0x81, 0x3d, 0x72, 0x1b, 0xdf, 0x07, 0x0d,
-	0x90, 0xde, 0x21, 0x03, 0xd7, 0x02, 0x0b, 0xda, 0x01, 0x3e, 0x0f, 0x9a, 0x68, 0xcd, 0xcb, 0x55,
-	0xe2, 0xfb, 0xea, 0x4e, 0xc0, 0xab, 0x51, 0xe8, 0xf2, 0x58, 0x71, 0xcc, 0xc4, 0x5d, 0x7f, 0x4e,
-	0x7b, 0xdb, 0x3d, 0x31, 0x14, 0x77, 0x1b, 0xe0, 0x10, 0x77, 0x8e, 0x37, 0x41, 0xbd, 0x9e, 0x0a,
-	0xc7, 0xde, 0x43, 0xf5, 0x26, 0x0a, 0x31, 0xd5, 0x88, 0xd3, 0xf9, 0x2f, 0xc6, 0xd4, 0x00, 0x1b,
-	0xac, 0xc2, 0xcc, 0x13, 0xf6, 0xbf, 0x25, 0x16, 0x80, 0x0d, 0x96, 0x29, 0x8b, 0x9c, 0xdd, 0x5f,
-	0x62, 0x01, 0x08, 0x75, 0xa9, 0x01, 0xf9, 0xb3, 0x60, 0x47, 0x58, 0x97, 0x10, 0x83, 0xea, 0x26,
-	0xb2, 0x10, 0xb1, 0x55, 0x3c, 0xff, 0xe4, 0x9c, 0x1d, 0x63, 0x75, 0x03, 0xa8, 0xc1, 0x38, 0x51,
-	0x6c, 0xb4, 0xc4, 0x38, 0x51, 0x0d, 0xc6, 0xc3, 0x29, 0x7b, 0xb0, 0xc4, 0x78, 0x38, 0x3d, 0xf9,
-	0xa3, 0x4d, 0xda, 0xcf, 0x5f, 0xd2, 0xcf, 0x08, 0x59, 0xbc, 0xc0, 0x69, 0xf3, 0xa9, 0x5d, 0xbd,
-	0x3b, 0xf6, 0x76, 0x97, 0x61, 0xb8, 0x7f, 0x4e, 0x48, 0xf7, 0x54, 0x09, 0x33, 0xa5, 0x57, 0x8e,
-	0xf1, 0x8f, 0xd7, 0x71, 0xf5, 0xc7, 0xeb, 0xf8, 0x2b, 0xf8, 0xe3, 0xb5, 0xb7, 0x53, 0xdd, 0x7b,
-	0xa7, 0x70, 0xdf, 0xf9, 0x31, 0xab, 0xfe, 0x7d, 0xf8, 0xb6, 0x41, 0xdb, 0x8d, 0x3b, 0x0c, 0xc6,
-	0x7c, 0x4a, 0xfa, 0xf5, 0x4b, 0xfe, 0xad, 0xc3, 0x68, 0xf0, 0xa6, 0x5e, 0x3c, 0xf8, 0x7b, 0xfe,
-	0x69, 0x45, 0x69, 0xe3, 0x9d, 0x85, 0x41, 0x6d, 0x37, 0x30, 0x18, 0x70, 0x8f, 0x74, 0xdd, 0x53,
-	0x84, 0x6e, 0x07, 0xaf, 0x12, 0x24, 0x0f, 0x02, 0x24, 0x4f, 0x2f, 0x1e, 0x7f, 0x01, 0xff, 0x73,
-	0x14, 0x80, 0x3c, 0x97, 0x8f, 0xbb, 0xcf, 0xcd, 0xa3, 0x5c, 0xbe, 0x68, 0x7d, 0x77, 0x30, 0x95,
-	0x76, 0x56, 0x8e, 0x21, 0xf0, 0x91, 0xe5, 0xa9, 0x36, 0x47, 0xe6, 0xc2, 0x58, 0xa1, 0x0c, 0x5a,
-	0x23, 0x9e, 0xcb, 0x91, 0x36, 0xe3, 0x55, 0xb7, 0xfa, 0xff, 0xff, 0x19, 0x00, 0x00, 0xff, 0xff,
-	0x42, 0x1d, 0x62, 0x84, 0xc3, 0x0e, 0x00, 0x00,
+	// 1549 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6e, 0x1c, 0x3f,
+	0x15, 0xd7, 0x7e, 0x65, 0x77, 0xbd, 0xc9, 0x26, 0x71, 0xda, 0xd4, 0xdd, 0x96, 0x36, 0x5d, 0x9a,
+	0x36, 0x85, 0x26, 0xdb, 0x2e, 0x95, 0xa8, 0x00, 0x09, 0xb5, 0x0d, 0x17, 0x15, 0x0a, 0xad, 0xa6,
+	0x70, 0x83, 0x84, 0x22, 0xef, 0x8c, 0x77, 0x63, 0x32, 0x1e, 0x0f, 0x63, 0x4f, 0x42, 0x78, 0x05,
+	0x5e, 0x80, 0x3b, 0x6e, 0x78, 0x41, 0x9e, 0x00, 0x74, 0x8e, 0x3d, 0xb3, 0x33, 0x9b, 0xb6, 0xb4,
+	0x41, 0xff, 0xab, 0x99, 0xf3, 0x3b, 0xc7, 0x3f, 0xfb, 0x7c, 0xf8, 0xd8, 0x26, 0x03, 0x6d, 0x26,
+	0xda, 0x1c, 0xa5, 0x99, 0xb6, 0x9a, 0x36, 0xb5, 0x19, 0xdd, 0x5b, 0x68, 0xbd, 0x88, 0xc5, 0x04,
+	0x91, 0x59, 0x3e, 0x9f, 0x08, 0x95, 0xda, 0x2b, 0x67, 0x30, 0x7a, 0xb8, 0xaa, 0xb4, 0x52, 0x09,
+	0x63, 0xb9, 0x4a, 0xbd, 0xc1, 0x4e, 0xa8, 0x95, 0xd2, 0xc9, 0xc4, 0x7d, 0x1c, 0x38, 0x9e, 0x91,
+	0xed, 0x77, 0x3a, 0xb1, 0x5c, 0x26, 0x22, 0x33, 0x81, 0xf8, 0x4b, 0x2e, 0x8c, 0xa5, 0xf7, 0x49,
+	0x3f, 0xe1, 0x4a, 0x98, 0x94, 0x87, 0x82, 0x35, 0xf6, 0x1a, 0x07, 0xfd, 0x60, 0x09, 0xd0, 0x09,
+	0x59, 0x8b, 0x32, 0x79, 0x21, 0x32, 0xd6, 0xdc, 0x6b, 0x1c, 0x0c, 0xa7, 0x77, 0x8e, 0x3c, 0x63,
+	0x49, 0x74, 0x8c, 0xea, 0xc0, 0x9b, 0x8d, 0xff, 0xd5, 0x20, 0xfd, 0x52, 0xf7, 0x3f, 0xc8, 0x87,
+	0xa4, 0x29, 0x23, 0x24, 0xee, 0x07, 0x4d, 0x19, 0xd1, 0x5b, 0xa4, 0x23, 0x15, 0x5f, 0x08, 0xd6,
+	0x42, 0xc8, 0x09, 0x74, 0x8b, 0xb4, 0x52, 0x19, 0xb1, 0xf6, 0x5e, 0xe3, 0x60, 0x23, 0x80, 0x5f,
+	0xba, 0x4b, 0xd6, 0x8c, 0xe5, 0x36, 0x37, 0xac, 0x83, 0x86, 0x5e, 0xa2, 0xb7, 0xc9, 0x5a, 0xaa,
+	0xa3, 0x53, 0x19, 0xb1, 0x35, 0x47, 0x90, 0xea, 0xe8, 0x7d, 0x44, 0x29, 0x69, 0xc3, 0x9c, 0xac,
+	0x8b, 0x20, 0xfe, 0x8f, 0x6d, 0x25, 0x14, 0x81, 0x30, 0xa9, 0x4e, 0x8c, 0xa0, 0x2f, 0x48, 0x4f,
+	0x09, 0xcb, 0x23, 0x6e, 0x39, 0x2e, 0x76, 0x30, 0xbd, 0x55, 0xb8, 0xfb, 0x3b, 0x1d, 0x89, 0x13,
+	0xaf, 0x0b, 0x4a, 0x2b, 0x7a, 0x48, 0x48, 0x58, 0x46, 0x94, 0x35, 0xf7, 0x5a, 0x07, 0x83, 0xe9,
+	0xc6, 0x91, 0x36, 0xcb, 0xf0, 0x04, 0x15, 0x83, 0xf1, 0x31, 0xd9, 0xac, 0x26, 0x20, 0x8d, 0xaf,
+	0xe8, 0x4b, 0xd2, 0xcb, 0xfc, 0xfc, 0xac, 0x81, 0xe3, 0x6f, 0xd7, 0xc7, 0x7b, 0x65, 0x50, 0x9a,
+	0x8d, 0x7f, 0x41, 0xd6, 0x8f, 0x95, 0x30, 0x8b, 0x22, 0x83, 0xbb, 0x64, 0x6d, 0xae, 0xe3, 0x58,
+	0x5f, 0xe2, 0xa2, 0x7b, 0x81, 0x97, 0xc0, 0x6f, 0xcb, 0x65, 0x8c, 0x01, 0xee, 0x05, 0xf8, 0x3f,
+	0xfe, 0x4f, 0x83, 0x6c, 0xfc, 0x56, 0x64, 0x89, 0x88, 0x4f, 0x84, 0x31, 0x7c, 0x71, 0x13, 0xa7,
+	0x47, 0xa4, 0x37, 0xe7, 0xa1, 0x8c, 0xa5, 0xbd, 0x42, 0xee, 0x4e, 0x50, 0xca, 0xa0, 0x4b, 0x33,
+	0xa9, 0x33, 0xd0, 0xb5, 0x9c, 0xae, 0x90, 0x41, 0x67, 0x60, 0xc9, 0x49, 0x28, 0x30, 0x9b, 0xad,
+	0xa0, 0x94, 0x21, 0xf5, 0x61, 0xac, 0xc3, 0x73, 0xcc, 0x68, 0x2b, 0x70, 0x02, 0x7d, 0x4d, 0xfa,
+	0x65, 0x61, 0x63, 0x4e, 0x07, 0xd3, 0xd1, 0x91, 0x2b, 0xfd, 0xa3, 0xa2, 0xf4, 0x8f, 0x7e, 0x5f,
+	0x58, 0x04, 0x4b, 0x63, 0xca, 0x48, 0x57, 0x39, 0x07, 0x7d, 0xda, 0x0b, 0x71, 0x4c, 0xc9, 0xd6,
+	0xc7, 0x4c, 0x87, 0xc2, 0x18, 0x51, 0xec, 0x81, 0xf1, 0x1b, 0x32, 0xac, 0x60, 0x90, 0x96, 0xc9,
+	0xb5, 0xb4, 0xec, 0x40, 0x5a, 0xbc, 0xd5, 0x67, 0x92, 0x92, 0x90, 0xcd, 0x15, 0xe5, 0x0d, 0x22,
+	0xfb, 0x8c, 0xf4, 0xd3, 0x62, 0x1d, 0xbe, 0x9a, 0x06, 0xd5, 0x69, 0x97, 0xda, 0xf1, 0x3f, 0x9a,
+	0xa4, 0xeb, 0xe1, 0x62, 0x87, 0x34, 0x30, 0xde, 0xf0, 0x0b, 0xa9, 0x4f, 0x53, 0xbf, 0xb7, 0x3a,
+	0x01, 0xfe, 0x43, 0x88, 0x61, 0x9f, 0x94, 0xbb, 0x0b, 0x05, 0x08, 0x94, 0x3d, 0xcb, 0x04, 0x8f,
+	0x0c, 0xe6, 0xa4, 0x13, 0x14, 0x22, 0xbd, 0x4b, 0x7a, 0x61, 0x9a, 0x9f, 0x42, 0x4c, 0x31, 0x2b,
+	0x8d, 0xa0, 0x1b, 0xa6, 0x39, 0x44, 0x9b, 0xee, 0x93, 0xe1, 0x85, 0xcc, 0x6c, 0xce, 0xe3, 0x53,
+	0x25, 0x94, 0xce, 0xae, 0x30, 0x39, 0xed, 0x60, 0xc3, 0xa3, 0x27, 0x08, 0xd2, 0xa7, 0x64, 0x33,
+	0x13, 0x46, 0x46, 0x22, 0xb1, 0x85, 0x5d, 0x17, 0xed, 0x86, 0x05, 0xec, 0x0d, 0x19, 0xe9, 0x42,
+	0x60, 0x78, 0x12, 0xb1, 0x9e, 0xcb, 0x96, 0x17, 0xe9, 0x03, 0x42, 0xc4, 0x5f, 0x45, 0x98, 0x5b,
+	0x3e, 0x8b, 0x05, 0xeb, 0xa3, 0xb2, 0x82, 0x80, 0xa3, 0x3c, 0x5b, 0x18, 0x46, 0xdc, 0xde, 0x86,
+	0xff, 0xb1, 0x26, 0xc3, 0x00, 0xca, 0x20, 0xb3, 0xdf, 0xd6, 0xe3, 0x56, 0xdb, 0xd0, 0xb2, 0xe7,
+	0xb5, 0xbe, 0xad, 0xe7, 0xbd, 0x23, 0x9b, 0xe5, 0x84, 0x37, 0xcd, 0xfd, 0xf8, 0xd7, 0x64, 0xbd,
+	0x24, 0xf9, 0x4a, 0x05, 0xae, 0x4c, 0x54, 0xa9, 0xc0, 0x3f, 0x91, 0xf5, 0x4f, 0x96, 0xdb, 0x1f,
+	0xaa, 0xb1, 0x73, 0xb2, 0xe1, 0xe9, 0x6f, 0x5c, 0xde, 0x0f, 0x5c, 0x05, 0x16, 0xa5, 0xdd, 0x03,
+	0x7f, 0x80, 0xd3, 0xd5, 0xa2, 0x19, 0xff, 0x92, 0x10, 0x3f, 0x05, 0x04, 0xe0, 0xf0, 0x5a, 0x00,
+	0xb6, 0x8b, 0x01, 0x9f, 0xdb, 0x80, 0xff, 0x6c, 0x90, 0x36, 0xe8, 0xbe, 0x33, 0xd9, 0x8f, 0xc8,
+	0xba, 0x2b, 0xcd, 0xd3, 0x1c, 0xbb, 0x45, 0x1b, 0x0b, 0x74, 0xe0, 0xb0, 0x3f, 0x00, 0x44, 0xef,
+	0x91, 0x3e, 0x6c, 0x04, 0xa7, 0xef, 0xa0, 0x1e, 0x76, 0x86, 0x53, 0x7e, 0xc7, 0x99, 0xf3, 0x67,
+	0xb2, 0x79, 0x22, 0xd4, 0xfb, 0x64, 0xae, 0xff, 0x8f, 0x18, 0xee, 0x43, 0x63, 0x53, 0x32, 0x99,
+	0x6b, 0x74, 0xc2, 0x37, 0x88, 0x82, 0xb7, 0xd0, 0x41, 0x35, 0x95, 0x73, 0x7d, 0xa5, 0x9a, 0x56,
+	0xd6, 0x53, 0x09, 0xe7, 0xdf, 0xd7, 0x49, 0xd7, 0x6b, 0xa1, 0x71, 0x2b, 0xa1, 0xac, 0xb6, 0x3c,
+	0xc6, 0x55, 0xb6, 0x83, 0x52, 0x76, 0x8d, 0x56, 0xcd, 0x33, 0x21, 0x70, 0x3d, 0xed, 0xa0, 0x10,
+	0xe9, 0x18, 0x23, 0xcb, 0x2f, 0xb8, 0x8c, 0x71, 0xf3, 0xb6, 0x50, 0x5d, 0xc3, 0x60, 0xf4, 0x2c,
+	0x9f, 0xcf, 0xe1, 0xf0, 0x74, 0x81, 0x2f, 0x44, 0x38, 0xd4, 0x42, 0x1e, 0x9e, 0x89, 0xc8, 0x47,
+	0xdc, 0x4b, 0xd0, 0x10, 0xcc, 0x25, 0x4f, 0xbd, 0xce, 0xb5, 0x9d, 0x0a, 0x02, 0xe3, 0x78, 0x68,
+	0xe5, 0x85, 0xf0, 0xad, 0xc6, 0x4b, 0xe0, 0x83, 0x4c, 0xbc, 0xa6, 0xe7, 0x7c, 0x28, 0x64, 0xe0,
+	0x74, 0x7f, 0x3c, 0xd1, 0x09, 0x36, 0x99, 0x76, 0x50, 0x41, 0xc0, 0x13, 0x99, 0x2c, 0x65, 0x6c,
+	0x36, 0xed, 0xa0, 0x86, 0x2d, 0x39, 0xe6, 0x32, 0x16, 0x6c, 0x50, 0xe5, 0x00, 0xa4, 0xca, 0x81,
+	0x16, 0xeb, 0x75, 0x0e, 0xb4, 0xd9, 0x23, 0x83, 0x3c, 0x11, 0x17, 0x32, 0x74, 0xdd, 0x6e, 0xc3,
+	0x95, 0x62, 0x05, 0xc2, 0x68, 0xc3, 0xc9, 0x28, 0x22, 0x36, 0xf4, 0xd1, 0x76, 0x22, 0x54, 0x3d,
+	0x44, 0xc1, 0x25, 0x69, 0x13, 0x75, 0x4b, 0x00, 0x8f, 0xde, 0x4b, 0x9e, 0x62, 0x9a, 0xb6, 0x9c,
+	0xf7, 0x85, 0x0c, 0xe7, 0x42, 0x24, 0x33, 0x7b, 0xc5, 0xb6, 0x51, 0xe1, 0x04, 0xe0, 0xbb, 0xcc,
+	0xa4, 0x15, 0x33, 0x1e, 0x9e, 0x33, 0xea, 0xf8, 0x4a, 0x00, 0xb4, 0xe0, 0x75, 0xca, 0x17, 0xc2,
+	0xb0, 0x1d, 0xa7, 0x2d, 0x01, 0xc8, 0x81, 0xe2, 0x69, 0x2a, 0x22, 0x76, 0xcb, 0xe5, 0xc0, 0x49,
+	0x30, 0x93, 0x39, 0x53, 0x42, 0xb1, 0xdb, 0x6e, 0x26, 0x14, 0x60, 0xab, 0x98, 0x98, 0xcf, 0xd8,
+	0x2e, 0x82, 0xf8, 0x0f, 0xd1, 0x32, 0x99, 0x08, 0x63, 0x2e, 0x15, 0x86, 0xe2, 0x8e, 0x8b, 0x56,
+	0x15, 0xc3, 0x4a, 0xc8, 0x13, 0x8f, 0x30, 0xe6, 0x2b, 0xa1, 0x44, 0x20, 0x9a, 0xe7, 0x78, 0xd3,
+	0x31, 0x16, 0x7c, 0xb8, 0xeb, 0xa2, 0x59, 0x81, 0x80, 0x01, 0x16, 0x8c, 0xa1, 0x35, 0x6c, 0xe4,
+	0x18, 0x96, 0x08, 0x30, 0x24, 0x73, 0x93, 0x27, 0xc6, 0xe5, 0xe3, 0x9e, 0x63, 0xa8, 0x40, 0xe0,
+	0xe9, 0x4c, 0xe7, 0x70, 0xa1, 0xb9, 0xef, 0x3c, 0x75, 0x12, 0xac, 0xbf, 0x0c, 0x96, 0x55, 0x29,
+	0xfb, 0x91, 0x5b, 0x7f, 0x15, 0x03, 0x76, 0xd8, 0xea, 0xd2, 0xc6, 0x52, 0x49, 0xcb, 0x1e, 0x38,
+	0xf6, 0x0a, 0xb4, 0xb4, 0xb0, 0x22, 0xe2, 0x86, 0x3d, 0xac, 0x5a, 0x20, 0x04, 0xf3, 0x5c, 0x28,
+	0x1e, 0xc7, 0x3a, 0x74, 0x89, 0xdf, 0x73, 0xf3, 0x54, 0x31, 0x60, 0xf1, 0x72, 0x6e, 0x44, 0xc4,
+	0x1e, 0x39, 0x96, 0x0a, 0x54, 0x61, 0x09, 0xcf, 0xf2, 0xe4, 0x9c, 0x8d, 0x6b, 0x2c, 0x88, 0xd1,
+	0xe7, 0x64, 0xfb, 0x8c, 0x67, 0xd1, 0x25, 0xcf, 0x44, 0xa8, 0xb3, 0x2c, 0x4f, 0xad, 0x88, 0xd8,
+	0x8f, 0xd1, 0xf0, 0xba, 0x82, 0x3e, 0x26, 0x1b, 0x50, 0x0e, 0x67, 0xf9, 0x42, 0xb8, 0x1a, 0x79,
+	0xec, 0xee, 0x07, 0x35, 0x90, 0x3e, 0x21, 0x43, 0x2c, 0x81, 0xa5, 0xd9, 0xbe, 0xbb, 0x1e, 0xd4,
+	0xd1, 0xd2, 0x2e, 0x55, 0x91, 0xaf, 0xab, 0x27, 0x15, 0xbb, 0x12, 0x85, 0x2a, 0x0f, 0x15, 0x77,
+	0x91, 0x78, 0xea, 0xfb, 0xb4, 0x97, 0xf1, 0x8a, 0xa1, 0x38, 0x6e, 0x80, 0x03, 0xb7, 0x73, 0xbc,
+	0x08, 0xec, 0xe5, 0x54, 0x6e, 0xec, 0x33, 0xc7, 0x5e, 0x47, 0xc1, 0xa7, 0x12, 0x41, 0x9e, 0x9f,
+	0x38, 0x9f, 0x6a, 0x60, 0xcd, 0x2a, 0x33, 0x17, 0x11, 0xfb, 0xe9, 0x8a, 0x15, 0x80, 0x35, 0x2b,
+	0x93, 0x67, 0x29, 0x7b, 0xbe, 0x62, 0x05, 0x20, 0xe4, 0xa5, 0x04, 0xe4, 0xdf, 0x04, 0x3b, 0x74,
+	0x79, 0xa9, 0x62, 0x90, 0xdd, 0x48, 0x66, 0x22, 0xb4, 0x8a, 0xa7, 0xaf, 0xce, 0xd9, 0x91, 0xcb,
+	0x6e, 0x05, 0xaa, 0x59, 0x4c, 0x15, 0x9b, 0xac, 0x58, 0x4c, 0x55, 0xcd, 0xe2, 0xe5, 0x82, 0xbd,
+	0x58, 0xb1, 0x78, 0xb9, 0x98, 0xfe, 0xbb, 0x49, 0x9a, 0x1f, 0x3e, 0xd1, 0xd7, 0x84, 0x2c, 0xdf,
+	0x2f, 0xb4, 0xfe, 0x50, 0x29, 0xee, 0x1d, 0xa3, 0x9d, 0x55, 0x18, 0xce, 0x9f, 0x29, 0xe9, 0xe0,
+	0x9b, 0x85, 0xee, 0x5e, 0xbb, 0xbf, 0xff, 0x06, 0xde, 0xb5, 0xa3, 0xed, 0xe2, 0xdc, 0x3b, 0x86,
+	0xf3, 0x0e, 0xc7, 0xbc, 0x22, 0x03, 0x1c, 0xf3, 0xc9, 0x66, 0x82, 0x2b, 0xba, 0x05, 0xbc, 0xd5,
+	0x87, 0xcf, 0x08, 0xef, 0x03, 0xb5, 0xd7, 0xcc, 0x8b, 0x06, 0x9d, 0x92, 0x35, 0x7f, 0xab, 0xfc,
+	0xd2, 0x54, 0x5b, 0xb5, 0x93, 0x0f, 0x66, 0xfa, 0x39, 0xe9, 0x97, 0xf7, 0xff, 0x2f, 0x0e, 0xa3,
+	0x95, 0x9b, 0xf8, 0xf2, 0x99, 0xd0, 0xf5, 0x17, 0x32, 0x4a, 0x6b, 0xb7, 0x33, 0xb7, 0xc0, 0xad,
+	0x1a, 0x06, 0x03, 0x9e, 0x91, 0x0e, 0x5e, 0x60, 0x9c, 0x37, 0xd5, 0xfb, 0xda, 0x68, 0x58, 0x41,
+	0xd2, 0xf8, 0xea, 0xed, 0xaf, 0xe0, 0x6d, 0xa9, 0x00, 0xe4, 0xa9, 0x7c, 0xdb, 0xf9, 0x60, 0xde,
+	0xa4, 0xf2, 0x63, 0xe3, 0x8f, 0xfb, 0x0b, 0x69, 0xcf, 0xf2, 0x19, 0x84, 0x6b, 0x62, 0x79, 0xac,
+	0xcd, 0xa1, 0xb9, 0x32, 0x56, 0x28, 0xe3, 0xa4, 0x09, 0x4f, 0xe5, 0x44, 0x9b, 0xd9, 0x1a, 0xae,
+	0xfe, 0x67, 0xff, 0x1d, 0x00, 0xfe, 0xc0, 0x87, 0xa4, 0x58, 0x10, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1533,6 +1691,7 @@ const _ = grpc.SupportPackageIsVersion4
 type OSClient interface {
 	Containers(ctx context.Context, in *ContainersRequest, opts ...grpc.CallOption) (*ContainersReply, error)
 	Dmesg(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*common.DataReply, error)
+	DmesgStream(ctx context.Context, in *DmesgRequest, opts ...grpc.CallOption) (OS_DmesgStreamClient, error)
 	Memory(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MemInfoReply, error)
 	Processes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ProcessesReply, error)
 	Restart(ctx context.Context, in *RestartRequest, opts ...grpc.CallOption) (*RestartReply, error)
@@ -1565,6 +1724,38 @@ func (c *oSClient) Dmesg(ctx context.Context, in *empty.Empty, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *oSClient) DmesgStream(ctx context.Context, in *DmesgRequest, opts ...grpc.CallOption) (OS_DmesgStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OS_serviceDesc.Streams[0], "/os.OS/DmesgStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oSDmesgStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OS_DmesgStreamClient interface {
+	Recv() (*KernelMessage, error)
+	grpc.ClientStream
+}
+
+type oSDmesgStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *oSDmesgStreamClient) Recv() (*KernelMessage, error) {
+	m := new(KernelMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *oSClient) Memory(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MemInfoReply, error) {
 	out := new(MemInfoReply)
 	err := c.cc.Invoke(ctx, "/os.OS/Memory", in, out, opts...)
@@ -1605,6 +1796,7 @@ func (c *oSClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.Cal
 type OSServer interface {
 	Containers(context.Context, *ContainersRequest) (*ContainersReply, error)
 	Dmesg(context.Context, *empty.Empty) (*common.DataReply, error)
+	DmesgStream(*DmesgRequest, OS_DmesgStreamServer) error
 	Memory(context.Context, *empty.Empty) (*MemInfoReply, error)
 	Processes(context.Context, *empty.Empty) (*ProcessesReply, error)
 	Restart(context.Context, *RestartRequest) (*RestartReply, error)
@@ -1651,6 +1843,27 @@ func _OS_Dmesg_Handler(srv interface{}, ctx context.Context, dec func(interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OS_DmesgStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DmesgRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OSServer).DmesgStream(m, &oSDmesgStreamServer{stream})
+}
+
+type OS_DmesgStreamServer interface {
+	Send(*KernelMessage) error
+	grpc.ServerStream
+}
+
+type oSDmesgStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *oSDmesgStreamServer) Send(m *KernelMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _OS_Memory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -1752,6 +1965,12 @@ var _OS_serviceDesc = grpc.ServiceDesc{
 			Handler:    _OS_Stats_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DmesgStream",
+			Handler:       _OS_DmesgStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "os/os.proto",
 }
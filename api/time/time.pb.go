@@ -9,6 +9,7 @@ import (
 	math "math"
 
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
@@ -116,13 +117,15 @@ func (m *TimeReply) GetResponse() []*TimeResponse {
 }
 
 type TimeResponse struct {
-	Metadata             *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Server               string               `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
-	Localtime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=localtime,proto3" json:"localtime,omitempty"`
-	Remotetime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=remotetime,proto3" json:"remotetime,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Metadata   *common.NodeMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Server     string               `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	Localtime  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=localtime,proto3" json:"localtime,omitempty"`
+	Remotetime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=remotetime,proto3" json:"remotetime,omitempty"`
+	// Sources is the state of each of the configured time servers
+	Sources              []*TimeSource `protobuf:"bytes,5,rep,name=sources,proto3" json:"sources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *TimeResponse) Reset()         { *m = TimeResponse{} }
@@ -182,37 +185,169 @@ func (m *TimeResponse) GetRemotetime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *TimeResponse) GetSources() []*TimeSource {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+type TimeSource struct {
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// Offset is the offset of the server clock from the local clock
+	Offset *duration.Duration `protobuf:"bytes,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Delay is the round trip time of the last query
+	Delay *duration.Duration `protobuf:"bytes,3,opt,name=delay,proto3" json:"delay,omitempty"`
+	// Jitter is the root mean square of the differences between the recent offsets
+	Jitter  *duration.Duration `protobuf:"bytes,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	Stratum uint32             `protobuf:"varint,5,opt,name=stratum,proto3" json:"stratum,omitempty"`
+	// Reach is the reachability register, each bit is the result of one of the last 8 queries, the lowest bit being the last
+	Reach uint32 `protobuf:"varint,6,opt,name=reach,proto3" json:"reach,omitempty"`
+	// Selected is set on the source the clock is synchronized to
+	Selected bool `protobuf:"varint,7,opt,name=selected,proto3" json:"selected,omitempty"`
+	// Falseticker is set on the sources whose time disagrees with the majority of the sources
+	Falseticker bool `protobuf:"varint,8,opt,name=falseticker,proto3" json:"falseticker,omitempty"`
+	// Error is the error of the last query
+	Error                string   `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TimeSource) Reset()         { *m = TimeSource{} }
+func (m *TimeSource) String() string { return proto.CompactTextString(m) }
+func (*TimeSource) ProtoMessage()    {}
+func (*TimeSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7ed1ef5b20ef4ce, []int{3}
+}
+
+func (m *TimeSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeSource.Unmarshal(m, b)
+}
+
+func (m *TimeSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeSource.Marshal(b, m, deterministic)
+}
+
+func (m *TimeSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeSource.Merge(m, src)
+}
+
+func (m *TimeSource) XXX_Size() int {
+	return xxx_messageInfo_TimeSource.Size(m)
+}
+
+func (m *TimeSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeSource proto.InternalMessageInfo
+
+func (m *TimeSource) GetServer() string {
+	if m != nil {
+		return m.Server
+	}
+	return ""
+}
+
+func (m *TimeSource) GetOffset() *duration.Duration {
+	if m != nil {
+		return m.Offset
+	}
+	return nil
+}
+
+func (m *TimeSource) GetDelay() *duration.Duration {
+	if m != nil {
+		return m.Delay
+	}
+	return nil
+}
+
+func (m *TimeSource) GetJitter() *duration.Duration {
+	if m != nil {
+		return m.Jitter
+	}
+	return nil
+}
+
+func (m *TimeSource) GetStratum() uint32 {
+	if m != nil {
+		return m.Stratum
+	}
+	return 0
+}
+
+func (m *TimeSource) GetReach() uint32 {
+	if m != nil {
+		return m.Reach
+	}
+	return 0
+}
+
+func (m *TimeSource) GetSelected() bool {
+	if m != nil {
+		return m.Selected
+	}
+	return false
+}
+
+func (m *TimeSource) GetFalseticker() bool {
+	if m != nil {
+		return m.Falseticker
+	}
+	return false
+}
+
+func (m *TimeSource) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*TimeRequest)(nil), "time.TimeRequest")
 	proto.RegisterType((*TimeReply)(nil), "time.TimeReply")
 	proto.RegisterType((*TimeResponse)(nil), "time.TimeResponse")
+	proto.RegisterType((*TimeSource)(nil), "time.TimeSource")
 }
 
 func init() { proto.RegisterFile("time/time.proto", fileDescriptor_e7ed1ef5b20ef4ce) }
 
 var fileDescriptor_e7ed1ef5b20ef4ce = []byte{
-	// 332 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xc1, 0x4e, 0x32, 0x31,
-	0x14, 0x85, 0x33, 0x3f, 0x84, 0x1f, 0x2e, 0x24, 0xc4, 0x6a, 0x08, 0x19, 0x17, 0x12, 0x12, 0x23,
-	0x1b, 0xa7, 0x06, 0x37, 0x46, 0x57, 0x62, 0x5c, 0x6a, 0xcc, 0xc4, 0x95, 0xbb, 0x32, 0x5c, 0xa1,
-	0x71, 0xca, 0xad, 0xd3, 0x62, 0xc2, 0x4b, 0xfa, 0x4c, 0xa6, 0xed, 0x88, 0x8d, 0x2c, 0xdc, 0x4c,
-	0xa7, 0xf7, 0x9c, 0x33, 0x3d, 0xf9, 0xa6, 0xd0, 0xb7, 0x52, 0x21, 0x77, 0x8f, 0x4c, 0x57, 0x64,
-	0x89, 0x35, 0xdd, 0x7b, 0x7a, 0xbc, 0x24, 0x5a, 0x96, 0xc8, 0xfd, 0x6c, 0xbe, 0x79, 0xe5, 0xa8,
-	0xb4, 0xdd, 0x06, 0x4b, 0x7a, 0xf2, 0x5b, 0x74, 0x11, 0x63, 0x85, 0xd2, 0xb5, 0xe1, 0xb0, 0x20,
-	0xa5, 0x68, 0xcd, 0xc3, 0x12, 0x86, 0xe3, 0x53, 0xe8, 0x3e, 0x4b, 0x85, 0x39, 0xbe, 0x6f, 0xd0,
-	0x58, 0x36, 0x80, 0x96, 0xc1, 0xea, 0x03, 0xab, 0x61, 0x32, 0x4a, 0x26, 0x9d, 0xbc, 0xde, 0x8d,
-	0x6f, 0xa0, 0x13, 0x6c, 0xba, 0xdc, 0xb2, 0x0c, 0xda, 0x15, 0x1a, 0x4d, 0x6b, 0x83, 0xc3, 0x64,
-	0xd4, 0x98, 0x74, 0xa7, 0x2c, 0xf3, 0x5d, 0x83, 0x25, 0x28, 0xf9, 0xce, 0x33, 0xfe, 0x4c, 0xa0,
-	0x17, 0x4b, 0xec, 0x02, 0xda, 0x0a, 0xad, 0x58, 0x08, 0x2b, 0xfc, 0x39, 0xdd, 0xe9, 0x51, 0x56,
-	0xb7, 0x7a, 0xa4, 0x05, 0x3e, 0xd4, 0x5a, 0xbe, 0x73, 0x45, 0xbd, 0xfe, 0xc5, 0xbd, 0xd8, 0x15,
-	0x74, 0x4a, 0x2a, 0x44, 0xe9, 0x8e, 0x1f, 0x36, 0xfc, 0xa7, 0xd2, 0x2c, 0x80, 0xc8, 0xbe, 0x41,
-	0xf8, 0x5a, 0x1e, 0x44, 0xfe, 0x63, 0x66, 0xd7, 0x00, 0x15, 0x2a, 0xb2, 0xe8, 0xa3, 0xcd, 0x3f,
-	0xa3, 0x91, 0x7b, 0xba, 0x82, 0xa6, 0x13, 0x18, 0xaf, 0xd7, 0xc1, 0x5e, 0xee, 0xde, 0xfd, 0x98,
-	0xb4, 0x1f, 0x63, 0x71, 0xe4, 0x78, 0xc0, 0x78, 0xb7, 0xc2, 0xe2, 0x8d, 0x1d, 0xc4, 0xaa, 0xc7,
-	0xbf, 0x17, 0x98, 0xcd, 0xa0, 0x57, 0x90, 0x0a, 0x53, 0xa1, 0xe5, 0xec, 0xbf, 0x93, 0x6e, 0xb5,
-	0x7c, 0x4a, 0x5e, 0xce, 0x96, 0xd2, 0xae, 0x36, 0x73, 0x07, 0x8e, 0x5b, 0x51, 0x92, 0x39, 0x37,
-	0x5b, 0x63, 0x51, 0x99, 0xb0, 0xe3, 0x42, 0x4b, 0x7f, 0x05, 0xe6, 0x2d, 0xdf, 0xea, 0xf2, 0x2b,
-	0x00, 0x00, 0xff, 0xff, 0xa7, 0x62, 0x03, 0x24, 0x55, 0x02, 0x00, 0x00,
+	// 484 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xcd, 0x8e, 0xd3, 0x30,
+	0x10, 0xc7, 0x95, 0xdd, 0x7e, 0x4e, 0x16, 0x2d, 0x98, 0xd5, 0xca, 0x04, 0x09, 0xa2, 0x4a, 0x88,
+	0x0a, 0x89, 0x04, 0xca, 0x05, 0xc1, 0x89, 0x02, 0x47, 0x10, 0x0a, 0x7b, 0xe2, 0xe6, 0x26, 0xd3,
+	0x36, 0x6c, 0x5c, 0x07, 0x7b, 0x82, 0xd4, 0xa7, 0xe3, 0xbd, 0x38, 0x21, 0xdb, 0x69, 0x1b, 0x6d,
+	0x85, 0x7a, 0x89, 0x33, 0xf3, 0xff, 0x8d, 0xe7, 0x43, 0x63, 0xb8, 0xa4, 0x52, 0x62, 0x6a, 0x3f,
+	0x49, 0xad, 0x15, 0x29, 0xd6, 0xb3, 0xff, 0xd1, 0x93, 0x95, 0x52, 0xab, 0x0a, 0x53, 0xe7, 0x5b,
+	0x34, 0xcb, 0xb4, 0x68, 0xb4, 0xa0, 0x52, 0x6d, 0x3c, 0x15, 0x3d, 0xbe, 0xab, 0xa3, 0xac, 0x69,
+	0xdb, 0x8a, 0x4f, 0xef, 0x8a, 0xf6, 0x4a, 0x43, 0x42, 0xd6, 0x2d, 0xf0, 0x30, 0x57, 0x52, 0xaa,
+	0x4d, 0xea, 0x0f, 0xef, 0x9c, 0x3c, 0x83, 0xf0, 0xa6, 0x94, 0x98, 0xe1, 0xaf, 0x06, 0x0d, 0xb1,
+	0x6b, 0x18, 0x18, 0xd4, 0xbf, 0x51, 0xf3, 0x20, 0x0e, 0xa6, 0xe3, 0xac, 0xb5, 0x26, 0xef, 0x61,
+	0xec, 0xb1, 0xba, 0xda, 0xb2, 0x04, 0x46, 0x1a, 0x4d, 0xad, 0x36, 0x06, 0x79, 0x10, 0x9f, 0x4f,
+	0xc3, 0x19, 0x4b, 0x5c, 0x2f, 0x1e, 0xf1, 0x4a, 0xb6, 0x67, 0x26, 0x7f, 0x03, 0xb8, 0xe8, 0x4a,
+	0xec, 0x15, 0x8c, 0x24, 0x92, 0x28, 0x04, 0x09, 0x97, 0x27, 0x9c, 0x5d, 0x25, 0x6d, 0x55, 0x5f,
+	0x55, 0x81, 0x5f, 0x5a, 0x2d, 0xdb, 0x53, 0x9d, 0xba, 0xce, 0xba, 0x75, 0xb1, 0xb7, 0x30, 0xae,
+	0x54, 0x2e, 0x2a, 0x9b, 0x9e, 0x9f, 0xbb, 0xab, 0xa2, 0xc4, 0x0f, 0x22, 0xd9, 0x0d, 0x22, 0xb9,
+	0xd9, 0x0d, 0x22, 0x3b, 0xc0, 0xec, 0x1d, 0x80, 0x46, 0xa9, 0x08, 0x5d, 0x68, 0xef, 0x64, 0x68,
+	0x87, 0x66, 0x2f, 0x60, 0x68, 0x54, 0xa3, 0x73, 0x34, 0xbc, 0xef, 0xfa, 0xbf, 0x7f, 0xe8, 0xff,
+	0xbb, 0x13, 0xb2, 0x1d, 0x30, 0xf9, 0x73, 0x06, 0x70, 0xf0, 0xff, 0x6f, 0xc0, 0xec, 0x35, 0x0c,
+	0xd4, 0x72, 0x69, 0x90, 0x5c, 0x83, 0xe1, 0xec, 0xd1, 0x51, 0x29, 0x9f, 0xda, 0x5d, 0xc8, 0x5a,
+	0x90, 0xa5, 0xd0, 0x2f, 0xb0, 0x12, 0x5b, 0x7e, 0x7e, 0x2a, 0xc2, 0x73, 0x36, 0xc7, 0xcf, 0x92,
+	0x08, 0x35, 0xef, 0x9d, 0x8a, 0x68, 0x41, 0xc6, 0x61, 0x68, 0x48, 0x0b, 0x6a, 0x24, 0xef, 0xc7,
+	0xc1, 0xf4, 0x5e, 0xb6, 0x33, 0xd9, 0x15, 0xf4, 0x35, 0x8a, 0x7c, 0xcd, 0x07, 0xce, 0xef, 0x0d,
+	0x16, 0xc1, 0xc8, 0x60, 0x85, 0x39, 0x61, 0xc1, 0x87, 0x71, 0x30, 0x1d, 0x65, 0x7b, 0x9b, 0xc5,
+	0x10, 0x2e, 0x45, 0x65, 0x90, 0xca, 0xfc, 0x16, 0x35, 0x1f, 0x39, 0xb9, 0xeb, 0xb2, 0x77, 0xa2,
+	0xd6, 0x4a, 0xf3, 0xb1, 0x9b, 0x8d, 0x37, 0x66, 0x6b, 0xe8, 0xd9, 0x01, 0xb2, 0xb4, 0x3d, 0xaf,
+	0x8f, 0xca, 0xfe, 0x6c, 0x9f, 0x41, 0x74, 0xd9, 0x5d, 0x42, 0xbb, 0xa7, 0xa9, 0x5f, 0xda, 0x8f,
+	0x6b, 0xcc, 0x6f, 0xd9, 0x83, 0xae, 0xea, 0x96, 0xfd, 0x28, 0x60, 0x3e, 0x87, 0x8b, 0x5c, 0x49,
+	0xef, 0x15, 0x75, 0x39, 0x1f, 0x5a, 0xe9, 0x43, 0x5d, 0x7e, 0x0b, 0x7e, 0x3c, 0x5f, 0x95, 0xb4,
+	0x6e, 0x16, 0x76, 0x4d, 0x53, 0x12, 0x95, 0x32, 0x2f, 0xcd, 0xd6, 0x10, 0x4a, 0xe3, 0xad, 0x54,
+	0xd4, 0xa5, 0x7b, 0x70, 0x8b, 0x81, 0xab, 0xea, 0xcd, 0xbf, 0x01, 0x00, 0xc4, 0x23, 0xbd, 0x7f,
+	0xe3, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -35,6 +35,10 @@ captures ownership and permission bits.`,
 			os.Exit(1)
 		}
 
+		if len(target) > 1 {
+			helpers.Fatalf("files can only be copied from a single node")
+		}
+
 		setupClient(func(c *client.Client) {
 			r, errCh, err := c.CopyOut(globalCtx, args[0])
 			if err != nil {
@@ -6,10 +6,15 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
@@ -27,24 +32,65 @@ var dmesgCmd = &cobra.Command{
 		}
 
 		setupClient(func(c *client.Client) {
-			md := metadata.New(make(map[string]string))
-			md.Set("targets", target...)
-			reply, err := c.Dmesg(metadata.NewOutgoingContext(globalCtx, md))
+			stream, err := c.DmesgStream(globalCtx, follow, dmesgTail)
 			if err != nil {
 				helpers.Fatalf("error getting dmesg: %s", err)
 			}
 
-			for _, resp := range reply.Response {
-				if len(reply.Response) > 1 {
-					fmt.Println(resp.Metadata.Hostname)
+			var errs nodeErrors
+
+			for {
+				msg, err := stream.Recv()
+				if err != nil {
+					if err == io.EOF || status.Code(err) == codes.Canceled {
+						errs.Exit()
+						return
+					}
+					helpers.Fatalf("error streaming dmesg: %s", err)
+				}
+
+				if errs.Report(msg.Metadata) {
+					continue
 				}
-				_, err = os.Stdout.Write(resp.Bytes.Bytes)
+
+				if len(target) > 1 && msg.Metadata != nil {
+					fmt.Printf("%s: ", msg.Metadata.Hostname)
+				}
+
+				timestamp, err := ptypes.Timestamp(msg.Timestamp)
 				helpers.Should(err)
+
+				fmt.Printf("%s: %s: [%s]: %s\n",
+					kmsgName(kmsgFacilities, msg.Facility),
+					kmsgName(kmsgPriorities, msg.Priority),
+					timestamp.Format(time.RFC3339Nano),
+					msg.Message,
+				)
 			}
 		})
 	},
 }
 
+var kmsgFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "", "", "", "",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+var kmsgPriorities = []string{
+	"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug",
+}
+
+func kmsgName(names []string, n int32) string {
+	if n >= 0 && int(n) < len(names) && names[n] != "" {
+		return names[n]
+	}
+
+	return strconv.Itoa(int(n))
+}
+
 func init() {
+	dmesgCmd.Flags().BoolVarP(&follow, "follow", "f", false, "specify if the kernel log should be streamed")
+	dmesgCmd.Flags().BoolVarP(&dmesgTail, "tail", "", false, "specify if only new messages should be sent (makes sense only when combined with --follow)")
 	rootCmd.AddCommand(dmesgCmd)
 }
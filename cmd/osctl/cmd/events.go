@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+)
+
+// eventsCmd represents the events command
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Stream machine events",
+	Long: `Stream machine events: sequences (boot, shutdown, reboot, upgrade, reset) and their phases
+starting and finishing, service state and health changes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 0 {
+			helpers.Should(cmd.Usage())
+			os.Exit(1)
+		}
+
+		setupClient(func(c *client.Client) {
+			stream, err := c.Events(globalCtx)
+			if err != nil {
+				helpers.Fatalf("error fetching events: %s", err)
+			}
+
+			var errs nodeErrors
+
+			for {
+				e, err := stream.Recv()
+				if err != nil {
+					if err == io.EOF || status.Code(err) == codes.Canceled {
+						errs.Exit()
+						return
+					}
+					helpers.Fatalf("error streaming events: %s", err)
+				}
+
+				if errs.Report(e.Metadata) {
+					continue
+				}
+
+				if len(target) > 1 && e.Metadata != nil {
+					fmt.Printf("%s: ", e.Metadata.Hostname)
+				}
+
+				fmt.Println(formatEvent(e))
+			}
+		})
+	},
+}
+
+func formatEvent(e *machineapi.Event) string {
+	var details string
+
+	switch e.Type {
+	case machineapi.EventType_SEQUENCE:
+		details = fmt.Sprintf("%s %s", e.Sequence.Sequence, strings.ToLower(e.Sequence.Action.String()))
+		if e.Sequence.Error != "" {
+			details += ": " + e.Sequence.Error
+		}
+	case machineapi.EventType_PHASE:
+		details = fmt.Sprintf("%q %s", e.Phase.Phase, strings.ToLower(e.Phase.Action.String()))
+		if e.Phase.Error != "" {
+			details += ": " + e.Phase.Error
+		}
+	case machineapi.EventType_SERVICE:
+		details = fmt.Sprintf("%s %s: %s", e.Service.Service, e.Service.State, e.Service.Message)
+	}
+
+	ts, err := ptypes.Timestamp(e.Ts)
+	helpers.Should(err)
+
+	return fmt.Sprintf("%s %-8s %s", ts.Format(time.RFC3339), strings.ToLower(e.Type.String()), details)
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+}
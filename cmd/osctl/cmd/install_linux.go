@@ -7,7 +7,6 @@ package cmd
 
 import (
 	"log"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
 
@@ -55,7 +54,6 @@ var installCmd = &cobra.Command{
 		}
 
 		cmdline := kernel.NewCmdline("")
-		cmdline.Append("initrd", filepath.Join("/", "default", constants.InitramfsAsset))
 		cmdline.Append(constants.KernelParamPlatform, platformArg)
 		cmdline.Append(constants.KernelParamConfig, endpoint)
 		if err = cmdline.AppendAll(config.Machine().Install().ExtraKernelArgs()); err != nil {
@@ -63,17 +61,17 @@ var installCmd = &cobra.Command{
 		}
 		cmdline.AppendDefaults()
 
-		i, err := installer.NewInstaller(cmdline, config.Machine().Install())
-		if err != nil {
-			log.Fatal(err)
-		}
-
 		sequence := runtime.None
 		if upgradeArg {
 			sequence = runtime.Upgrade
 		}
 
-		if err = i.Install(sequence); err != nil {
+		i, err := installer.NewInstaller(cmdline, sequence, config.Machine().Install())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err = i.Install(); err != nil {
 			log.Fatal(err)
 		}
 
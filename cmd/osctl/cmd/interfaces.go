@@ -7,10 +7,16 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	networkapi "github.com/talos-systems/talos/api/network"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
@@ -21,7 +27,10 @@ import (
 var interfacesCmd = &cobra.Command{
 	Use:   "interfaces",
 	Short: "List network interfaces",
-	Long:  ``,
+	Long: `List network interfaces.
+
+With --watch, stream the changes of the interfaces and their addresses as they
+happen instead, e.g. link flaps and DHCP address changes.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 0 {
 			helpers.Should(cmd.Usage())
@@ -29,6 +38,12 @@ var interfacesCmd = &cobra.Command{
 		}
 
 		setupClient(func(c *client.Client) {
+			if watch {
+				watchNetwork(c, networkapi.WatchType_LINK, networkapi.WatchType_ADDRESS)
+
+				return
+			}
+
 			reply, err := c.Interfaces(globalCtx)
 			if err != nil {
 				helpers.Fatalf("error getting interfaces: %s", err)
@@ -60,6 +75,62 @@ func intersRender(reply *networkapi.InterfacesReply) {
 	helpers.Should(w.Flush())
 }
 
+// watchNetwork prints the network changes of the types until the stream
+// ends.
+func watchNetwork(c *client.Client, types ...networkapi.WatchType) {
+	stream, err := c.WatchNetwork(globalCtx, types...)
+	if err != nil {
+		helpers.Fatalf("error watching network: %s", err)
+	}
+
+	var errs nodeErrors
+
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF || status.Code(err) == codes.Canceled {
+				errs.Exit()
+				return
+			}
+			helpers.Fatalf("error streaming network changes: %s", err)
+		}
+
+		if errs.Report(e.Metadata) {
+			continue
+		}
+
+		if len(target) > 1 && e.Metadata != nil {
+			fmt.Printf("%s: ", e.Metadata.Hostname)
+		}
+
+		fmt.Println(formatWatchEvent(e))
+	}
+}
+
+func formatWatchEvent(e *networkapi.WatchEvent) string {
+	var details string
+
+	switch e.Type {
+	case networkapi.WatchType_LINK:
+		details = fmt.Sprintf("%s %s mtu %d %s", e.Interface.Name, operState(e.Interface.Operstate), e.Interface.Mtu, e.Interface.Hardwareaddr)
+	case networkapi.WatchType_ADDRESS:
+		details = fmt.Sprintf("%s %s", e.Interface.Name, e.Address.Address)
+	case networkapi.WatchType_ROUTE:
+		details = fmt.Sprintf("%s via %s dev %s metric %d", e.Route.Destination, e.Route.Gateway, e.Route.Interface, e.Route.Metric)
+	}
+
+	ts, err := ptypes.Timestamp(e.Ts)
+	helpers.Should(err)
+
+	return fmt.Sprintf("%s %-6s %-7s %s", ts.Format(time.RFC3339), strings.ToLower(e.Action.String()), strings.ToLower(e.Type.String()), details)
+}
+
+// operState returns the operational state like ip link does, e.g. "UP".
+func operState(state networkapi.OperState) string {
+	return strings.TrimPrefix(state.String(), "OPER_")
+}
+
 func init() {
+	interfacesCmd.Flags().BoolVarP(&watch, "watch", "w", false, "stream the changes of the interfaces and their addresses")
 	rootCmd.AddCommand(interfacesCmd)
 }
@@ -29,6 +29,10 @@ Kubeconfig will be written to PWD/kubeconfig or [local-path]/kubeconfig if speci
 			os.Exit(1)
 		}
 
+		if len(target) > 1 {
+			helpers.Fatalf("kubeconfig can only be retrieved from a single node")
+		}
+
 		setupClient(func(c *client.Client) {
 			r, errCh, err := c.KubeconfigRaw(globalCtx)
 			if err != nil {
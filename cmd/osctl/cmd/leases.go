@@ -0,0 +1,116 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// nolint: golint
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/spf13/cobra"
+
+	networkapi "github.com/talos-systems/talos/api/network"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+)
+
+var leasesEvents bool
+
+// leasesCmd represents the net leases command
+var leasesCmd = &cobra.Command{
+	Use:   "leases",
+	Short: "List DHCP leases",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 0 {
+			helpers.Should(cmd.Usage())
+			os.Exit(1)
+		}
+
+		setupClient(func(c *client.Client) {
+			reply, err := c.Leases(globalCtx)
+			if err != nil {
+				helpers.Fatalf("error getting leases: %s", err)
+			}
+
+			if leasesEvents {
+				leaseEventsRender(reply)
+				return
+			}
+
+			leasesRender(reply)
+		})
+	},
+}
+
+func leasesRender(reply *networkapi.LeasesReply) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tINTERFACE\tSTATE\tADDRESS\tSERVER\tRENEW\tEXPIRY")
+
+	for _, resp := range reply.Response {
+		node := ""
+
+		if resp.Metadata != nil {
+			node = resp.Metadata.Hostname
+		}
+
+		for _, lease := range resp.Leases {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", node, lease.Interface, leaseState(lease.State), lease.Address, lease.Server, leaseTime(lease.Renew), leaseTime(lease.Expiry))
+		}
+	}
+
+	helpers.Should(w.Flush())
+}
+
+func leaseEventsRender(reply *networkapi.LeasesReply) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tTIME\tINTERFACE\tSTATE\tADDRESS\tERROR")
+
+	for _, resp := range reply.Response {
+		node := ""
+
+		if resp.Metadata != nil {
+			node = resp.Metadata.Hostname
+		}
+
+		for _, event := range resp.Events {
+			lease := event.Lease
+			if lease == nil {
+				lease = &networkapi.Lease{}
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", node, leaseTime(event.Ts), lease.Interface, leaseState(lease.State), lease.Address, event.Error)
+		}
+	}
+
+	helpers.Should(w.Flush())
+}
+
+func leaseState(state networkapi.LeaseState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "LEASE_"))
+}
+
+func leaseTime(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func init() {
+	leasesCmd.Flags().BoolVar(&leasesEvents, "events", false, "list the recent changes of the leases instead")
+	rootCmd.AddCommand(leasesCmd)
+}
@@ -5,6 +5,8 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 	"os"
 
@@ -42,29 +44,106 @@ var logsCmd = &cobra.Command{
 				driver = common.ContainerDriver_CRI
 			}
 
-			stream, err := c.Logs(globalCtx, namespace, driver, args[0])
+			stream, err := c.Logs(globalCtx, namespace, driver, args[0], follow, tailLines)
 			if err != nil {
 				helpers.Fatalf("error fetching logs: %s", err)
 			}
 
+			w := newLogWriter(os.Stdout, len(target) > 1)
+
+			var errs nodeErrors
+
 			for {
 				data, err := stream.Recv()
 				if err != nil {
 					if err == io.EOF || status.Code(err) == codes.Canceled {
+						helpers.Should(w.Flush())
+						errs.Exit()
 						return
 					}
 					helpers.Fatalf("error streaming logs: %s", err)
 				}
 
-				_, err = os.Stdout.Write(data.Bytes)
-				helpers.Should(err)
+				if errs.Report(data.Metadata) {
+					continue
+				}
+
+				helpers.Should(w.Write(data))
 			}
 		})
 	},
 }
 
+// logWriter writes log chunks streamed from one or more nodes.
+//
+// When logs of several nodes are interleaved, chunks are split into lines
+// and each line is prefixed with the node it came from.
+type logWriter struct {
+	out     io.Writer
+	prefix  bool
+	partial map[string][]byte
+}
+
+func newLogWriter(out io.Writer, prefix bool) *logWriter {
+	return &logWriter{
+		out:     out,
+		prefix:  prefix,
+		partial: map[string][]byte{},
+	}
+}
+
+func (w *logWriter) Write(data *common.Data) error {
+	if !w.prefix {
+		_, err := w.out.Write(data.Bytes)
+		return err
+	}
+
+	var node string
+	if data.Metadata != nil {
+		node = data.Metadata.Hostname
+	}
+
+	buf := append(w.partial[node], data.Bytes...)
+
+	for {
+		idx := bytes.IndexByte(buf, '\n')
+		if idx < 0 {
+			break
+		}
+
+		if _, err := fmt.Fprintf(w.out, "%s: %s", node, buf[:idx+1]); err != nil {
+			return err
+		}
+
+		buf = buf[idx+1:]
+	}
+
+	w.partial[node] = buf
+
+	return nil
+}
+
+// Flush writes out any incomplete trailing lines.
+func (w *logWriter) Flush() error {
+	for node, buf := range w.partial {
+		if len(buf) == 0 {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w.out, "%s: %s\n", node, buf); err != nil {
+			return err
+		}
+	}
+
+	w.partial = map[string][]byte{}
+
+	return nil
+}
+
 func init() {
 	logsCmd.Flags().BoolVarP(&kubernetes, "kubernetes", "k", false, "use the k8s.io containerd namespace")
 	logsCmd.Flags().BoolVarP(&useCRI, "use-cri", "c", false, "use the CRI driver")
+	logsCmd.Flags().BoolVarP(&follow, "follow", "f", false, "specify if the logs should be streamed")
+	logsCmd.Flags().Int32Var(&tailLines, "tail", 0, "lines of log file to display (default is to show from the beginning)")
 	rootCmd.AddCommand(logsCmd)
 }
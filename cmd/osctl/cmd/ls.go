@@ -53,17 +53,28 @@ var lsCmd = &cobra.Command{
 				helpers.Fatalf("error fetching logs: %s", err)
 			}
 
+			multipleNodes := len(target) > 1
+
+			var errs nodeErrors
+
 			if !long {
 				for {
 					info, err := stream.Recv()
 					if err != nil {
 						if err == io.EOF || status.Code(err) == codes.Canceled {
+							errs.Exit()
 							return
 						}
 						helpers.Fatalf("error streaming results: %s", err)
 					}
+					if errs.Report(info.Metadata) {
+						continue
+					}
+
 					if info.Error != "" {
 						fmt.Fprintf(os.Stderr, "error reading file %s: %s\n", info.Name, info.Error)
+					} else if multipleNodes {
+						fmt.Printf("%s\t%s\n", info.Metadata.Hostname, info.RelativeName)
 					} else {
 						fmt.Println(info.RelativeName)
 					}
@@ -71,17 +82,26 @@ var lsCmd = &cobra.Command{
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-			fmt.Fprintln(w, "MODE\tSIZE(B)\tLASTMOD\tNAME")
+			if multipleNodes {
+				fmt.Fprintln(w, "NODE\tMODE\tSIZE(B)\tLASTMOD\tNAME")
+			} else {
+				fmt.Fprintln(w, "MODE\tSIZE(B)\tLASTMOD\tNAME")
+			}
 			for {
 				info, err := stream.Recv()
 				if err != nil {
 					if err == io.EOF || status.Code(err) == codes.Canceled {
 						helpers.Should(w.Flush())
+						errs.Exit()
 						return
 					}
 					helpers.Fatalf("error streaming results: %s", err)
 				}
 
+				if errs.Report(info.Metadata) {
+					continue
+				}
+
 				if info.Error != "" {
 					fmt.Fprintf(os.Stderr, "error reading file %s: %s\n", info.Name, info.Error)
 				} else {
@@ -89,6 +109,9 @@ var lsCmd = &cobra.Command{
 					if info.Link != "" {
 						display += " -> " + info.Link
 					}
+					if multipleNodes {
+						fmt.Fprintf(w, "%s\t", info.Metadata.Hostname)
+					}
 					fmt.Fprintf(w, "%s\t%d\t%s\t%s\n",
 						os.FileMode(info.Mode).String(),
 						info.Size,
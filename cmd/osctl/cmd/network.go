@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// nolint: golint
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	networkapi "github.com/talos-systems/talos/api/network"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+)
+
+var (
+	networkApplyTimeout time.Duration
+	networkApplyConfirm bool
+)
+
+// networkCmd represents the network command
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Manage the network configuration",
+	Long:  ``,
+}
+
+// networkApplyCmd represents the network apply command
+var networkApplyCmd = &cobra.Command{
+	Use:   "apply <file>",
+	Short: "Apply a network configuration",
+	Long: `Apply the network section of the machine config in the file (machine.network) to the running node.
+The changes are rolled back unless they are confirmed before the timeout expires, the confirmation is
+sent once the changes are applied, which verifies that the node is still reachable. A change which is
+not confirmed yet is also rolled back if networkd restarts.
+
+The changes are not persistent: the machine config is not changed, and the network configuration of the
+machine config is applied again on reboot. Update the machine config to keep the changes.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		network, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			helpers.Fatalf("error reading the network config: %s", err)
+		}
+
+		setupClient(func(c *client.Client) {
+			reply, err := c.ApplyNetwork(globalCtx, network, networkApplyTimeout)
+			if err != nil {
+				helpers.Fatalf("error applying the network config: %s", err)
+			}
+
+			networkChangesRender(reply)
+
+			fmt.Println("the changes are not persistent, they are lost on reboot unless the machine config is updated")
+
+			if !networkApplyConfirm {
+				fmt.Printf("confirm the changes with \"osctl network confirm\" within %s\n", networkApplyTimeout)
+				return
+			}
+
+			if _, err = c.ConfirmNetwork(globalCtx); err != nil {
+				helpers.Fatalf("error confirming the network changes, they will be rolled back: %s", err)
+			}
+		})
+	},
+}
+
+// networkConfirmCmd represents the network confirm command
+var networkConfirmCmd = &cobra.Command{
+	Use:   "confirm",
+	Short: "Confirm the applied network configuration",
+	Long:  ``,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		setupClient(func(c *client.Client) {
+			if _, err := c.ConfirmNetwork(globalCtx); err != nil {
+				helpers.Fatalf("error confirming the network changes: %s", err)
+			}
+		})
+	},
+}
+
+func networkChangesRender(reply *networkapi.ApplyReply) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tCHANGE")
+
+	for _, resp := range reply.Response {
+		node := ""
+
+		if resp.Metadata != nil {
+			node = resp.Metadata.Hostname
+		}
+
+		for _, change := range resp.Changes {
+			fmt.Fprintf(w, "%s\t%s\n", node, change)
+		}
+	}
+
+	helpers.Should(w.Flush())
+}
+
+func init() {
+	networkApplyCmd.Flags().DurationVar(&networkApplyTimeout, "timeout", time.Minute, "the time to confirm the changes before they are rolled back")
+	networkApplyCmd.Flags().BoolVar(&networkApplyConfirm, "confirm", true, "confirm the changes once they are applied")
+
+	networkCmd.AddCommand(networkApplyCmd, networkConfirmCmd)
+	rootCmd.AddCommand(networkCmd)
+}
@@ -7,26 +7,51 @@ package cmd
 
 import (
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	machineapi "github.com/talos-systems/talos/api/machine"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
 )
 
+var (
+	resetForce  bool
+	resetReboot bool
+	resetScope  string
+)
+
 // resetCmd represents the reset command
 var resetCmd = &cobra.Command{
 	Use:   "reset",
 	Short: "Reset a node",
-	Long:  ``,
+	Long: `Reset wipes the node so that it can be reused, once the reset is done the
+node is powered off (or rebooted with --reboot).
+
+With --scope=ephemeral only the ephemeral partition (Kubernetes and etcd data)
+is wiped, and the node boots again from the installed system disk. With
+--scope=system-disk the whole system disk is wiped, and the node has to be
+installed again.
+
+Before wiping, the node is cordoned and drained, and a control plane node
+leaves etcd. The reset is refused if the etcd cluster would lose quorum once
+the node leaves it. With --force the node is wiped right away.
+
+Nodes running in containers can't be reset, recreate the container instead.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 0 {
 			helpers.Should(cmd.Usage())
 			os.Exit(1)
 		}
 
+		scope, ok := machineapi.ResetScope_value[strings.ToUpper(strings.Replace(resetScope, "-", "_", -1))]
+		if !ok {
+			helpers.Fatalf("unknown reset scope %q", resetScope)
+		}
+
 		setupClient(func(c *client.Client) {
-			if err := c.Reset(globalCtx); err != nil {
+			if err := c.Reset(globalCtx, resetForce, resetReboot, machineapi.ResetScope(scope)); err != nil {
 				helpers.Fatalf("error executing reset: %s", err)
 			}
 		})
@@ -34,5 +59,8 @@ var resetCmd = &cobra.Command{
 }
 
 func init() {
+	resetCmd.Flags().BoolVar(&resetForce, "force", false, "wipe the node without cordoning and draining it and leaving etcd, even if the etcd cluster loses quorum")
+	resetCmd.Flags().BoolVar(&resetReboot, "reboot", false, "reboot the node once the reset is done instead of powering it off")
+	resetCmd.Flags().StringVar(&resetScope, "scope", "ephemeral", "what to wipe: ephemeral (the ephemeral partition) or system-disk (the whole system disk)")
 	rootCmd.AddCommand(resetCmd)
 }
@@ -0,0 +1,39 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// nolint: dupl,golint
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+)
+
+// rollbackCmd represents the rollback command
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Rollback a node to the previous installation",
+	Long: `Rollback switches the node back to the boot slot of the previous installation
+(the one before the last upgrade), and reboots it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 0 {
+			helpers.Should(cmd.Usage())
+			os.Exit(1)
+		}
+
+		setupClient(func(c *client.Client) {
+			if err := c.Rollback(globalCtx); err != nil {
+				helpers.Fatalf("error executing rollback: %s", err)
+			}
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/talos-systems/talos/api/common"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
 	"github.com/talos-systems/talos/pkg/constants"
@@ -28,6 +29,8 @@ var (
 	crt            string
 	additionalSANs []string
 	csr            string
+	dmesgTail      bool
+	follow         bool
 	hours          int
 	ip             string
 	key            string
@@ -36,8 +39,10 @@ var (
 	name           string
 	organization   string
 	rsa            bool
+	tailLines      int32
 	talosconfig    string
 	target         []string
+	watch          bool
 	cmdcontext     string
 )
 
@@ -129,6 +134,32 @@ func setupClient(action func(*client.Client)) {
 	action(c)
 }
 
+// nodeErrors tracks the errors reported by the nodes in a streamed reply.
+type nodeErrors struct {
+	failed bool
+}
+
+// Report prints the error reported by the node, if any, and returns whether
+// there was one.
+func (e *nodeErrors) Report(md *common.NodeMetadata) bool {
+	if md == nil || md.Error == "" {
+		return false
+	}
+
+	fmt.Fprintf(os.Stderr, "%s: %s\n", md.Hostname, md.Error)
+
+	e.failed = true
+
+	return true
+}
+
+// Exit exits with a non-zero status if any node reported an error.
+func (e *nodeErrors) Exit() {
+	if e.failed {
+		os.Exit(1)
+	}
+}
+
 // nolint: gocyclo
 func extractTarGz(localPath string, r io.Reader) {
 	zr, err := gzip.NewReader(r)
@@ -21,7 +21,9 @@ import (
 var routesCmd = &cobra.Command{
 	Use:   "routes",
 	Short: "List network routes",
-	Long:  ``,
+	Long: `List network routes.
+
+With --watch, stream the changes of the routes as they happen instead.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 0 {
 			helpers.Should(cmd.Usage())
@@ -29,6 +31,12 @@ var routesCmd = &cobra.Command{
 		}
 
 		setupClient(func(c *client.Client) {
+			if watch {
+				watchNetwork(c, networkapi.WatchType_ROUTE)
+
+				return
+			}
+
 			reply, err := c.Routes(globalCtx)
 			if err != nil {
 				helpers.Fatalf("error getting routes: %s", err)
@@ -59,5 +67,6 @@ func routesRender(reply *networkapi.RoutesReply) {
 }
 
 func init() {
+	routesCmd.Flags().BoolVarP(&watch, "watch", "w", false, "stream the changes of the routes")
 	rootCmd.AddCommand(routesCmd)
 }
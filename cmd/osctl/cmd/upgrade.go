@@ -18,13 +18,28 @@ import (
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
 )
 
-var upgradeImage string
+var (
+	upgradeImage string
+	upgradeForce bool
+	upgradeStage bool
+)
 
 // upgradeCmd represents the processes command
 var upgradeCmd = &cobra.Command{
 	Use:   "upgrade",
 	Short: "Upgrade Talos on the target node",
-	Long:  ``,
+	Long: `Upgrade pulls and validates the installer image first, while the node keeps
+running. Only once that succeeds, the node is drained, and the upgrade is
+installed. The progress can be followed with osctl events.
+
+With --stage, the upgrade is applied on the next reboot instead. The etcd
+cluster is validated again before the staged upgrade is applied, it stays
+staged if the validation fails.
+
+The upgrade is installed into the boot slot the node didn't boot from. If the
+node boots the new installation 3 times without becoming healthy, it falls
+back to the previous boot slot; this works the same with BIOS and UEFI. A boot
+which hangs before it can reboot is only counted once the node is power cycled.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		upgrade()
 	},
@@ -32,6 +47,8 @@ var upgradeCmd = &cobra.Command{
 
 func init() {
 	upgradeCmd.Flags().StringVarP(&upgradeImage, "image", "u", "", "the container image to use for performing the install")
+	upgradeCmd.Flags().BoolVar(&upgradeForce, "force", false, "force the upgrade even if etcd members are down, or the etcd cluster loses quorum once the node leaves it")
+	upgradeCmd.Flags().BoolVar(&upgradeStage, "stage", false, "pull and validate the installer image now, and apply the upgrade on the next reboot")
 	rootCmd.AddCommand(upgradeCmd)
 }
 
@@ -44,7 +61,7 @@ func upgrade() {
 	setupClient(func(c *client.Client) {
 		// TODO: See if we can validate version and prevent starting upgrades to
 		// an unknown version
-		reply, err = c.Upgrade(globalCtx, upgradeImage)
+		reply, err = c.Upgrade(globalCtx, upgradeImage, upgradeForce, upgradeStage)
 	})
 
 	if err != nil {
@@ -15,6 +15,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
@@ -238,8 +239,13 @@ func (c *Client) Restart(ctx context.Context, namespace string, driver common.Co
 }
 
 // Reset implements the proto.OSClient interface.
-func (c *Client) Reset(ctx context.Context) (err error) {
-	_, err = c.MachineClient.Reset(ctx, &empty.Empty{})
+func (c *Client) Reset(ctx context.Context, force, reboot bool, scope machineapi.ResetScope) (err error) {
+	_, err = c.MachineClient.Reset(ctx, &machineapi.ResetRequest{
+		Force:  force,
+		Reboot: reboot,
+		Scope:  scope,
+	})
+
 	return
 }
 
@@ -249,6 +255,12 @@ func (c *Client) Reboot(ctx context.Context) (err error) {
 	return
 }
 
+// Rollback implements the proto.OSClient interface.
+func (c *Client) Rollback(ctx context.Context) (err error) {
+	_, err = c.MachineClient.Rollback(ctx, &empty.Empty{})
+	return
+}
+
 // Shutdown implements the proto.OSClient interface.
 func (c *Client) Shutdown(ctx context.Context) (err error) {
 	_, err = c.MachineClient.Shutdown(ctx, &empty.Empty{})
@@ -260,12 +272,27 @@ func (c *Client) Dmesg(ctx context.Context) (*common.DataReply, error) {
 	return c.client.Dmesg(ctx, &empty.Empty{})
 }
 
+// DmesgStream implements the proto.OSClient interface.
+func (c *Client) DmesgStream(ctx context.Context, follow, tail bool) (osapi.OS_DmesgStreamClient, error) {
+	return c.client.DmesgStream(ctx, &osapi.DmesgRequest{
+		Follow: follow,
+		Tail:   tail,
+	})
+}
+
+// Events implements the proto.OSClient interface.
+func (c *Client) Events(ctx context.Context) (machineapi.Machine_EventsClient, error) {
+	return c.MachineClient.Events(ctx, &machineapi.EventsRequest{})
+}
+
 // Logs implements the proto.OSClient interface.
-func (c *Client) Logs(ctx context.Context, namespace string, driver common.ContainerDriver, id string) (stream machineapi.Machine_LogsClient, err error) {
+func (c *Client) Logs(ctx context.Context, namespace string, driver common.ContainerDriver, id string, follow bool, tailLines int32) (stream machineapi.Machine_LogsClient, err error) {
 	stream, err = c.MachineClient.Logs(ctx, &machineapi.LogsRequest{
 		Namespace: namespace,
 		Driver:    driver,
 		Id:        id,
+		Follow:    follow,
+		TailLines: tailLines,
 	})
 
 	return
@@ -286,6 +313,26 @@ func (c *Client) Interfaces(ctx context.Context) (*networkapi.InterfacesReply, e
 	return c.NetworkClient.Interfaces(ctx, &empty.Empty{})
 }
 
+// Leases implements the proto.NetworkClient interface.
+func (c *Client) Leases(ctx context.Context) (*networkapi.LeasesReply, error) {
+	return c.NetworkClient.Leases(ctx, &empty.Empty{})
+}
+
+// WatchNetwork implements the proto.NetworkClient interface.
+func (c *Client) WatchNetwork(ctx context.Context, types ...networkapi.WatchType) (networkapi.Network_WatchClient, error) {
+	return c.NetworkClient.Watch(ctx, &networkapi.WatchRequest{Types: types})
+}
+
+// ApplyNetwork implements the proto.NetworkClient interface.
+func (c *Client) ApplyNetwork(ctx context.Context, network []byte, timeout time.Duration) (*networkapi.ApplyReply, error) {
+	return c.NetworkClient.Apply(ctx, &networkapi.ApplyRequest{Network: network, Timeout: uint32(timeout / time.Second)})
+}
+
+// ConfirmNetwork implements the proto.NetworkClient interface.
+func (c *Client) ConfirmNetwork(ctx context.Context) (*networkapi.ConfirmReply, error) {
+	return c.NetworkClient.Confirm(ctx, &empty.Empty{})
+}
+
 // Processes implements the proto.OSClient interface.
 func (c *Client) Processes(ctx context.Context) (reply *osapi.ProcessesReply, err error) {
 	return c.client.Processes(ctx, &empty.Empty{})
@@ -320,8 +367,8 @@ func (c *Client) CopyOut(ctx context.Context, rootPath string) (io.Reader, <-cha
 
 // Upgrade initiates a Talos upgrade ... and implements the proto.OSClient
 // interface
-func (c *Client) Upgrade(ctx context.Context, image string) (*machineapi.UpgradeReply, error) {
-	return c.MachineClient.Upgrade(ctx, &machineapi.UpgradeRequest{Image: image})
+func (c *Client) Upgrade(ctx context.Context, image string, force, stage bool) (*machineapi.UpgradeReply, error) {
+	return c.MachineClient.Upgrade(ctx, &machineapi.UpgradeRequest{Image: image, Force: force, Stage: stage})
 }
 
 // ServiceList returns list of services with their state
@@ -387,6 +434,12 @@ func readStream(stream machineStream) (io.Reader, <-chan error, error) {
 				return
 			}
 
+			if data.Metadata != nil && data.Metadata.Error != "" {
+				//nolint: errcheck
+				pw.CloseWithError(fmt.Errorf("%s: %s", data.Metadata.Hostname, data.Metadata.Error))
+				return
+			}
+
 			if data.Bytes != nil {
 				_, err = pw.Write(data.Bytes)
 				if err != nil {
@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"github.com/talos-systems/talos/api"
+	"github.com/talos-systems/talos/internal/app/apid/pkg/proxy"
 	"github.com/talos-systems/talos/pkg/config"
 	"github.com/talos-systems/talos/pkg/constants"
 	"github.com/talos-systems/talos/pkg/grpc/factory"
@@ -82,6 +83,7 @@ func main() {
 	}
 
 	protoProxy := api.NewApiProxy(provider)
+	streamProxy := proxy.NewStreamProxy(provider)
 
 	err = factory.ListenAndServe(
 		&api.Registrator{
@@ -91,6 +93,7 @@ func main() {
 			NetworkClient: networkClient,
 		},
 		factory.Port(constants.OsdPort),
+		factory.WithStreamInterceptor(streamProxy.StreamInterceptor()),
 		factory.WithStreamInterceptor(protoProxy.StreamInterceptor()),
 		factory.WithUnaryInterceptor(protoProxy.UnaryInterceptor()),
 		factory.WithDefaultLog(),
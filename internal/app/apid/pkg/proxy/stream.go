@@ -0,0 +1,245 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package proxy fans the streaming requests of the API out to the nodes.
+//
+// The proxy generated into the api package forwards the streaming requests
+// to the first target only, the StreamProxy interceptor runs before it and
+// takes over the streaming methods it knows about.
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/talos-systems/talos/api/common"
+	"github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/api/network"
+	"github.com/talos-systems/talos/api/os"
+	"github.com/talos-systems/talos/pkg/constants"
+	"github.com/talos-systems/talos/pkg/grpc/tls"
+)
+
+// stream describes the messages of a streaming method. The response messages
+// have a Metadata field, which is set to the node the message came from.
+type stream struct {
+	request  func() proto.Message
+	response func() proto.Message
+}
+
+// streams are the streaming methods which are fanned out to the targets.
+var streams = map[string]stream{
+	"/os.OS/DmesgStream": {
+		request:  func() proto.Message { return &os.DmesgRequest{} },
+		response: func() proto.Message { return &os.KernelMessage{} },
+	},
+	"/machine.Machine/CopyOut": {
+		request:  func() proto.Message { return &machine.CopyOutRequest{} },
+		response: func() proto.Message { return &machine.StreamingData{} },
+	},
+	"/machine.Machine/Events": {
+		request:  func() proto.Message { return &machine.EventsRequest{} },
+		response: func() proto.Message { return &machine.Event{} },
+	},
+	"/machine.Machine/Kubeconfig": {
+		request:  func() proto.Message { return &empty.Empty{} },
+		response: func() proto.Message { return &machine.StreamingData{} },
+	},
+	"/machine.Machine/LS": {
+		request:  func() proto.Message { return &machine.LSRequest{} },
+		response: func() proto.Message { return &machine.FileInfo{} },
+	},
+	"/machine.Machine/Logs": {
+		request:  func() proto.Message { return &machine.LogsRequest{} },
+		response: func() proto.Message { return &common.Data{} },
+	},
+	"/network.Network/Watch": {
+		request:  func() proto.Message { return &network.WatchRequest{} },
+		response: func() proto.Message { return &network.WatchEvent{} },
+	},
+}
+
+// StreamProxy fans the streaming requests out to all the targets, and
+// interleaves the replies into the stream of the client.
+type StreamProxy struct {
+	credentials func() (credentials.TransportCredentials, error)
+	dial        func(ctx context.Context, target string, creds credentials.TransportCredentials) (*grpc.ClientConn, error)
+}
+
+// NewStreamProxy initializes a StreamProxy, which authenticates to the
+// targets with the certificates of the provider.
+func NewStreamProxy(provider tls.CertificateProvider) *StreamProxy {
+	return &StreamProxy{
+		credentials: func() (credentials.TransportCredentials, error) {
+			ca, err := provider.GetCA()
+			if err != nil {
+				return nil, err
+			}
+
+			certs, err := provider.GetCertificate(nil)
+			if err != nil {
+				return nil, err
+			}
+
+			tlsConfig, err := tls.New(
+				tls.WithClientAuthType(tls.Mutual),
+				tls.WithCACertPEM(ca),
+				tls.WithKeypair(*certs),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return credentials.NewTLS(tlsConfig), nil
+		},
+		dial: func(ctx context.Context, target string, creds credentials.TransportCredentials) (*grpc.ClientConn, error) {
+			return grpc.DialContext(ctx, fmt.Sprintf("%s:%d", target, constants.OsdPort), grpc.WithTransportCredentials(creds))
+		},
+	}
+}
+
+// StreamInterceptor proxies the streaming methods which are fanned out, the
+// other methods and the requests proxied from another node are passed on to
+// the next interceptor.
+func (p *StreamProxy) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		if _, ok := md["proxyfrom"]; ok {
+			return handler(srv, ss)
+		}
+
+		s, ok := streams[info.FullMethod]
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		creds, err := p.credentials()
+		if err != nil {
+			return err
+		}
+
+		return p.proxy(ss, info.FullMethod, s, creds)
+	}
+}
+
+// proxy sends the request to every target. Each message is tagged with the
+// node it came from; a failure of one node is sent as a message carrying the
+// error in its metadata instead of aborting the streams of the other nodes.
+func (p *StreamProxy) proxy(ss grpc.ServerStream, method string, s stream, creds credentials.TransportCredentials) error {
+	md, _ := metadata.FromIncomingContext(ss.Context())
+
+	// default to target node specified in config or on cli
+	targets, ok := md["targets"]
+	if !ok {
+		targets = md[":authority"]
+	}
+
+	proxyMd := metadata.New(nil)
+	proxyMd.Set("proxyfrom", md[":authority"]...)
+
+	in := s.request()
+	if err := ss.RecvMsg(in); err != nil {
+		return err
+	}
+
+	// tie the lifetime of the upstream calls to the downstream one
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(ss.Context(), proxyMd))
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		sendErr error
+	)
+
+	// grpc.ServerStream is not safe for concurrent SendMsg calls
+	send := func(msg proto.Message, nodeMd *common.NodeMetadata) error {
+		setMetadata(msg, nodeMd)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		err := ss.SendMsg(msg)
+		if err != nil && sendErr == nil {
+			sendErr = err
+
+			cancel()
+		}
+
+		return err
+	}
+
+	wg.Add(len(targets))
+
+	for _, target := range targets {
+		go func(target string) {
+			defer wg.Done()
+
+			err := p.proxyTarget(ctx, target, method, in, s.response, creds, send)
+			if err != nil && ctx.Err() == nil {
+				// nolint: errcheck
+				send(s.response(), &common.NodeMetadata{Hostname: target, Error: err.Error()})
+			}
+		}(target)
+	}
+
+	wg.Wait()
+
+	return sendErr
+}
+
+// proxyTarget copies the replies of the target to the client.
+func (p *StreamProxy) proxyTarget(ctx context.Context, target, method string, in proto.Message, newResponse func() proto.Message, creds credentials.TransportCredentials, send func(proto.Message, *common.NodeMetadata) error) error {
+	conn, err := p.dial(ctx, target, creds)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	cs, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, method)
+	if err != nil {
+		return err
+	}
+
+	if err = cs.SendMsg(in); err != nil {
+		return err
+	}
+
+	if err = cs.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		msg := newResponse()
+
+		err = cs.RecvMsg(msg)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = send(msg, &common.NodeMetadata{Hostname: target}); err != nil {
+			return err
+		}
+	}
+}
+
+// setMetadata sets the Metadata field of a response message.
+func setMetadata(msg proto.Message, md *common.NodeMetadata) {
+	reflect.ValueOf(msg).Elem().FieldByName("Metadata").Set(reflect.ValueOf(md))
+}
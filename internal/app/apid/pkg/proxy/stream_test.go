@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/talos-systems/talos/api/common"
+	"github.com/talos-systems/talos/api/machine"
+)
+
+type StreamSuite struct {
+	suite.Suite
+
+	servers []*grpc.Server
+}
+
+func TestStreamSuite(t *testing.T) {
+	suite.Run(t, new(StreamSuite))
+}
+
+// logsServer serves the logs of a node.
+type logsServer struct {
+	machine.MachineServer
+
+	lines []string
+	err   error
+}
+
+func (s *logsServer) Logs(in *machine.LogsRequest, srv machine.Machine_LogsServer) error {
+	for _, line := range s.lines {
+		if err := srv.Send(&common.Data{Bytes: []byte(in.Id + ": " + line)}); err != nil {
+			return err
+		}
+	}
+
+	return s.err
+}
+
+func (suite *StreamSuite) TearDownTest() {
+	for _, s := range suite.servers {
+		s.Stop()
+	}
+
+	suite.servers = nil
+}
+
+func (suite *StreamSuite) TestStreamsHaveMetadata() {
+	for method, s := range streams {
+		msg := s.response()
+
+		suite.Assert().NotPanics(func() { setMetadata(msg, &common.NodeMetadata{Hostname: "node"}) }, method)
+	}
+}
+
+func (suite *StreamSuite) TestFanOut() {
+	nodes := map[string]*logsServer{
+		"node1": {lines: []string{"a", "b"}},
+		"node2": {lines: []string{"c"}},
+		"node3": {lines: []string{"d"}, err: errors.New("disk on fire")},
+	}
+
+	listeners := map[string]*bufconn.Listener{}
+
+	for name, srv := range nodes {
+		listeners[name] = suite.serve(srv)
+	}
+
+	p := &StreamProxy{
+		credentials: func() (credentials.TransportCredentials, error) { return nil, nil },
+		dial: func(ctx context.Context, target string, _ credentials.TransportCredentials) (*grpc.ClientConn, error) {
+			l, ok := listeners[target]
+			if !ok {
+				return nil, errors.New("unknown node")
+			}
+
+			return grpc.DialContext(ctx, target, grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return l.Dial()
+			}))
+		},
+	}
+
+	front := suite.serve(&logsServer{}, grpc.StreamInterceptor(p.StreamInterceptor()))
+
+	conn, err := grpc.Dial("front", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return front.Dial()
+	}))
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "targets", "node1", "targets", "node2", "targets", "node3", "targets", "node4")
+
+	stream, err := machine.NewMachineClient(conn).Logs(ctx, &machine.LogsRequest{Id: "kubelet"})
+	suite.Require().NoError(err)
+
+	var replies []string
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		suite.Require().NoError(err)
+
+		if msg.Metadata.Error != "" {
+			replies = append(replies, msg.Metadata.Hostname+" error")
+			continue
+		}
+
+		replies = append(replies, msg.Metadata.Hostname+" "+string(msg.Bytes))
+	}
+
+	sort.Strings(replies)
+
+	suite.Assert().Equal([]string{
+		"node1 kubelet: a",
+		"node1 kubelet: b",
+		"node2 kubelet: c",
+		"node3 error",
+		"node3 kubelet: d",
+		"node4 error",
+	}, replies)
+}
+
+func (suite *StreamSuite) serve(srv machine.MachineServer, opts ...grpc.ServerOption) *bufconn.Listener {
+	l := bufconn.Listen(1 << 16)
+
+	s := grpc.NewServer(opts...)
+	machine.RegisterMachineServer(s, srv)
+
+	// nolint: errcheck
+	go s.Serve(l)
+
+	suite.servers = append(suite.servers, s)
+
+	return l
+}
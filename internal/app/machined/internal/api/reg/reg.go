@@ -20,17 +20,21 @@ import (
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
 
+	"github.com/containerd/containerd/reference"
 	criconstants "github.com/containerd/cri/pkg/constants"
 
 	"github.com/talos-systems/talos/api/common"
 	"github.com/talos-systems/talos/api/machine"
 	machineapi "github.com/talos-systems/talos/api/machine"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	servicelog "github.com/talos-systems/talos/internal/app/machined/pkg/system/log"
 	"github.com/talos-systems/talos/internal/pkg/containers"
 	"github.com/talos-systems/talos/internal/pkg/containers/containerd"
 	"github.com/talos-systems/talos/internal/pkg/containers/cri"
 	"github.com/talos-systems/talos/internal/pkg/etcd"
 	"github.com/talos-systems/talos/internal/pkg/event"
+	"github.com/talos-systems/talos/internal/pkg/installer/bootloader/syslinux"
+	"github.com/talos-systems/talos/internal/pkg/kernel"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
 	"github.com/talos-systems/talos/internal/pkg/runtime/platform"
 	"github.com/talos-systems/talos/pkg/archiver"
@@ -96,16 +100,25 @@ func (r *Registrator) Shutdown(ctx context.Context, in *empty.Empty) (reply *mac
 
 // Upgrade initiates an upgrade.
 func (r *Registrator) Upgrade(ctx context.Context, in *machineapi.UpgradeRequest) (data *machineapi.UpgradeReply, err error) {
-	if err = etcd.ValidateForUpgrade(); err != nil {
+	if err = etcd.ValidateForUpgrade(in.GetForce()); err != nil {
 		return nil, err
 	}
 
+	if _, err = reference.Parse(in.GetImage()); err != nil {
+		return nil, fmt.Errorf("invalid installer image reference %q: %w", in.GetImage(), err)
+	}
+
 	event.Bus().Notify(event.Event{Type: event.Upgrade, Data: in})
 
+	ack := "Upgrade request received"
+	if in.GetStage() {
+		ack = "Upgrade staging request received, the upgrade is applied on the next reboot"
+	}
+
 	data = &machineapi.UpgradeReply{
 		Response: []*machineapi.UpgradeResponse{
 			{
-				Ack: "Upgrade request received",
+				Ack: ack,
 			},
 		},
 	}
@@ -113,21 +126,119 @@ func (r *Registrator) Upgrade(ctx context.Context, in *machineapi.UpgradeRequest
 	return data, err
 }
 
-// Reset resets the node.
-func (r *Registrator) Reset(ctx context.Context, in *empty.Empty) (data *machineapi.ResetReply, err error) {
-	// Stop the kubelet.
-	if err = system.Services(r.config).Stop(ctx, "kubelet"); err != nil {
-		return data, err
+// Reset initiates a reset of the node.
+func (r *Registrator) Reset(ctx context.Context, in *machineapi.ResetRequest) (data *machineapi.ResetReply, err error) {
+	if _, ok := machineapi.ResetScope_name[int32(in.GetScope())]; !ok {
+		return nil, fmt.Errorf("unknown reset scope: %d", in.GetScope())
 	}
 
-	// Remove the machine config.
-	if err = os.Remove(constants.ConfigPath); err != nil {
-		return nil, err
+	// there is no system disk to wipe in a container, the node is reset by
+	// recreating the container instead
+	if r.platform != nil && r.platform.Mode() == runtime.Container {
+		return nil, errors.New("reset is not supported in container mode")
+	}
+
+	if !in.GetForce() {
+		if err = etcd.ValidateForReset(); err != nil {
+			return nil, err
+		}
 	}
 
-	return &machineapi.ResetReply{
+	log.Printf("reset via API received")
+	event.Bus().Notify(event.Event{Type: event.Reset, Data: in})
+
+	data = &machineapi.ResetReply{
 		Response: []*machineapi.ResetResponse{},
-	}, err
+	}
+
+	return data, err
+}
+
+// Rollback switches the node to the boot slot it didn't boot from, and reboots
+// it.
+func (r *Registrator) Rollback(ctx context.Context, in *empty.Empty) (reply *machineapi.RollbackReply, err error) {
+	var label string
+
+	if label, err = syslinux.Revert(constants.BootMountPoint, syslinux.BootedLabel(kernel.ProcCmdline())); err != nil {
+		return nil, fmt.Errorf("failed to roll back: %w", err)
+	}
+
+	log.Printf("rolled back to boot slot %s, rebooting", label)
+	event.Bus().Notify(event.Event{Type: event.Reboot})
+
+	reply = &machineapi.RollbackReply{
+		Response: []*machineapi.RollbackResponse{
+			{},
+		},
+	}
+
+	return reply, nil
+}
+
+// eventsObserver subscribes to the machine events on the event bus.
+type eventsObserver struct {
+	ch event.Channel
+}
+
+// Channel implements the event.Observer interface.
+func (o *eventsObserver) Channel() event.Channel {
+	return o.ch
+}
+
+// Types implements the event.Observer interface.
+func (o *eventsObserver) Types() []event.Type {
+	return []event.Type{event.Sequence, event.Phase, event.Service}
+}
+
+// Events implements the machineapi.MachineServer interface and streams the
+// machine events as they happen.
+func (r *Registrator) Events(req *machineapi.EventsRequest, s machineapi.Machine_EventsServer) error {
+	// the channel is buffered so that notifications racing with Unregister
+	// don't block the bus
+	observer := &eventsObserver{ch: make(event.Channel, 100)}
+
+	event.Bus().Register(observer)
+
+	// event.Bus().Notify() blocks until the event is received, so a slow
+	// client should not hold up the machine: events are drained into a
+	// queue, and dropped when the queue is full
+	queue := make(chan *machineapi.Event, 1000)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case e := <-observer.ch:
+				data, ok := e.Data.(*machineapi.Event)
+				if !ok {
+					continue
+				}
+
+				select {
+				case queue <- data:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	defer func() {
+		event.Bus().Unregister(observer)
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-s.Context().Done():
+			return nil
+		case e := <-queue:
+			if err := s.Send(e); err != nil {
+				return err
+			}
+		}
+	}
 }
 
 // ServiceList returns list of the registered services and their status
@@ -439,20 +550,20 @@ func (r *Registrator) Logs(req *machineapi.LogsRequest, l machineapi.Machine_Log
 	case req.Namespace == constants.SystemContainerdNamespace || req.Id == "kubelet":
 		filename := filepath.Join(constants.DefaultLogPath, filepath.Base(req.Id)+".log")
 
-		var file *os.File
-
-		file, err = os.OpenFile(filename, os.O_RDONLY, 0)
-		if err != nil {
+		if _, err = os.Stat(filename); err != nil {
 			return
 		}
-		// nolint: errcheck
-		defer file.Close()
 
-		chunk = filechunker.NewChunker(file)
+		chunk = servicelog.NewChunker(filename, req.Follow, int(req.TailLines))
 	default:
 		var file io.Closer
 
-		if chunk, file, err = k8slogs(l.Context(), req); err != nil {
+		opts := []filechunker.Option{
+			filechunker.Follow(req.Follow),
+			filechunker.Tail(int(req.TailLines)),
+		}
+
+		if chunk, file, err = k8slogs(l.Context(), req, opts...); err != nil {
 			return err
 		}
 		// nolint: errcheck
@@ -468,7 +579,7 @@ func (r *Registrator) Logs(req *machineapi.LogsRequest, l machineapi.Machine_Log
 	return nil
 }
 
-func k8slogs(ctx context.Context, req *machineapi.LogsRequest) (chunker.Chunker, io.Closer, error) {
+func k8slogs(ctx context.Context, req *machineapi.LogsRequest, opts ...filechunker.Option) (chunker.Chunker, io.Closer, error) {
 	inspector, err := getContainerInspector(ctx, req.Namespace, req.Driver)
 	if err != nil {
 		return nil, nil, err
@@ -485,7 +596,7 @@ func k8slogs(ctx context.Context, req *machineapi.LogsRequest) (chunker.Chunker,
 		return nil, nil, fmt.Errorf("container %q not found", req.Id)
 	}
 
-	return container.GetLogChunker()
+	return container.GetLogChunker(opts...)
 }
 
 func getContainerInspector(ctx context.Context, namespace string, driver common.ContainerDriver) (containers.Inspector, error) {
@@ -0,0 +1,209 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package dns
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// cacheSize is the maximum number of cached answers.
+	cacheSize = 4096
+
+	// maxTTL caps the time the answers are cached for.
+	maxTTL = time.Hour
+)
+
+type cacheKey struct {
+	name  string
+	typ   dnsmessage.Type
+	class dnsmessage.Class
+}
+
+type cacheEntry struct {
+	msg     dnsmessage.Message
+	stored  time.Time
+	expires time.Time
+}
+
+// cache caches the answers of the upstreams, until the lowest TTL of the
+// records of the answer expires. Negative answers are cached as per RFC2308.
+type cache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+	size    int
+}
+
+func newCache(size int) *cache {
+	return &cache{
+		entries: map[cacheKey]*cacheEntry{},
+		size:    size,
+	}
+}
+
+func newCacheKey(q dnsmessage.Question) cacheKey {
+	return cacheKey{
+		name:  strings.ToLower(q.Name.String()),
+		typ:   q.Type,
+		class: q.Class,
+	}
+}
+
+// get returns the cached answer of the question, with the TTLs of the records
+// lowered by the time the answer has been cached for.
+func (c *cache) get(q dnsmessage.Question, now time.Time) (*dnsmessage.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := newCacheKey(q)
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !now.Before(entry.expires) {
+		delete(c.entries, key)
+
+		return nil, false
+	}
+
+	elapsed := uint32(now.Sub(entry.stored) / time.Second)
+
+	msg := entry.msg
+	msg.Answers = ageRecords(entry.msg.Answers, elapsed)
+	msg.Authorities = ageRecords(entry.msg.Authorities, elapsed)
+	msg.Additionals = ageRecords(entry.msg.Additionals, elapsed)
+
+	return &msg, true
+}
+
+// put caches the answer, unless it can't be cached.
+func (c *cache) put(msg *dnsmessage.Message, now time.Time) {
+	if len(msg.Questions) != 1 {
+		return
+	}
+
+	ttl, ok := cacheTTL(msg)
+	if !ok || ttl == 0 {
+		return
+	}
+
+	entry := &cacheEntry{
+		msg:     *msg,
+		stored:  now,
+		expires: now.Add(ttl),
+	}
+
+	// the OPT record is specific to the exchange with the upstream
+	entry.msg.Additionals = nil
+
+	for _, r := range msg.Additionals {
+		if r.Header.Type != dnsmessage.TypeOPT {
+			entry.msg.Additionals = append(entry.msg.Additionals, r)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.size {
+		c.evict(now)
+	}
+
+	c.entries[newCacheKey(msg.Questions[0])] = entry
+}
+
+// evict removes the expired answers, or an arbitrary answer if none expired.
+func (c *cache) evict(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+
+	for key := range c.entries {
+		if len(c.entries) < c.size {
+			break
+		}
+
+		delete(c.entries, key)
+	}
+}
+
+// cacheTTL returns the time the answer can be cached for. Successful answers
+// are cached for the lowest TTL of their records, negative answers for the
+// TTL of the SOA record of the zone.
+func cacheTTL(msg *dnsmessage.Message) (time.Duration, bool) {
+	if msg.Truncated {
+		return 0, false
+	}
+
+	switch msg.RCode {
+	case dnsmessage.RCodeSuccess, dnsmessage.RCodeNameError:
+	default:
+		return 0, false
+	}
+
+	var (
+		ttl   uint32
+		found bool
+	)
+
+	lower := func(t uint32) {
+		if !found || t < ttl {
+			ttl = t
+			found = true
+		}
+	}
+
+	if msg.RCode == dnsmessage.RCodeSuccess && len(msg.Answers) > 0 {
+		for _, r := range msg.Answers {
+			lower(r.Header.TTL)
+		}
+	} else {
+		for _, r := range msg.Authorities {
+			if soa, ok := r.Body.(*dnsmessage.SOAResource); ok {
+				lower(r.Header.TTL)
+				lower(soa.MinTTL)
+			}
+		}
+	}
+
+	if !found {
+		return 0, false
+	}
+
+	d := time.Duration(ttl) * time.Second
+	if d > maxTTL {
+		d = maxTTL
+	}
+
+	return d, true
+}
+
+func ageRecords(records []dnsmessage.Resource, elapsed uint32) []dnsmessage.Resource {
+	if records == nil {
+		return nil
+	}
+
+	aged := make([]dnsmessage.Resource, len(records))
+
+	for i, r := range records {
+		if r.Header.TTL > elapsed {
+			r.Header.TTL -= elapsed
+		} else {
+			r.Header.TTL = 0
+		}
+
+		aged[i] = r
+	}
+
+	return aged
+}
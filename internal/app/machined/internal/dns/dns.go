@@ -0,0 +1,32 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package dns implements the local caching DNS forwarder.
+package dns
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"github.com/talos-systems/talos/internal/pkg/runtime"
+)
+
+// Service wraps the DNS forwarder.
+type Service struct{}
+
+// NewService creates new Service
+func NewService() *Service {
+	return &Service{}
+}
+
+// Main is an entrypoint to the DNS cache service.
+func (s *Service) Main(ctx context.Context, config runtime.Configurator, logWriter io.Writer) error {
+	f, err := NewForwarder(config.Machine().Network().DNSCache(), log.New(logWriter, "dns ", log.Flags()))
+	if err != nil {
+		return err
+	}
+
+	return f.ListenAndServe(ctx)
+}
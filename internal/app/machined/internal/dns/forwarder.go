@@ -0,0 +1,564 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package dns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+const (
+	// healthCheckInterval is the interval the upstreams are health-checked
+	// at, and the upstreams of the network are reloaded at.
+	healthCheckInterval = 10 * time.Second
+
+	// tcpIdleTimeout is the time a client connection is kept open for
+	// without queries.
+	tcpIdleTimeout = 10 * time.Second
+
+	// udpSize is the size of the UDP answers the forwarder advertises with
+	// EDNS(0).
+	udpSize = 1232
+)
+
+// forward sends the queries of a domain, and of its subdomains, to the
+// upstreams of the forward.
+type forward struct {
+	domain    string
+	upstreams []*upstream
+}
+
+// Forwarder is a caching DNS forwarder. The queries are sent to the healthy
+// upstreams first, in order, the upstreams which fail to answer are
+// health-checked until they answer again.
+type Forwarder struct {
+	addr   string
+	logger *log.Logger
+	cache  *cache
+
+	forwards []forward
+
+	// resolvConfPath is the resolv.conf the upstreams are loaded from, unless
+	// they are configured.
+	resolvConfPath string
+
+	mu         sync.Mutex
+	upstreams  []*upstream
+	resolvConf []byte
+}
+
+// NewForwarder creates the forwarder of the DNS cache configuration. The
+// upstreams default to the resolvers of the network.
+func NewForwarder(config *machine.DNSCache, logger *log.Logger) (*Forwarder, error) {
+	f := &Forwarder{
+		addr:   net.JoinHostPort(constants.DNSCacheAddress, "53"),
+		logger: logger,
+		cache:  newCache(cacheSize),
+	}
+
+	var err error
+
+	if f.upstreams, err = newUpstreams(config.Upstreams); err != nil {
+		return nil, err
+	}
+
+	if len(f.upstreams) == 0 {
+		f.resolvConfPath = constants.DNSUpstreamResolvConfPath
+	}
+
+	for _, fwd := range config.Forwards {
+		upstreams, err := newUpstreams(fwd.Upstreams)
+		if err != nil {
+			return nil, err
+		}
+
+		f.forwards = append(f.forwards, forward{
+			domain:    canonicalName(fwd.Domain),
+			upstreams: upstreams,
+		})
+	}
+
+	// the most specific domain is matched first
+	sort.SliceStable(f.forwards, func(i, j int) bool {
+		return len(f.forwards[i].domain) > len(f.forwards[j].domain)
+	})
+
+	return f, nil
+}
+
+// ListenAndServe answers the queries on the address of the DNS cache, over
+// UDP and TCP, until the context is canceled.
+func (f *Forwarder) ListenAndServe(ctx context.Context) error {
+	pc, err := net.ListenPacket("udp", f.addr)
+	if err != nil {
+		return err
+	}
+
+	l, err := net.Listen("tcp", f.addr)
+	if err != nil {
+		// nolint: errcheck
+		pc.Close()
+
+		return err
+	}
+
+	f.reload()
+
+	go f.healthCheck(ctx)
+
+	go func() {
+		<-ctx.Done()
+
+		// nolint: errcheck
+		pc.Close()
+		// nolint: errcheck
+		l.Close()
+	}()
+
+	go f.serveTCP(ctx, l)
+
+	f.serveUDP(ctx, pc)
+
+	return nil
+}
+
+func (f *Forwarder) serveUDP(ctx context.Context, pc net.PacketConn) {
+	for {
+		buf := make([]byte, maxMessageSize)
+
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() == nil {
+				f.logger.Printf("failed to read query: %v", err)
+			}
+
+			return
+		}
+
+		go func() {
+			if answer := f.handle(ctx, buf[:n], true); answer != nil {
+				// nolint: errcheck
+				pc.WriteTo(answer, addr)
+			}
+		}()
+	}
+}
+
+func (f *Forwarder) serveTCP(ctx context.Context, l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				f.logger.Printf("failed to accept connection: %v", err)
+			}
+
+			return
+		}
+
+		go func() {
+			// nolint: errcheck
+			defer conn.Close()
+
+			for {
+				if err := conn.SetDeadline(time.Now().Add(tcpIdleTimeout)); err != nil {
+					return
+				}
+
+				query, err := readMessage(conn)
+				if err != nil {
+					return
+				}
+
+				answer := f.handle(ctx, query, false)
+				if answer == nil {
+					return
+				}
+
+				if err = writeMessage(conn, answer); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// handle answers the query, from the cache, or with the answer of an
+// upstream. Queries which can't be parsed are dropped.
+func (f *Forwarder) handle(ctx context.Context, query []byte, udp bool) []byte {
+	var p dnsmessage.Parser
+
+	h, err := p.Start(query)
+	if err != nil {
+		return nil
+	}
+
+	q, err := p.Question()
+	if err != nil || h.Response {
+		return reply(h, nil, dnsmessage.RCodeFormatError)
+	}
+
+	maxSize, edns := ednsSize(&p)
+
+	var answer []byte
+
+	if msg, ok := f.cache.get(q, time.Now()); ok {
+		if answer, err = cachedAnswer(msg, h, q, edns); err != nil {
+			return reply(h, &q, dnsmessage.RCodeServerFailure)
+		}
+	} else {
+		if answer, err = f.forward(ctx, q, query); err != nil {
+			f.logger.Printf("failed to forward the query of %s %s: %v", q.Type, q.Name, err)
+
+			return reply(h, &q, dnsmessage.RCodeServerFailure)
+		}
+
+		var msg dnsmessage.Message
+
+		// the answers which can't be parsed are passed through
+		if msg.Unpack(answer) == nil {
+			f.cache.put(&msg, time.Now())
+		}
+	}
+
+	if udp && len(answer) > maxSize {
+		// the client retries over TCP
+		return reply(dnsmessage.Header{ID: h.ID, Truncated: true, RecursionDesired: h.RecursionDesired}, &q, dnsmessage.RCodeSuccess)
+	}
+
+	return answer
+}
+
+// forward sends the query to the upstreams of the name, the healthy upstreams
+// first, until one of them answers.
+func (f *Forwarder) forward(ctx context.Context, q dnsmessage.Question, query []byte) ([]byte, error) {
+	upstreams := f.upstreamsOf(q.Name.String())
+	if len(upstreams) == 0 {
+		return nil, errors.New("no upstreams")
+	}
+
+	var err error
+
+	for _, u := range ordered(upstreams) {
+		var answer []byte
+
+		if answer, err = u.exchange(ctx, query); err != nil {
+			f.setHealthy(u, false, err)
+
+			continue
+		}
+
+		f.setHealthy(u, true, nil)
+
+		return answer, nil
+	}
+
+	return nil, err
+}
+
+// upstreamsOf returns the upstreams of the most specific forward of the name,
+// or the upstreams of the forwarder.
+func (f *Forwarder) upstreamsOf(name string) []*upstream {
+	name = canonicalName(name)
+
+	for _, fwd := range f.forwards {
+		if name == fwd.domain || strings.HasSuffix(name, "."+fwd.domain) {
+			return fwd.upstreams
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.upstreams
+}
+
+// healthCheck probes all the upstreams at the health check interval, and
+// reloads the upstreams of the network.
+func (f *Forwarder) healthCheck(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		f.reload()
+
+		f.mu.Lock()
+		upstreams := append([]*upstream{}, f.upstreams...)
+		f.mu.Unlock()
+
+		for _, fwd := range f.forwards {
+			upstreams = append(upstreams, fwd.upstreams...)
+		}
+
+		var wg sync.WaitGroup
+
+		wg.Add(len(upstreams))
+
+		for _, u := range upstreams {
+			go func(u *upstream) {
+				defer wg.Done()
+
+				err := f.probe(ctx, u)
+				f.setHealthy(u, err == nil, err)
+			}(u)
+		}
+
+		wg.Wait()
+	}
+}
+
+// probe queries the upstream for the name servers of the root zone.
+func (f *Forwarder) probe(ctx context.Context, u *upstream) error {
+	// nolint: gosec
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: uint16(rand.Intn(1 << 16)), RecursionDesired: true})
+
+	if err := b.StartQuestions(); err != nil {
+		return err
+	}
+
+	if err := b.Question(dnsmessage.Question{Name: dnsmessage.MustNewName("."), Type: dnsmessage.TypeNS, Class: dnsmessage.ClassINET}); err != nil {
+		return err
+	}
+
+	query, err := b.Finish()
+	if err != nil {
+		return err
+	}
+
+	answer, err := u.exchange(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	var p dnsmessage.Parser
+
+	h, err := p.Start(answer)
+	if err != nil {
+		return err
+	}
+
+	if h.RCode == dnsmessage.RCodeServerFailure || h.RCode == dnsmessage.RCodeRefused {
+		return fmt.Errorf("the upstream answered with %s", h.RCode)
+	}
+
+	return nil
+}
+
+func (f *Forwarder) setHealthy(u *upstream, healthy bool, err error) {
+	if !u.setHealthy(healthy) {
+		return
+	}
+
+	if healthy {
+		f.logger.Printf("upstream %s is healthy", u)
+	} else {
+		f.logger.Printf("upstream %s is unhealthy: %v", u, err)
+	}
+}
+
+// reload loads the upstreams from the resolv.conf of the network if it
+// changed, the health of the upstreams which are kept is preserved.
+func (f *Forwarder) reload() {
+	if f.resolvConfPath == "" {
+		return
+	}
+
+	b, err := ioutil.ReadFile(f.resolvConfPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			f.logger.Printf("failed to read %s: %v", f.resolvConfPath, err)
+		}
+
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if bytes.Equal(b, f.resolvConf) {
+		return
+	}
+
+	current := map[string]*upstream{}
+
+	for _, u := range f.upstreams {
+		current[u.addr] = u
+	}
+
+	upstreams := []*upstream{}
+
+	for _, resolver := range parseResolvConf(b) {
+		u, err := newUpstream(machine.DNSUpstream{Address: resolver})
+		if err != nil {
+			continue
+		}
+
+		if existing, ok := current[u.addr]; ok {
+			u = existing
+		}
+
+		upstreams = append(upstreams, u)
+	}
+
+	f.upstreams = upstreams
+	f.resolvConf = b
+
+	f.logger.Printf("forwarding to the upstreams %v", upstreams)
+}
+
+func newUpstreams(config []machine.DNSUpstream) ([]*upstream, error) {
+	upstreams := make([]*upstream, 0, len(config))
+
+	for _, c := range config {
+		u, err := newUpstream(c)
+		if err != nil {
+			return nil, err
+		}
+
+		upstreams = append(upstreams, u)
+	}
+
+	return upstreams, nil
+}
+
+// ordered returns the healthy upstreams followed by the unhealthy ones, the
+// unhealthy upstreams are only tried if the healthy ones fail.
+func ordered(upstreams []*upstream) []*upstream {
+	healthy := make([]*upstream, 0, len(upstreams))
+	unhealthy := []*upstream{}
+
+	for _, u := range upstreams {
+		if u.isHealthy() {
+			healthy = append(healthy, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// parseResolvConf returns the nameservers of the resolv.conf.
+func parseResolvConf(b []byte) []string {
+	resolvers := []string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			resolvers = append(resolvers, fields[1])
+		}
+	}
+
+	return resolvers
+}
+
+// ednsSize returns the largest UDP answer the client accepts, and whether
+// the client supports EDNS(0).
+func ednsSize(p *dnsmessage.Parser) (int, bool) {
+	if p.SkipAllQuestions() != nil || p.SkipAllAnswers() != nil || p.SkipAllAuthorities() != nil {
+		return 512, false
+	}
+
+	for {
+		h, err := p.AdditionalHeader()
+		if err != nil {
+			return 512, false
+		}
+
+		if h.Type == dnsmessage.TypeOPT {
+			size := int(h.Class)
+			if size < 512 {
+				size = 512
+			}
+
+			return size, true
+		}
+
+		if p.SkipAdditional() != nil {
+			return 512, false
+		}
+	}
+}
+
+// cachedAnswer packs the cached answer for the query.
+func cachedAnswer(msg *dnsmessage.Message, h dnsmessage.Header, q dnsmessage.Question, edns bool) ([]byte, error) {
+	msg.Header.ID = h.ID
+	msg.Header.RecursionDesired = h.RecursionDesired
+	msg.Questions = []dnsmessage.Question{q}
+
+	if edns {
+		var opt dnsmessage.ResourceHeader
+
+		if err := opt.SetEDNS0(udpSize, dnsmessage.RCodeSuccess, false); err != nil {
+			return nil, err
+		}
+
+		msg.Additionals = append(msg.Additionals, dnsmessage.Resource{Header: opt, Body: &dnsmessage.OPTResource{}})
+	}
+
+	return msg.Pack()
+}
+
+// reply builds an answer without records.
+func reply(h dnsmessage.Header, q *dnsmessage.Question, rcode dnsmessage.RCode) []byte {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 h.ID,
+			Response:           true,
+			OpCode:             h.OpCode,
+			Truncated:          h.Truncated,
+			RecursionDesired:   h.RecursionDesired,
+			RecursionAvailable: true,
+			RCode:              rcode,
+		},
+	}
+
+	if q != nil {
+		msg.Questions = []dnsmessage.Question{*q}
+	}
+
+	b, err := msg.Pack()
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
+
+// canonicalName returns the lower case fully qualified name.
+func canonicalName(name string) string {
+	name = strings.ToLower(name)
+
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+
+	return name
+}
@@ -0,0 +1,280 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package dns
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+type ForwarderSuite struct {
+	suite.Suite
+}
+
+func TestForwarderSuite(t *testing.T) {
+	suite.Run(t, new(ForwarderSuite))
+}
+
+func (suite *ForwarderSuite) TestForwardAndCache() {
+	server := suite.server(1)
+
+	f := suite.forwarder(&machine.DNSCache{Upstreams: []machine.DNSUpstream{{Address: server.addr}}})
+
+	answer := suite.answer(f.handle(context.Background(), suite.query(1, "example.com.", false), true))
+	suite.Assert().Equal(uint16(1), answer.Header.ID)
+	suite.Assert().Equal(dnsmessage.RCodeSuccess, answer.Header.RCode)
+	suite.Require().Len(answer.Answers, 1)
+	suite.Assert().Equal(uint32(300), answer.Answers[0].Header.TTL)
+
+	// the second query is answered from the cache
+	answer = suite.answer(f.handle(context.Background(), suite.query(2, "EXAMPLE.com.", true), true))
+	suite.Assert().Equal(uint16(2), answer.Header.ID)
+	suite.Assert().Equal("EXAMPLE.com.", answer.Questions[0].Name.String())
+	suite.Require().Len(answer.Answers, 1)
+	suite.Assert().Equal(&dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}}, answer.Answers[0].Body)
+	suite.Require().Len(answer.Additionals, 1)
+	suite.Assert().Equal(dnsmessage.TypeOPT, answer.Additionals[0].Header.Type)
+
+	suite.Assert().Equal(int32(1), atomic.LoadInt32(&server.queries))
+}
+
+func (suite *ForwarderSuite) TestFailover() {
+	down := suite.closedAddr()
+	server := suite.server(1)
+
+	f := suite.forwarder(&machine.DNSCache{Upstreams: []machine.DNSUpstream{{Address: down}, {Address: server.addr}}})
+
+	answer := suite.answer(f.handle(context.Background(), suite.query(1, "example.com.", false), true))
+	suite.Assert().Equal(dnsmessage.RCodeSuccess, answer.Header.RCode)
+
+	suite.Assert().False(f.upstreams[0].isHealthy())
+	suite.Assert().True(f.upstreams[1].isHealthy())
+
+	// the healthy upstream is tried first
+	suite.Assert().Equal([]*upstream{f.upstreams[1], f.upstreams[0]}, ordered(f.upstreams))
+
+	suite.Assert().Error(f.probe(context.Background(), f.upstreams[0]))
+	suite.Assert().NoError(f.probe(context.Background(), f.upstreams[1]))
+}
+
+func (suite *ForwarderSuite) TestServerFailure() {
+	f := suite.forwarder(&machine.DNSCache{Upstreams: []machine.DNSUpstream{{Address: suite.closedAddr()}}})
+
+	answer := suite.answer(f.handle(context.Background(), suite.query(1, "example.com.", false), true))
+	suite.Assert().Equal(uint16(1), answer.Header.ID)
+	suite.Assert().Equal(dnsmessage.RCodeServerFailure, answer.Header.RCode)
+	suite.Require().Len(answer.Questions, 1)
+
+	suite.Assert().Nil(f.handle(context.Background(), []byte{0}, true))
+}
+
+func (suite *ForwarderSuite) TestForwards() {
+	f := suite.forwarder(&machine.DNSCache{
+		Upstreams: []machine.DNSUpstream{{Address: "10.0.0.1"}},
+		Forwards: []machine.DNSForward{
+			{Domain: "example.com", Upstreams: []machine.DNSUpstream{{Address: "10.0.0.2"}}},
+			{Domain: "Corp.Example.com.", Upstreams: []machine.DNSUpstream{{Address: "10.0.0.3", TLSServerName: "dns.example.com"}}},
+		},
+	})
+
+	suite.Assert().Equal("10.0.0.3:853", f.upstreamsOf("host.corp.example.com.")[0].addr)
+	suite.Assert().Equal("10.0.0.3:853", f.upstreamsOf("corp.example.com.")[0].addr)
+	suite.Assert().Equal("10.0.0.2:53", f.upstreamsOf("www.example.com.")[0].addr)
+	suite.Assert().Equal("10.0.0.1:53", f.upstreamsOf("notexample.com.")[0].addr)
+}
+
+func (suite *ForwarderSuite) TestReload() {
+	tmp, err := ioutil.TempFile("", "resolv.conf")
+	suite.Require().NoError(err)
+
+	_, err = tmp.WriteString("nameserver 10.0.0.1\nnameserver 2001:db8::1\n")
+	suite.Require().NoError(err)
+	suite.Require().NoError(tmp.Close())
+
+	f := suite.forwarder(&machine.DNSCache{})
+	f.resolvConfPath = tmp.Name()
+
+	f.reload()
+	suite.Require().Len(f.upstreams, 2)
+	suite.Assert().Equal("10.0.0.1:53", f.upstreams[0].addr)
+	suite.Assert().Equal("[2001:db8::1]:53", f.upstreams[1].addr)
+
+	f.upstreams[0].setHealthy(false)
+
+	suite.Require().NoError(ioutil.WriteFile(tmp.Name(), []byte("nameserver 10.0.0.1\n"), 0644))
+
+	// the health of the kept upstreams is preserved
+	f.reload()
+	suite.Require().Len(f.upstreams, 1)
+	suite.Assert().False(f.upstreams[0].isHealthy())
+}
+
+func (suite *ForwarderSuite) TestCacheTTL() {
+	c := newCache(2)
+	now := time.Now()
+
+	name := dnsmessage.MustNewName("example.com.")
+	q := dnsmessage.Question{Name: name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}
+
+	// negative answers are cached for the TTL of the SOA record
+	c.put(&dnsmessage.Message{
+		Header:    dnsmessage.Header{Response: true, RCode: dnsmessage.RCodeNameError},
+		Questions: []dnsmessage.Question{q},
+		Authorities: []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: name, Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET, TTL: 3600},
+			Body:   &dnsmessage.SOAResource{NS: name, MBox: name, MinTTL: 60},
+		}},
+	}, now)
+
+	msg, ok := c.get(q, now.Add(10*time.Second))
+	suite.Require().True(ok)
+	suite.Assert().Equal(uint32(3590), msg.Authorities[0].Header.TTL)
+
+	_, ok = c.get(q, now.Add(time.Minute))
+	suite.Assert().False(ok)
+
+	// failures are not cached
+	c.put(&dnsmessage.Message{
+		Header:    dnsmessage.Header{Response: true, RCode: dnsmessage.RCodeServerFailure},
+		Questions: []dnsmessage.Question{q},
+	}, now)
+
+	_, ok = c.get(q, now)
+	suite.Assert().False(ok)
+}
+
+func (suite *ForwarderSuite) TestTruncate() {
+	server := suite.server(64)
+
+	f := suite.forwarder(&machine.DNSCache{Upstreams: []machine.DNSUpstream{{Address: server.addr}}})
+
+	answer := suite.answer(f.handle(context.Background(), suite.query(1, "example.com.", false), true))
+	suite.Assert().True(answer.Header.Truncated)
+	suite.Assert().Empty(answer.Answers)
+
+	// the answer fits the EDNS(0) size of the client
+	answer = suite.answer(f.handle(context.Background(), suite.query(2, "example.com.", true), true))
+	suite.Assert().False(answer.Header.Truncated)
+	suite.Assert().Len(answer.Answers, 64)
+}
+
+type server struct {
+	addr    string
+	answers int
+	queries int32
+}
+
+// server starts an upstream which answers the A queries with the given number
+// of records from 192.0.2.0/24.
+func (suite *ForwarderSuite) server(answers int) *server {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	s := &server{addr: pc.LocalAddr().String(), answers: answers}
+
+	go func() {
+		// nolint: errcheck
+		defer pc.Close()
+
+		buf := make([]byte, maxMessageSize)
+
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			atomic.AddInt32(&s.queries, 1)
+
+			var query dnsmessage.Message
+
+			if err = query.Unpack(buf[:n]); err != nil {
+				continue
+			}
+
+			answer := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.Header.ID, Response: true, RecursionAvailable: true},
+				Questions: query.Questions,
+			}
+
+			if query.Questions[0].Type == dnsmessage.TypeA {
+				for i := 0; i < s.answers; i++ {
+					answer.Answers = append(answer.Answers, dnsmessage.Resource{
+						Header: dnsmessage.ResourceHeader{Name: query.Questions[0].Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 300},
+						Body:   &dnsmessage.AResource{A: [4]byte{192, 0, 2, byte(i + 1)}},
+					})
+				}
+			}
+
+			b, err := answer.Pack()
+			if err != nil {
+				continue
+			}
+
+			// nolint: errcheck
+			pc.WriteTo(b, addr)
+		}
+	}()
+
+	return s
+}
+
+// closedAddr returns the address of an upstream which is down.
+func (suite *ForwarderSuite) closedAddr() string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	addr := pc.LocalAddr().String()
+	suite.Require().NoError(pc.Close())
+
+	return addr
+}
+
+func (suite *ForwarderSuite) forwarder(config *machine.DNSCache) *Forwarder {
+	f, err := NewForwarder(config, log.New(ioutil.Discard, "", 0))
+	suite.Require().NoError(err)
+
+	return f
+}
+
+func (suite *ForwarderSuite) query(id uint16, name string, edns bool) []byte {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{
+			{Name: dnsmessage.MustNewName(name), Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET},
+		},
+	}
+
+	if edns {
+		var opt dnsmessage.ResourceHeader
+		suite.Require().NoError(opt.SetEDNS0(4096, dnsmessage.RCodeSuccess, false))
+
+		msg.Additionals = []dnsmessage.Resource{{Header: opt, Body: &dnsmessage.OPTResource{}}}
+	}
+
+	b, err := msg.Pack()
+	suite.Require().NoError(err)
+
+	return b
+}
+
+func (suite *ForwarderSuite) answer(b []byte) *dnsmessage.Message {
+	suite.Require().NotNil(b)
+
+	var msg dnsmessage.Message
+	suite.Require().NoError(msg.Unpack(b))
+
+	return &msg
+}
@@ -0,0 +1,234 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package dns
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+const (
+	// exchangeTimeout is the time an upstream has to answer a query.
+	exchangeTimeout = 2 * time.Second
+
+	// maxMessageSize is the largest DNS message.
+	maxMessageSize = 65535
+)
+
+// upstream is a DNS server the queries are forwarded to.
+type upstream struct {
+	addr string
+	tls  *tls.Config
+
+	mu      sync.Mutex
+	healthy bool
+}
+
+// newUpstream creates the upstream, the port of the address defaults to 53,
+// or 853 for DNS-over-TLS.
+func newUpstream(u machine.DNSUpstream) (*upstream, error) {
+	port := "53"
+	if u.TLSServerName != "" {
+		port = "853"
+	}
+
+	addr := u.Address
+
+	if ip := net.ParseIP(addr); ip != nil {
+		addr = net.JoinHostPort(ip.String(), port)
+	} else if host, _, err := net.SplitHostPort(addr); err != nil || net.ParseIP(host) == nil {
+		return nil, fmt.Errorf("invalid upstream address %q", u.Address)
+	}
+
+	up := &upstream{
+		addr:    addr,
+		healthy: true,
+	}
+
+	if u.TLSServerName != "" {
+		up.tls = &tls.Config{ServerName: u.TLSServerName}
+	}
+
+	return up, nil
+}
+
+func (u *upstream) String() string {
+	if u.tls != nil {
+		return fmt.Sprintf("tls://%s#%s", u.addr, u.tls.ServerName)
+	}
+
+	return u.addr
+}
+
+func (u *upstream) isHealthy() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.healthy
+}
+
+// setHealthy records the health of the upstream, and returns whether it
+// changed.
+func (u *upstream) setHealthy(healthy bool) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	changed := u.healthy != healthy
+	u.healthy = healthy
+
+	return changed
+}
+
+// exchange sends the query to the upstream, and returns the answer. Queries
+// are sent over UDP, and again over TCP if the answer is truncated.
+func (u *upstream) exchange(ctx context.Context, query []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, exchangeTimeout)
+	defer cancel()
+
+	if u.tls != nil {
+		return u.exchangeStream(ctx, query)
+	}
+
+	answer, err := u.exchangeUDP(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var p dnsmessage.Parser
+
+	h, err := p.Start(answer)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Truncated {
+		return u.exchangeStream(ctx, query)
+	}
+
+	return answer, nil
+}
+
+func (u *upstream) exchangeUDP(ctx context.Context, query []byte) ([]byte, error) {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "udp", u.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = conn.Write(query); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, maxMessageSize)
+
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		// ignore the answers to other queries
+		if n >= 2 && binary.BigEndian.Uint16(buf) == binary.BigEndian.Uint16(query) {
+			return buf[:n], nil
+		}
+	}
+}
+
+// exchangeStream sends the query over TCP, or over TLS, with the length of
+// the message as a prefix.
+func (u *upstream) exchangeStream(ctx context.Context, query []byte) ([]byte, error) {
+	var (
+		d    net.Dialer
+		conn net.Conn
+		err  error
+	)
+
+	conn, err = d.DialContext(ctx, "tcp", u.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if u.tls != nil {
+		tlsConn := tls.Client(conn, u.tls)
+
+		if err = tlsConn.Handshake(); err != nil {
+			return nil, err
+		}
+
+		conn = tlsConn
+	}
+
+	if err = writeMessage(conn, query); err != nil {
+		return nil, err
+	}
+
+	return readMessage(conn)
+}
+
+// readMessage reads a length prefixed DNS message from the stream.
+func readMessage(r io.Reader) ([]byte, error) {
+	var length uint16
+
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+
+	if length == 0 {
+		return nil, errors.New("empty message")
+	}
+
+	msg := make([]byte, length)
+
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// writeMessage writes the DNS message to the stream, prefixed with its length.
+func writeMessage(w io.Writer, msg []byte) error {
+	if len(msg) > maxMessageSize {
+		return errors.New("message is too large")
+	}
+
+	b := make([]byte, 2+len(msg))
+	binary.BigEndian.PutUint16(b, uint16(len(msg)))
+	copy(b[2:], msg)
+
+	_, err := w.Write(b)
+
+	return err
+}
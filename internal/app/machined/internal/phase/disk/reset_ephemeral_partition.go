@@ -0,0 +1,45 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package disk
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/talos/internal/app/machined/internal/phase"
+	"github.com/talos-systems/talos/internal/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/xfs"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+// ResetEphemeralPartition represents the task for wiping the ephemeral
+// partition.
+type ResetEphemeralPartition struct {
+	partname string
+}
+
+// NewResetEphemeralPartitionTask initializes and returns a
+// ResetEphemeralPartition task.
+func NewResetEphemeralPartitionTask(partname string) phase.Task {
+	return &ResetEphemeralPartition{
+		partname: partname,
+	}
+}
+
+// TaskFunc returns the runtime function.
+func (task *ResetEphemeralPartition) TaskFunc(mode runtime.Mode) phase.TaskFunc {
+	return func(r runtime.Runtime) error {
+		return task.standard()
+	}
+}
+
+// standard recreates the file system, the partition itself is kept so that
+// the node still boots from the installed system disk.
+func (task *ResetEphemeralPartition) standard() (err error) {
+	if err = xfs.MakeFS(task.partname, xfs.WithLabel(constants.EphemeralPartitionLabel), xfs.WithForce(true)); err != nil {
+		return fmt.Errorf("failed to wipe ephemeral partition: %w", err)
+	}
+
+	return nil
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/internal/pkg/event"
 	"github.com/talos-systems/talos/internal/pkg/kmsg"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
 	"github.com/talos-systems/talos/internal/pkg/runtime/platform"
@@ -98,6 +100,7 @@ func (r *Runner) runPhase(phase *Phase) error {
 	start := time.Now()
 
 	log.Printf("[phase]: %s", phase.description)
+	event.NotifyPhase(phase.description, machineapi.EventAction_START, nil)
 
 	for _, task := range phase.tasks {
 		go r.runTask(task, errCh)
@@ -115,6 +118,7 @@ func (r *Runner) runPhase(phase *Phase) error {
 	}
 
 	log.Printf("[phase]: %s done, %s", phase.description, time.Since(start))
+	event.NotifyPhase(phase.description, machineapi.EventAction_STOP, result.ErrorOrNil())
 
 	return result.ErrorOrNil()
 }
@@ -0,0 +1,86 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package rootfs
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/talos-systems/talos/internal/app/machined/internal/phase"
+	"github.com/talos-systems/talos/internal/pkg/installer/bootloader/syslinux"
+	"github.com/talos-systems/talos/internal/pkg/kernel"
+	"github.com/talos-systems/talos/internal/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+// CheckBootSlot represents the CheckBootSlot task.
+type CheckBootSlot struct{}
+
+// NewCheckBootSlotTask initializes and returns a CheckBootSlot task.
+func NewCheckBootSlotTask() phase.Task {
+	return &CheckBootSlot{}
+}
+
+// TaskFunc returns the runtime function.
+func (task *CheckBootSlot) TaskFunc(mode runtime.Mode) phase.TaskFunc {
+	switch mode {
+	case runtime.Container:
+		return nil
+	default:
+		return task.standard
+	}
+}
+
+// standard counts the boots of a newly installed boot slot, and falls back to
+// the previous slot once it failed to boot too many times.
+func (task *CheckBootSlot) standard(r runtime.Runtime) (err error) {
+	label := syslinux.BootedLabel(kernel.ProcCmdline())
+	if label == "" {
+		return nil
+	}
+
+	fallback, err := syslinux.CheckBoot(constants.BootMountPoint, label)
+	if err != nil {
+		log.Printf("WARNING: failed to check boot slot %s: %v", label, err)
+
+		return nil
+	}
+
+	if fallback != "" {
+		// failing the boot reboots the node into the fallback slot
+		return fmt.Errorf("boot slot %s failed to boot %d times, reverted to boot slot %s", label, syslinux.MaxBootAttempts, fallback)
+	}
+
+	return nil
+}
+
+// MarkBootHealthy represents the MarkBootHealthy task.
+type MarkBootHealthy struct{}
+
+// NewMarkBootHealthyTask initializes and returns a MarkBootHealthy task.
+func NewMarkBootHealthyTask() phase.Task {
+	return &MarkBootHealthy{}
+}
+
+// TaskFunc returns the runtime function.
+func (task *MarkBootHealthy) TaskFunc(mode runtime.Mode) phase.TaskFunc {
+	switch mode {
+	case runtime.Container:
+		return nil
+	default:
+		return task.standard
+	}
+}
+
+// standard keeps the booted slot as the default, if it is the pending boot
+// slot.
+func (task *MarkBootHealthy) standard(r runtime.Runtime) (err error) {
+	label := syslinux.BootedLabel(kernel.ProcCmdline())
+	if label == "" {
+		return nil
+	}
+
+	return syslinux.MarkHealthy(constants.BootMountPoint, label)
+}
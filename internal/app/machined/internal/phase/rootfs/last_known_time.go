@@ -0,0 +1,70 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package rootfs
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/machined/internal/phase"
+	"github.com/talos-systems/talos/internal/app/ntpd/pkg/ntp"
+	"github.com/talos-systems/talos/internal/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+// LastKnownTime represents the LastKnownTime task.
+type LastKnownTime struct {
+	path    string
+	setTime func(time.Time) error
+}
+
+// NewLastKnownTimeTask initializes and returns a LastKnownTime task.
+func NewLastKnownTimeTask() phase.Task {
+	return &LastKnownTime{
+		path:    constants.LastKnownTimePath,
+		setTime: settimeofday,
+	}
+}
+
+// TaskFunc returns the runtime function.
+func (task *LastKnownTime) TaskFunc(mode runtime.Mode) phase.TaskFunc {
+	switch mode {
+	case runtime.Container:
+		return nil
+	default:
+		return task.runtime
+	}
+}
+
+// runtime moves the clock forward to the last time ntpd synchronized, if the
+// hardware clock is behind it (e.g. a machine without a battery backed RTC),
+// until ntpd synchronizes again.
+func (task *LastKnownTime) runtime(r runtime.Runtime) (err error) {
+	last, err := ntp.LastKnownTime(task.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !time.Now().Before(last) {
+		return nil
+	}
+
+	log.Printf("the clock is behind the last known time, setting it to %s", last)
+
+	return task.setTime(last)
+}
+
+func settimeofday(t time.Time) error {
+	tv := unix.NsecToTimeval(t.UnixNano())
+
+	return unix.Settimeofday(&tv)
+}
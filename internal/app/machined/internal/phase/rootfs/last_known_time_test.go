@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package rootfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/app/ntpd/pkg/ntp"
+	"github.com/talos-systems/talos/internal/pkg/runtime"
+)
+
+type LastKnownTimeSuite struct {
+	suite.Suite
+
+	tmpDir string
+	task   *LastKnownTime
+	set    []time.Time
+}
+
+func TestLastKnownTimeSuite(t *testing.T) {
+	suite.Run(t, new(LastKnownTimeSuite))
+}
+
+func (suite *LastKnownTimeSuite) SetupTest() {
+	var err error
+
+	suite.tmpDir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+
+	suite.set = nil
+	suite.task = &LastKnownTime{
+		path: filepath.Join(suite.tmpDir, "time", "last-known"),
+		setTime: func(t time.Time) error {
+			suite.set = append(suite.set, t)
+			return nil
+		},
+	}
+}
+
+func (suite *LastKnownTimeSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.tmpDir))
+}
+
+func (suite *LastKnownTimeSuite) TestMissing() {
+	suite.Require().NoError(suite.task.TaskFunc(runtime.Metal)(nil))
+	suite.Assert().Empty(suite.set)
+}
+
+func (suite *LastKnownTimeSuite) TestBehind() {
+	last := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	suite.Require().NoError(ntp.SaveLastKnownTime(suite.task.path, last))
+
+	suite.Require().NoError(suite.task.TaskFunc(runtime.Metal)(nil))
+	suite.Require().Len(suite.set, 1)
+	suite.Assert().True(last.Equal(suite.set[0]))
+}
+
+func (suite *LastKnownTimeSuite) TestAhead() {
+	suite.Require().NoError(ntp.SaveLastKnownTime(suite.task.path, time.Now().Add(-24*time.Hour)))
+
+	suite.Require().NoError(suite.task.TaskFunc(runtime.Metal)(nil))
+	suite.Assert().Empty(suite.set)
+}
+
+func (suite *LastKnownTimeSuite) TestContainer() {
+	suite.Assert().Nil(suite.task.TaskFunc(runtime.Container))
+}
@@ -5,13 +5,24 @@
 package services
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"github.com/talos-systems/talos/internal/app/machined/internal/phase"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/log"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/services"
+	"github.com/talos-systems/talos/internal/pkg/logship"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
 	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/constants"
 )
 
+// shipper forwards the service logs and the kernel messages, it is closed
+// once the services are stopped.
+var shipper *logship.Shipper
+
 // StartServices represents the StartServices task.
 type StartServices struct{}
 
@@ -26,6 +37,30 @@ func (task *StartServices) TaskFunc(mode runtime.Mode) phase.TaskFunc {
 }
 
 func (task *StartServices) standard(r runtime.Runtime) (err error) {
+	log.SetRotation(r.Config().Machine().Logging().MaxSize(), r.Config().Machine().Logging().MaxFiles())
+
+	if destinations := r.Config().Machine().Logging().Destinations(); len(destinations) > 0 {
+		if shipper, err = logship.New(destinations); err != nil {
+			return err
+		}
+
+		log.SetSink(shipper)
+
+		shipper.ShipKernel()
+	}
+
+	if r.Platform().Mode() == runtime.Container {
+		// ntpd isn't run in a container, the host keeps the time, so the
+		// services waiting for the time to be synchronized can start right away
+		if err = os.MkdirAll(filepath.Dir(constants.TimeSyncedPath), 0750); err != nil {
+			return err
+		}
+
+		if err = ioutil.WriteFile(constants.TimeSyncedPath, nil, 0644); err != nil {
+			return err
+		}
+	}
+
 	task.loadSystemServices(r)
 	task.loadKubernetesServices(r)
 
@@ -45,6 +80,12 @@ func (task *StartServices) loadSystemServices(r runtime.Runtime) {
 		&services.Networkd{},
 	)
 
+	if r.Config().Machine().Network().DNSCache().Enabled {
+		svcs.Load(
+			&services.DNS{},
+		)
+	}
+
 	if r.Platform().Mode() != runtime.Container {
 		// udevd-trigger is causing stalls/unresponsive stuff when running in local mode
 		// TODO: investigate root cause, but workaround for now is to skip it in container mode
@@ -9,6 +9,7 @@ import (
 
 	"github.com/talos-systems/talos/internal/app/machined/internal/phase"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/log"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
 	"github.com/talos-systems/talos/pkg/config/machine"
 )
@@ -32,9 +33,19 @@ func (task *StopServices) TaskFunc(mode runtime.Mode) phase.TaskFunc {
 
 func (task *StopServices) standard(r runtime.Runtime) (err error) {
 	if task.upgrade {
-		services := []string{"containerd", "networkd", "ntpd", "udevd"}
+		controlPlane := r.Config().Machine().Type() == machine.Bootstrap || r.Config().Machine().Type() == machine.ControlPlane
 
-		if r.Config().Machine().Type() == machine.Bootstrap || r.Config().Machine().Type() == machine.ControlPlane {
+		services := []string{}
+
+		if controlPlane {
+			// etcd is already stopped if the node left the cluster
+			// gracefully, it keeps running otherwise
+			services = append(services, "etcd")
+		}
+
+		services = append(services, "containerd", "networkd", "ntpd", "udevd")
+
+		if controlPlane {
 			services = append(services, "trustd")
 		}
 
@@ -44,10 +55,27 @@ func (task *StopServices) standard(r runtime.Runtime) (err error) {
 			}
 		}
 
+		closeShipper()
+
 		return nil
 	}
 
 	system.Services(nil).Shutdown()
 
+	closeShipper()
+
 	return nil
 }
+
+// closeShipper stops forwarding the logs, the network is gone once the
+// services are stopped.
+func closeShipper() {
+	if shipper == nil {
+		return
+	}
+
+	log.SetSink(nil)
+	shipper.Close()
+
+	shipper = nil
+}
@@ -55,6 +55,13 @@ func (task *LeaveEtcd) standard(r runtime.Runtime) (err error) {
 		return err
 	}
 
+	// The only member can't be removed, there is no cluster to leave.
+	if len(resp.Members) == 1 {
+		log.Println("the node is the only etcd member, not leaving the etcd cluster")
+
+		return nil
+	}
+
 	var id *uint64
 
 	for _, member := range resp.Members {
@@ -67,6 +74,12 @@ func (task *LeaveEtcd) standard(r runtime.Runtime) (err error) {
 		return fmt.Errorf("failed to find %q in list of etcd members", hostname)
 	}
 
+	// Move the leadership off the node first, so that the cluster doesn't
+	// have to wait for an election once the member is removed.
+	if err = etcd.TransferLeadership(context.Background(), client, hostname); err != nil {
+		log.Printf("WARNING: failed to transfer etcd leadership: %v\n", err)
+	}
+
 	log.Println("leaving etcd cluster")
 
 	_, err = client.MemberRemove(context.Background(), *id)
@@ -0,0 +1,47 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package upgrade
+
+import (
+	"log"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/internal/app/machined/internal/phase"
+	"github.com/talos-systems/talos/internal/pkg/install"
+	"github.com/talos-systems/talos/internal/pkg/runtime"
+)
+
+// Stage represents the task for pulling and validating the installer image
+// before the node is disrupted by the upgrade. The image of the request is
+// pinned to the digest of the validated image, so that the upgrade installs
+// it even if the tag is moved meanwhile.
+type Stage struct {
+	req *machineapi.UpgradeRequest
+}
+
+// NewStageTask initializes and returns a Stage task.
+func NewStageTask(req *machineapi.UpgradeRequest) phase.Task {
+	return &Stage{
+		req: req,
+	}
+}
+
+// TaskFunc returns the runtime function.
+func (task *Stage) TaskFunc(mode runtime.Mode) phase.TaskFunc {
+	return task.standard
+}
+
+func (task *Stage) standard(r runtime.Runtime) (err error) {
+	digest, err := install.PullAndValidateInstallerImage(r.Config().Machine().ImagePolicies(), task.req.Image)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("installer image %q (%s) is ready", task.req.Image, digest)
+
+	task.req.Image, err = install.PinnedReference(task.req.Image, digest)
+
+	return err
+}
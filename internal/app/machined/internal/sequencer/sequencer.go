@@ -9,11 +9,15 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/internal/sequencer/v1alpha1"
 )
 
-// Sequencer describes the boot, shutdown, and upgrade events.
+// Sequencer describes the boot, shutdown, upgrade, and reset events.
+//
+// An upgrade is staged first, which must not disrupt the node.
 type Sequencer interface {
 	Boot() error
 	Shutdown() error
+	StageUpgrade(*machineapi.UpgradeRequest) error
 	Upgrade(*machineapi.UpgradeRequest) error
+	Reset(*machineapi.ResetRequest) error
 }
 
 // Version represents the sequencer version.
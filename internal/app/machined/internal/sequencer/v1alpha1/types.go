@@ -102,9 +102,14 @@ func (d *Sequencer) Boot() error {
 			"platform tasks",
 			platform.NewPlatformTask(),
 		),
+		phase.NewPhase(
+			"last known time",
+			rootfs.NewLastKnownTimeTask(),
+		),
 		phase.NewPhase(
 			"installation verification",
 			rootfs.NewCheckInstallTask(),
+			rootfs.NewCheckBootSlotTask(),
 		),
 		phase.NewPhase(
 			"overlay mounts",
@@ -139,6 +144,10 @@ func (d *Sequencer) Boot() error {
 			"post startup tasks",
 			services.NewLabelNodeAsMasterTask(),
 		),
+		phase.NewPhase(
+			"boot health",
+			rootfs.NewMarkBootHealthyTask(),
+		),
 	)
 
 	return phaserunner.Run()
@@ -171,6 +180,33 @@ func (d *Sequencer) Shutdown() error {
 	return phaserunner.Run()
 }
 
+// StageUpgrade implements the Sequencer interface.
+func (d *Sequencer) StageUpgrade(req *machineapi.UpgradeRequest) error {
+	content, err := config.FromFile(constants.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	config, err := config.New(content)
+	if err != nil {
+		return err
+	}
+
+	phaserunner, err := phase.NewRunner(config, runtime.Upgrade)
+	if err != nil {
+		return err
+	}
+
+	phaserunner.Add(
+		phase.NewPhase(
+			"pull and validate installer image",
+			upgrade.NewStageTask(req),
+		),
+	)
+
+	return phaserunner.Run()
+}
+
 // Upgrade implements the Sequencer interface.
 func (d *Sequencer) Upgrade(req *machineapi.UpgradeRequest) error {
 	content, err := config.FromFile(constants.ConfigPath)
@@ -196,6 +232,7 @@ func (d *Sequencer) Upgrade(req *machineapi.UpgradeRequest) error {
 	}
 
 	devname := dev.Device().Name()
+	partname := dev.Path
 
 	if err := dev.Close(); err != nil {
 		return err
@@ -229,8 +266,8 @@ func (d *Sequencer) Upgrade(req *machineapi.UpgradeRequest) error {
 			disk.NewVerifyDiskAvailabilityTask(devname),
 		),
 		phase.NewPhase(
-			"reset system disk",
-			disk.NewResetSystemDiskTask(devname),
+			"reset ephemeral partition",
+			disk.NewResetEphemeralPartitionTask(partname),
 		),
 		phase.NewPhase(
 			"upgrade",
@@ -240,3 +277,90 @@ func (d *Sequencer) Upgrade(req *machineapi.UpgradeRequest) error {
 
 	return phaserunner.Run()
 }
+
+// Reset implements the Sequencer interface.
+func (d *Sequencer) Reset(req *machineapi.ResetRequest) error {
+	content, err := config.FromFile(constants.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	config, err := config.New(content)
+	if err != nil {
+		return err
+	}
+
+	phaserunner, err := phase.NewRunner(config, runtime.Reset)
+	if err != nil {
+		return err
+	}
+
+	var dev *probe.ProbedBlockDevice
+
+	dev, err = probe.GetDevWithFileSystemLabel(constants.EphemeralPartitionLabel)
+	if err != nil {
+		return err
+	}
+
+	devname := dev.Device().Name()
+	partname := dev.Path
+
+	if err := dev.Close(); err != nil {
+		return err
+	}
+
+	if !req.GetForce() {
+		phaserunner.Add(
+			phase.NewPhase(
+				"cordon and drain node",
+				kubernetes.NewCordonAndDrainTask(),
+				upgrade.NewLeaveEtcdTask(),
+			),
+		)
+	}
+
+	phaserunner.Add(
+		phase.NewPhase(
+			"remove all pods",
+			kubernetes.NewRemoveAllPodsTask(),
+		),
+		phase.NewPhase(
+			"stop services",
+			services.NewStopServicesTask(true),
+		),
+		phase.NewPhase(
+			"unmount system disk submounts",
+			rootfs.NewUnmountOverlayTask(),
+			rootfs.NewUnmountPodMountsTask(),
+		),
+		phase.NewPhase(
+			"unmount system disk",
+			rootfs.NewUnmountSystemDisksTask(devname),
+		),
+		phase.NewPhase(
+			"verify system disk not in use",
+			disk.NewVerifyDiskAvailabilityTask(devname),
+		),
+	)
+
+	switch req.GetScope() {
+	case machineapi.ResetScope_EPHEMERAL:
+		phaserunner.Add(
+			phase.NewPhase(
+				"reset ephemeral partition",
+				disk.NewResetEphemeralPartitionTask(partname),
+			),
+		)
+	case machineapi.ResetScope_SYSTEM_DISK:
+		phaserunner.Add(
+			phase.NewPhase(
+				"reset system disk",
+				disk.NewResetSystemDiskTask(devname),
+			),
+		)
+	default:
+		return fmt.Errorf("unknown reset scope: %s", req.GetScope())
+	}
+
+	return phaserunner.Run()
+}
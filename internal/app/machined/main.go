@@ -68,6 +68,27 @@ func sync() {
 	log.Printf("sync hasn't completed in time, aborting...")
 }
 
+// runSequence runs the sequence, publishing its start and finish on the
+// event bus.
+func runSequence(name string, f func() error) error {
+	event.NotifySequence(name, machineapi.EventAction_START, nil)
+
+	err := f()
+
+	event.NotifySequence(name, machineapi.EventAction_STOP, err)
+
+	return err
+}
+
+// upgrade runs the upgrade sequence, and reboots the node.
+func upgrade(seq sequencer.Sequencer, req *machineapi.UpgradeRequest) {
+	if err := runSequence("upgrade", func() error { return seq.Upgrade(req) }); err != nil {
+		panic(fmt.Errorf("upgrade failed: %w", err))
+	}
+
+	event.Bus().Notify(event.Event{Type: event.Reboot})
+}
+
 // nolint: gocyclo
 func main() {
 	var err error
@@ -113,7 +134,7 @@ func main() {
 	// Start the boot sequence in a go routine so that we can list for events.
 	go func() {
 		defer recovery()
-		if err := seq.Boot(); err != nil {
+		if err := runSequence("boot", seq.Boot); err != nil {
 			log.Println(err)
 			panic(fmt.Errorf("boot failed: %w", err))
 		}
@@ -129,7 +150,20 @@ func main() {
 			rebootFlag = unix.LINUX_REBOOT_CMD_POWER_OFF
 			fallthrough
 		case event.Reboot:
-			if err := seq.Shutdown(); err != nil {
+			if rebootFlag == unix.LINUX_REBOOT_CMD_RESTART {
+				if req := stagedUpgrade(); req != nil {
+					upgrade(seq, req)
+
+					continue
+				}
+			}
+
+			sequence := "reboot"
+			if rebootFlag == unix.LINUX_REBOOT_CMD_POWER_OFF {
+				sequence = "shutdown"
+			}
+
+			if err := runSequence(sequence, seq.Shutdown); err != nil {
 				panic(fmt.Errorf("shutdown failed: %w", err))
 			}
 
@@ -149,11 +183,51 @@ func main() {
 				continue
 			}
 
-			if err := seq.Upgrade(req); err != nil {
-				panic(fmt.Errorf("upgrade failed: %w", err))
+			if err := runSequence("stage upgrade", func() error { return seq.StageUpgrade(req) }); err != nil {
+				// Nothing was disrupted yet, the node keeps running.
+				log.Printf("upgrade to %q aborted: %v", req.Image, err)
+				continue
 			}
 
-			event.Bus().Notify(event.Event{Type: event.Reboot})
+			if req.GetStage() {
+				if err := stageUpgrade(req); err != nil {
+					log.Printf("failed to stage upgrade to %q: %v", req.Image, err)
+					continue
+				}
+
+				log.Printf("upgrade to %q is staged, it is applied on the next reboot", req.Image)
+
+				continue
+			}
+
+			upgrade(seq, req)
+		case event.Reset:
+			var (
+				req *machineapi.ResetRequest
+				ok  bool
+			)
+
+			if req, ok = e.Data.(*machineapi.ResetRequest); !ok {
+				log.Println("cannot perform reset, unexpected data type")
+				continue
+			}
+
+			if err := runSequence("reset", func() error { return seq.Reset(req) }); err != nil {
+				panic(fmt.Errorf("reset failed: %w", err))
+			}
+
+			rebootFlag = unix.LINUX_REBOOT_CMD_POWER_OFF
+			if req.GetReboot() {
+				rebootFlag = unix.LINUX_REBOOT_CMD_RESTART
+			}
+
+			// The reset sequence stopped the services and unmounted the
+			// system disk already, the shutdown sequence is skipped.
+			sync()
+
+			if unix.Reboot(rebootFlag) == nil {
+				select {}
+			}
 		}
 	}
 }
@@ -0,0 +1,40 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package conditions
+
+import (
+	"context"
+	"time"
+)
+
+type timeSync struct {
+	synced  file
+	timeout time.Duration
+}
+
+func (t timeSync) Wait(ctx context.Context) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	err := t.synced.Wait(timeoutCtx)
+	if err == context.DeadlineExceeded && ctx.Err() == nil {
+		// the clock isn't behind the last known time, which is restored on
+		// boot, it is good enough if ntpd can't synchronize
+		return nil
+	}
+
+	return err
+}
+
+func (t timeSync) String() string {
+	return "time to be synchronized"
+}
+
+// WaitForTimeSync is a service condition that will wait for ntpd to
+// synchronize the time, which ntpd reports by creating the file, for at most
+// the timeout.
+func WaitForTimeSync(syncedPath string, timeout time.Duration) Condition {
+	return timeSync{synced: file(syncedPath), timeout: timeout}
+}
@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package conditions_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/conditions"
+)
+
+type TimeSuite struct {
+	suite.Suite
+
+	tempDir string
+}
+
+func (suite *TimeSuite) SetupSuite() {
+	var err error
+	suite.tempDir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+}
+
+func (suite *TimeSuite) TearDownSuite() {
+	suite.Require().NoError(os.RemoveAll(suite.tempDir))
+}
+
+func (suite *TimeSuite) TestString() {
+	suite.Require().Equal("time to be synchronized", conditions.WaitForTimeSync("synced", time.Minute).String())
+}
+
+func (suite *TimeSuite) TestWaitForTimeSync() {
+	path := filepath.Join(suite.tempDir, "synced")
+
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- conditions.WaitForTimeSync(path, time.Minute).Wait(context.Background())
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	select {
+	case <-errCh:
+		suite.Fail("unexpected return")
+	default:
+	}
+
+	suite.Require().NoError(ioutil.WriteFile(path, nil, 0644))
+
+	suite.Require().NoError(<-errCh)
+
+	suite.Require().NoError(os.Remove(path))
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	go func() {
+		errCh <- conditions.WaitForTimeSync(path, time.Minute).Wait(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	ctxCancel()
+
+	suite.Require().Equal(context.Canceled, <-errCh)
+}
+
+func (suite *TimeSuite) TestTimeout() {
+	path := filepath.Join(suite.tempDir, "never")
+
+	start := time.Now()
+
+	suite.Require().NoError(conditions.WaitForTimeSync(path, 100*time.Millisecond).Wait(context.Background()))
+	suite.Assert().True(time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestTimeSuite(t *testing.T) {
+	suite.Run(t, new(TimeSuite))
+}
@@ -0,0 +1,183 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/talos-systems/talos/pkg/chunker"
+	filechunker "github.com/talos-systems/talos/pkg/chunker/file"
+)
+
+// segmentChunker streams a log together with its rotated segments.
+type segmentChunker struct {
+	path   string
+	follow bool
+	tail   int
+}
+
+// NewChunker initializes a chunker.Chunker which streams the log at path,
+// starting with the oldest rotated segment, so that readers see one
+// continuous stream.
+//
+// If tail is positive, the stream starts at the last tail lines of the log.
+// If follow is set, the chunker keeps streaming data appended to the log,
+// across rotations.
+func NewChunker(path string, follow bool, tail int) chunker.Chunker {
+	return &segmentChunker{
+		path:   path,
+		follow: follow,
+		tail:   tail,
+	}
+}
+
+// Read implements chunker.Chunker.
+func (c *segmentChunker) Read(ctx context.Context) <-chan []byte {
+	ch := make(chan []byte, 1)
+
+	go func() {
+		defer close(ch)
+
+		segments, tail, err := c.segments()
+		if err != nil {
+			log.Printf("failed to list log segments: %v\n", err)
+			return
+		}
+
+		for i, path := range segments {
+			last := i == len(segments)-1
+
+			opts := []filechunker.Option{filechunker.Follow(c.follow && last)}
+			if i == 0 {
+				opts = append(opts, filechunker.Tail(tail))
+			}
+
+			if !c.stream(ctx, ch, path, opts...) {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// stream copies a single segment to ch, it returns false if the stream
+// should be aborted.
+func (c *segmentChunker) stream(ctx context.Context, ch chan<- []byte, path string, opts ...filechunker.Option) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// segment was rotated away while we were reading previous ones
+			return true
+		}
+
+		log.Printf("failed to open log segment: %v\n", err)
+
+		return false
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	for b := range filechunker.NewChunker(f, opts...).Read(ctx) {
+		select {
+		case ch <- b:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return ctx.Err() == nil
+}
+
+// segments returns the paths of the segments to read, oldest first, along
+// with the number of lines to read from the first one (0 meaning all).
+func (c *segmentChunker) segments() ([]string, int, error) {
+	var segments []string
+
+	for n := 1; ; n++ {
+		path := segmentPath(c.path, n)
+
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				break
+			}
+
+			return nil, 0, err
+		}
+
+		segments = append([]string{path}, segments...)
+	}
+
+	segments = append(segments, c.path)
+
+	if c.tail <= 0 {
+		return segments, 0, nil
+	}
+
+	remaining := c.tail
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		lines, err := countLines(segments[i])
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if lines >= remaining {
+			return segments[i:], remaining, nil
+		}
+
+		remaining -= lines
+	}
+
+	return segments, 0, nil
+}
+
+// countLines returns the number of lines in the file, an incomplete last
+// line is counted as well.
+func countLines(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	var (
+		lines int
+		last  byte
+	)
+
+	buf := make([]byte, 32*1024)
+
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if last != 0 && last != '\n' {
+		lines++
+	}
+
+	return lines, nil
+}
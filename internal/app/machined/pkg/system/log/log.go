@@ -5,24 +5,81 @@
 package log
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
 
-	filechunker "github.com/talos-systems/talos/pkg/chunker/file"
+	"github.com/talos-systems/talos/pkg/constants"
 )
 
 var instance = map[string]*Log{}
 var mu sync.Mutex
 
+var sink Sink
+
+var rotation = struct {
+	maxSize  int64
+	maxFiles int
+}{
+	maxSize:  constants.DefaultLogMaxSize,
+	maxFiles: constants.DefaultLogMaxFiles,
+}
+
+// maxLineLength is the size at which a line which is still incomplete is
+// forwarded to the sink anyway.
+const maxLineLength = 64 * 1024
+
+// Sink receives every line written to the service logs. The line is only
+// valid for the duration of the call.
+type Sink interface {
+	WriteLine(service string, line []byte)
+}
+
 // Log represents the log of a service. It supports streaming of the contents of
 // the log file by way of implementing the chunker.Chunker interface.
+//
+// Once the log file grows beyond the configured size, it is rotated: the
+// file is renamed to <name>.log.1 (shifting older files up by one), and a new
+// file is started. Files beyond the configured count are removed.
 type Log struct {
-	Name   string
-	Path   string
-	source filechunker.Source
+	Name string
+	Path string
+
+	mu       sync.Mutex
+	source   *os.File
+	size     int64
+	maxSize  int64
+	maxFiles int
+	pending  []byte
+}
+
+// SetRotation sets the size at which logs are rotated, and the number of
+// rotated files kept for each log. It applies to logs created after the call.
+func SetRotation(maxSize int64, maxFiles int) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	rotation.maxSize = maxSize
+	rotation.maxFiles = maxFiles
+}
+
+// SetSink sets the sink every service log line is forwarded to, nil disables
+// forwarding.
+func SetSink(s Sink) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	sink = s
+}
+
+func currentSink() Sink {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return sink
 }
 
 // New initializes and registers a log for a service.
@@ -34,17 +91,31 @@ func New(name, rootPath string) (*Log, error) {
 		mu.Unlock()
 		return l, nil
 	}
+	maxSize, maxFiles := rotation.maxSize, rotation.maxFiles
 	mu.Unlock()
 
-	w, err := os.Create(logpath)
+	// the log of the previous boot is appended to, so that it stays
+	// continuous with the rotated segments
+	w, err := os.OpenFile(logpath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
 	if err != nil {
 		return nil, fmt.Errorf("create log file: %s", err.Error())
 	}
 
+	st, err := w.Stat()
+	if err != nil {
+		// nolint: errcheck
+		w.Close()
+
+		return nil, fmt.Errorf("stat log file: %w", err)
+	}
+
 	l := &Log{
-		Name:   name,
-		Path:   logpath,
-		source: w,
+		Name:     name,
+		Path:     logpath,
+		source:   w,
+		size:     st.Size(),
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
 	}
 
 	mu.Lock()
@@ -56,7 +127,23 @@ func New(name, rootPath string) (*Log, error) {
 
 // Write implements io.WriteCloser.
 func (l *Log) Write(p []byte) (n int, err error) {
-	return l.source.Write(p)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(p)) > l.maxSize {
+		if err = l.rotate(); err != nil {
+			return 0, fmt.Errorf("rotate log file: %w", err)
+		}
+	}
+
+	n, err = l.source.Write(p)
+	l.size += int64(n)
+
+	if s := currentSink(); s != nil {
+		l.forward(s, p[:n])
+	}
+
+	return n, err
 }
 
 // Close implements io.WriteCloser.
@@ -65,16 +152,83 @@ func (l *Log) Close() error {
 	delete(instance, l.Path)
 	mu.Unlock()
 
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	return l.source.Close()
 }
 
 // Read implements chunker.Chunker.
 func (l *Log) Read(ctx context.Context) <-chan []byte {
-	c := filechunker.NewChunker(l.source)
-	return c.Read(ctx)
+	return NewChunker(l.Path, true, 0).Read(ctx)
+}
+
+// forward splits the written data into lines and passes complete lines to
+// the sink, the remainder is kept until the line is completed.
+func (l *Log) forward(s Sink, p []byte) {
+	l.pending = append(l.pending, p...)
+
+	for {
+		idx := bytes.IndexByte(l.pending, '\n')
+		if idx < 0 {
+			break
+		}
+
+		s.WriteLine(l.Name, l.pending[:idx])
+
+		l.pending = l.pending[idx+1:]
+	}
+
+	if len(l.pending) >= maxLineLength {
+		s.WriteLine(l.Name, l.pending)
+
+		l.pending = nil
+	}
+
+	if len(l.pending) == 0 {
+		// release the buffer
+		l.pending = nil
+	}
+}
+
+func (l *Log) rotate() error {
+	if err := l.source.Close(); err != nil {
+		return err
+	}
+
+	// shift every segment up by one, the active file becomes <name>.log.1
+	for i := l.maxFiles + 1; i > 0; i-- {
+		if err := os.Rename(segmentPath(l.Path, i-1), segmentPath(l.Path, i)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := os.Remove(segmentPath(l.Path, l.maxFiles+1)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	w, err := os.Create(l.Path)
+	if err != nil {
+		return err
+	}
+
+	l.source = w
+	l.size = 0
+
+	return nil
 }
 
 // FormatLogPath formats the path the log file.
 func FormatLogPath(p, rootPath string) string {
 	return filepath.Join(rootPath, p+".log")
 }
+
+// segmentPath returns the path of the n-th rotated segment of the log, the
+// active log file being segment 0.
+func segmentPath(path string, n int) string {
+	if n == 0 {
+		return path
+	}
+
+	return fmt.Sprintf("%s.%d", path, n)
+}
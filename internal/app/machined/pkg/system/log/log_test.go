@@ -0,0 +1,185 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package log_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/log"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+type LogSuite struct {
+	suite.Suite
+
+	tmpDir string
+	no     int
+	log    *log.Log
+}
+
+func (suite *LogSuite) SetupSuite() {
+	var err error
+
+	suite.tmpDir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+
+	log.SetRotation(16, 2)
+}
+
+func (suite *LogSuite) SetupTest() {
+	suite.no++
+
+	var err error
+
+	suite.log, err = log.New(fmt.Sprintf("%d", suite.no), suite.tmpDir)
+	suite.Require().NoError(err)
+}
+
+func (suite *LogSuite) TearDownTest() {
+	suite.Require().NoError(suite.log.Close())
+}
+
+func (suite *LogSuite) TearDownSuite() {
+	log.SetRotation(constants.DefaultLogMaxSize, constants.DefaultLogMaxFiles)
+
+	suite.Require().NoError(os.RemoveAll(suite.tmpDir))
+}
+
+func (suite *LogSuite) write(lines ...string) {
+	for _, line := range lines {
+		_, err := suite.log.Write([]byte(line + "\n"))
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *LogSuite) contents(path string) string {
+	b, err := ioutil.ReadFile(path)
+	suite.Require().NoError(err)
+
+	return string(b)
+}
+
+func collectChunks(chunksCh <-chan []byte) <-chan []byte {
+	combinedCh := make(chan []byte)
+
+	go func() {
+		res := []byte(nil)
+
+		for chunk := range chunksCh {
+			res = append(res, chunk...)
+		}
+
+		combinedCh <- res
+	}()
+
+	return combinedCh
+}
+
+func (suite *LogSuite) TestRotation() {
+	suite.write("line 1", "line 2", "line 3", "line 4", "line 5", "line 6", "line 7")
+
+	suite.Assert().Equal("line 7\n", suite.contents(suite.log.Path))
+	suite.Assert().Equal("line 5\nline 6\n", suite.contents(suite.log.Path+".1"))
+	suite.Assert().Equal("line 3\nline 4\n", suite.contents(suite.log.Path+".2"))
+
+	_, err := os.Stat(suite.log.Path + ".3")
+	suite.Assert().True(os.IsNotExist(err))
+}
+
+func (suite *LogSuite) TestReadSegments() {
+	suite.write("line 1", "line 2", "line 3", "line 4", "line 5")
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	combined := <-collectChunks(log.NewChunker(suite.log.Path, false, 0).Read(ctx))
+	suite.Assert().Equal("line 1\nline 2\nline 3\nline 4\nline 5\n", string(combined))
+
+	combined = <-collectChunks(log.NewChunker(suite.log.Path, false, 3).Read(ctx))
+	suite.Assert().Equal("line 3\nline 4\nline 5\n", string(combined))
+
+	combined = <-collectChunks(log.NewChunker(suite.log.Path, false, 100).Read(ctx))
+	suite.Assert().Equal("line 1\nline 2\nline 3\nline 4\nline 5\n", string(combined))
+}
+
+func (suite *LogSuite) TestFollowRotation() {
+	suite.write("line 1", "line 2")
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	combinedCh := collectChunks(suite.log.Read(ctx))
+
+	time.Sleep(50 * time.Millisecond)
+
+	suite.write("line 3", "line 4")
+
+	time.Sleep(50 * time.Millisecond)
+
+	suite.write("line 5")
+
+	time.Sleep(50 * time.Millisecond)
+
+	ctxCancel()
+
+	suite.Assert().Equal("line 1\nline 2\nline 3\nline 4\nline 5\n", string(<-combinedCh))
+}
+
+func (suite *LogSuite) TestReopen() {
+	suite.write("line 1", "line 2", "line 3")
+
+	// the log is created again on the next boot
+	suite.Require().NoError(suite.log.Close())
+
+	var err error
+
+	suite.log, err = log.New(suite.log.Name, suite.tmpDir)
+	suite.Require().NoError(err)
+
+	suite.write("line 4", "line 5")
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	combined := <-collectChunks(log.NewChunker(suite.log.Path, false, 0).Read(ctx))
+	suite.Assert().Equal("line 1\nline 2\nline 3\nline 4\nline 5\n", string(combined))
+
+	suite.Assert().Equal("line 5\n", suite.contents(suite.log.Path))
+}
+
+type recordingSink struct {
+	lines []string
+}
+
+func (s *recordingSink) WriteLine(service string, line []byte) {
+	s.lines = append(s.lines, service+": "+string(line))
+}
+
+func (suite *LogSuite) TestSink() {
+	sink := &recordingSink{}
+
+	log.SetSink(sink)
+	defer log.SetSink(nil)
+
+	for _, chunk := range []string{"line 1\nli", "ne 2", "\n", "line 3\nline 4\n"} {
+		_, err := suite.log.Write([]byte(chunk))
+		suite.Require().NoError(err)
+	}
+
+	name := suite.log.Name
+
+	suite.Assert().Equal([]string{name + ": line 1", name + ": line 2", name + ": line 3", name + ": line 4"}, sink.lines)
+}
+
+func TestLogSuite(t *testing.T) {
+	suite.Run(t, new(LogSuite))
+}
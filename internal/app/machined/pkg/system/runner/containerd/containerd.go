@@ -8,7 +8,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"syscall"
 	"time"
 
@@ -18,6 +17,7 @@ import (
 	"github.com/containerd/containerd/oci"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
+	processlogger "github.com/talos-systems/talos/internal/app/machined/pkg/system/log"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
 )
 
@@ -121,7 +121,12 @@ func (c *containerdRunner) Run(eventSink events.Recorder) error {
 		err  error
 	)
 
-	creator := cio.LogFile(c.logPath())
+	w, err := processlogger.New(c.args.ID, c.opts.LogPath)
+	if err != nil {
+		return fmt.Errorf("service log handler: %w", err)
+	}
+
+	creator := cio.NewCreator(cio.WithStreams(nil, w, w))
 	if c.debug {
 		creator = cio.NewCreator(cio.WithStreams(os.Stdin, os.Stdout, os.Stderr))
 	}
@@ -216,10 +221,6 @@ func (c *containerdRunner) newOCISpecOpts(image oci.Image) []oci.SpecOpts {
 	return specOpts
 }
 
-func (c *containerdRunner) logPath() string {
-	return filepath.Join(c.opts.LogPath, c.args.ID+".log")
-}
-
 func (c *containerdRunner) String() string {
 	return fmt.Sprintf("Containerd(%v)", c.args.ID)
 }
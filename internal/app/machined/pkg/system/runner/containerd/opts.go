@@ -6,6 +6,8 @@ package containerd
 
 import (
 	"context"
+	"io/ioutil"
+	"path/filepath"
 
 	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/oci"
@@ -30,3 +32,33 @@ func WithRootfsPropagation(rp string) oci.SpecOpts {
 		return nil
 	}
 }
+
+// WithWriteableProcSysNet makes /proc/sys/net writeable, /proc/sys is
+// read-only by default. runc refuses to mount anything in /proc, so the rest
+// of /proc/sys is made read-only instead of /proc/sys itself.
+func WithWriteableProcSysNet() oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		entries, err := ioutil.ReadDir("/proc/sys")
+		if err != nil {
+			return err
+		}
+
+		paths := []string{}
+
+		for _, path := range s.Linux.ReadonlyPaths {
+			if path != "/proc/sys" {
+				paths = append(paths, path)
+			}
+		}
+
+		for _, entry := range entries {
+			if entry.Name() != "net" {
+				paths = append(paths, filepath.Join("/proc/sys", entry.Name()))
+			}
+		}
+
+		s.Linux.ReadonlyPaths = paths
+
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containerd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/oci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/suite"
+
+	containerdrunner "github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
+)
+
+type OptsSuite struct {
+	suite.Suite
+}
+
+func TestOptsSuite(t *testing.T) {
+	suite.Run(t, new(OptsSuite))
+}
+
+func (suite *OptsSuite) TestWithWriteableProcSysNet() {
+	s := &oci.Spec{
+		Linux: &specs.Linux{
+			ReadonlyPaths: []string{"/proc/asound", "/proc/sys"},
+		},
+	}
+
+	suite.Require().NoError(containerdrunner.WithWriteableProcSysNet()(context.Background(), nil, nil, s))
+
+	suite.Assert().Contains(s.Linux.ReadonlyPaths, "/proc/asound")
+	suite.Assert().Contains(s.Linux.ReadonlyPaths, "/proc/sys/kernel")
+	suite.Assert().Contains(s.Linux.ReadonlyPaths, "/proc/sys/vm")
+	suite.Assert().NotContains(s.Linux.ReadonlyPaths, "/proc/sys")
+	suite.Assert().NotContains(s.Linux.ReadonlyPaths, "/proc/sys/net")
+}
@@ -11,11 +11,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+
 	machineapi "github.com/talos-systems/talos/api/machine"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/conditions"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/health"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
+	"github.com/talos-systems/talos/internal/pkg/event"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
 )
 
@@ -79,6 +82,8 @@ func (svcrunner *ServiceRunner) UpdateState(newstate events.ServiceState, messag
 	isDown := svcrunner.inStateLocked(StateEventDown)
 	svcrunner.mu.Unlock()
 
+	svcrunner.publish(event)
+
 	if isUp {
 		svcrunner.notifyEvent(StateEventUp)
 	}
@@ -116,11 +121,33 @@ func (svcrunner *ServiceRunner) healthUpdate(change health.StateChange) {
 	isUp := svcrunner.inStateLocked(StateEventUp)
 	svcrunner.mu.Unlock()
 
+	svcrunner.publish(event)
+
 	if isUp {
 		svcrunner.notifyEvent(StateEventUp)
 	}
 }
 
+// publish sends the service event to the machine-wide event bus.
+func (svcrunner *ServiceRunner) publish(e events.ServiceEvent) {
+	// nolint: errcheck
+	ts, _ := ptypes.TimestampProto(e.Timestamp)
+
+	event.Bus().Notify(event.Event{
+		Type: event.Service,
+		Data: &machineapi.Event{
+			Ts:   ts,
+			Type: machineapi.EventType_SERVICE,
+			Service: &machineapi.ServiceStateEvent{
+				Service: svcrunner.id,
+				State:   e.State.String(),
+				Message: e.Message,
+				Health:  svcrunner.healthState.AsProto(),
+			},
+		},
+	})
+}
+
 // GetEventHistory returns history of events for this service
 func (svcrunner *ServiceRunner) GetEventHistory(count int) []events.ServiceEvent {
 	svcrunner.mu.Lock()
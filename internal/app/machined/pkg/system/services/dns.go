@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package services
+
+import (
+	"context"
+
+	"github.com/talos-systems/talos/internal/app/machined/internal/dns"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/conditions"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/goroutine"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
+	"github.com/talos-systems/talos/internal/pkg/runtime"
+)
+
+// DNS implements the Service interface. It serves as the concrete type with
+// the required methods.
+type DNS struct{}
+
+// ID implements the Service interface.
+func (d *DNS) ID(config runtime.Configurator) string {
+	return "dns"
+}
+
+// PreFunc implements the Service interface.
+func (d *DNS) PreFunc(ctx context.Context, config runtime.Configurator) error {
+	return nil
+}
+
+// PostFunc implements the Service interface.
+func (d *DNS) PostFunc(config runtime.Configurator) (err error) {
+	return nil
+}
+
+// Condition implements the Service interface.
+func (d *DNS) Condition(config runtime.Configurator) conditions.Condition {
+	return nil
+}
+
+// DependsOn implements the Service interface.
+func (d *DNS) DependsOn(config runtime.Configurator) []string {
+	return []string{"networkd"}
+}
+
+// Runner implements the Service interface.
+func (d *DNS) Runner(config runtime.Configurator) (runner.Runner, error) {
+	return restart.New(goroutine.NewRunner(config, "dns", dns.NewService().Main),
+		restart.WithType(restart.Forever),
+	), nil
+}
+
+// APIRestartAllowed implements the APIRestartableService interface.
+func (d *DNS) APIRestartAllowed(config runtime.Configurator) bool {
+	return true
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
+	containerdimage "github.com/talos-systems/talos/internal/pkg/containers/image"
 	"github.com/talos-systems/talos/internal/pkg/etcd"
 	"github.com/talos-systems/talos/internal/pkg/metadata"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
@@ -68,7 +69,7 @@ func (e *Etcd) PreFunc(ctx context.Context, config runtime.Configurator) (err er
 
 	// Pull the image and unpack it.
 	containerdctx := namespaces.WithNamespace(ctx, constants.SystemContainerdNamespace)
-	if _, err = client.Pull(containerdctx, etcdImage, containerdapi.WithPullUnpack); err != nil {
+	if _, err = containerdimage.Pull(containerdctx, config.Machine().ImagePolicies(), client, etcdImage); err != nil {
 		return fmt.Errorf("failed to pull image %q: %w", etcdImage, err)
 	}
 
@@ -82,7 +83,7 @@ func (e *Etcd) PostFunc(config runtime.Configurator) (err error) {
 
 // Condition implements the Service interface.
 func (e *Etcd) Condition(config runtime.Configurator) conditions.Condition {
-	return nil
+	return timeSyncCondition(config)
 }
 
 // DependsOn implements the Service interface.
@@ -29,6 +29,7 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
+	containerdimage "github.com/talos-systems/talos/internal/pkg/containers/image"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
 	"github.com/talos-systems/talos/pkg/argsbuilder"
 	"github.com/talos-systems/talos/pkg/constants"
@@ -106,7 +107,7 @@ func (k *Kubelet) PreFunc(ctx context.Context, config runtime.Configurator) erro
 	containerdctx := namespaces.WithNamespace(ctx, "k8s.io")
 
 	image := fmt.Sprintf("%s:v%s", constants.KubernetesImage, config.Cluster().Version())
-	if _, err = client.Pull(containerdctx, image, containerdapi.WithPullUnpack); err != nil {
+	if _, err = containerdimage.Pull(containerdctx, config.Machine().ImagePolicies(), client, image); err != nil {
 		return fmt.Errorf("failed to pull image %q: %w", image, err)
 	}
 
@@ -120,7 +121,7 @@ func (k *Kubelet) PostFunc(config runtime.Configurator) (err error) {
 
 // Condition implements the Service interface.
 func (k *Kubelet) Condition(config runtime.Configurator) conditions.Condition {
-	return nil
+	return timeSyncCondition(config)
 }
 
 // DependsOn implements the Service interface.
@@ -267,5 +268,12 @@ func (k *Kubelet) args(config runtime.Configurator) ([]string, error) {
 		extraArgs.Set("cluster-domain", "cluster.local")
 	}
 
+	// The DNS cache is only reachable from the host network namespace, the
+	// pods resolve names with the resolvers of the network. /run is mounted
+	// at /var/run.
+	if config.Machine().Network().DNSCache().Enabled && !extraArgs.Contains("resolv-conf") {
+		extraArgs.Set("resolv-conf", "/var"+constants.DNSUpstreamResolvConfPath)
+	}
+
 	return blackListArgs.Merge(extraArgs).Args(), nil
 }
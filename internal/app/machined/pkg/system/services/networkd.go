@@ -86,6 +86,15 @@ func (n *Networkd) Runner(config runtime.Configurator) (runner.Runner, error) {
 		{Type: "bind", Destination: filepath.Dir(constants.NetworkSocketPath), Source: filepath.Dir(constants.NetworkSocketPath), Options: []string{"rbind", "rw"}},
 	}
 
+	// The owners of the virtual IPs are elected through etcd
+	if hasVIP(config) {
+		if err := os.MkdirAll(constants.EtcdPKIPath, 0700); err != nil {
+			return nil, err
+		}
+
+		mounts = append(mounts, specs.Mount{Type: "bind", Destination: constants.EtcdPKIPath, Source: constants.EtcdPKIPath, Options: []string{"rbind", "ro"}})
+	}
+
 	env := []string{}
 	for key, val := range config.Machine().Env() {
 		env = append(env, fmt.Sprintf("%s=%s", key, val))
@@ -99,6 +108,8 @@ func (n *Networkd) Runner(config runtime.Configurator) (runner.Runner, error) {
 		runner.WithEnv(env),
 		runner.WithOCISpecOpts(
 			containerd.WithMemoryLimit(int64(1000000*32)),
+			// IPv6 addressing sets the sysctls of the interfaces
+			containerd.WithWriteableProcSysNet(),
 			oci.WithCapabilities([]string{
 				strings.ToUpper("CAP_" + capability.CAP_NET_ADMIN.String()),
 				strings.ToUpper("CAP_" + capability.CAP_NET_RAW.String()),
@@ -111,6 +122,17 @@ func (n *Networkd) Runner(config runtime.Configurator) (runner.Runner, error) {
 	), nil
 }
 
+// hasVIP returns whether a virtual IP is configured on the machine.
+func hasVIP(config runtime.Configurator) bool {
+	for _, device := range config.Machine().Network().Devices() {
+		if device.VIP != nil && !device.Ignore {
+			return true
+		}
+	}
+
+	return false
+}
+
 // HealthFunc implements the HealthcheckedService interface
 func (n *Networkd) HealthFunc(runtime.Configurator) health.Check {
 	return func(ctx context.Context) error {
@@ -13,9 +13,11 @@ import (
 	"strings"
 
 	containerdapi "github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/oci"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/syndtr/gocapability/capability"
+	"golang.org/x/sys/unix"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/conditions"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
@@ -74,11 +76,35 @@ func (n *NTPd) Runner(config runtime.Configurator) (runner.Runner, error) {
 		return nil, err
 	}
 
+	// Ensure the last known time dir exists
+	if err := os.MkdirAll(filepath.Dir(constants.LastKnownTimePath), 0700); err != nil {
+		return nil, err
+	}
+
 	mounts := []specs.Mount{
 		{Type: "bind", Destination: constants.ConfigPath, Source: constants.ConfigPath, Options: []string{"rbind", "ro"}},
 		{Type: "bind", Destination: filepath.Dir(constants.TimeSocketPath), Source: filepath.Dir(constants.TimeSocketPath), Options: []string{"rbind", "rw"}},
+		// the certificates of the NTS-KE servers are verified against the system CAs
+		{Type: "bind", Destination: "/etc/ssl", Source: "/etc/ssl", Options: []string{"bind", "ro"}},
+		{Type: "bind", Destination: filepath.Dir(constants.LastKnownTimePath), Source: filepath.Dir(constants.LastKnownTimePath), Options: []string{"rbind", "rw"}},
+	}
+
+	specOpts := []oci.SpecOpts{
+		containerd.WithMemoryLimit(int64(1000000 * 32)),
+		oci.WithCapabilities([]string{
+			strings.ToUpper("CAP_" + capability.CAP_SYS_TIME.String()),
+		}),
+		oci.WithHostNamespace(specs.NetworkNamespace),
 	}
 
+	// the hardware clock is synced after each synchronization, if there is one
+	if rtc, err := withRTCDevice(constants.RTCPath); err == nil {
+		mounts = append(mounts, specs.Mount{Type: "bind", Destination: constants.RTCPath, Source: constants.RTCPath, Options: []string{"bind", "rw"}})
+		specOpts = append(specOpts, rtc)
+	}
+
+	specOpts = append(specOpts, oci.WithMounts(mounts))
+
 	env := []string{}
 	for key, val := range config.Machine().Env() {
 		env = append(env, fmt.Sprintf("%s=%s", key, val))
@@ -90,19 +116,46 @@ func (n *NTPd) Runner(config runtime.Configurator) (runner.Runner, error) {
 		runner.WithContainerdAddress(constants.SystemContainerdAddress),
 		runner.WithContainerImage(image),
 		runner.WithEnv(env),
-		runner.WithOCISpecOpts(
-			containerd.WithMemoryLimit(int64(1000000*32)),
-			oci.WithCapabilities([]string{
-				strings.ToUpper("CAP_" + capability.CAP_SYS_TIME.String()),
-			}),
-			oci.WithHostNamespace(specs.NetworkNamespace),
-			oci.WithMounts(mounts),
-		),
+		runner.WithOCISpecOpts(specOpts...),
 	),
 		restart.WithType(restart.Forever),
 	), nil
 }
 
+// timeSyncCondition is the condition of the services which need a
+// synchronized time, e.g. to issue or validate certificates.
+func timeSyncCondition(config runtime.Configurator) conditions.Condition {
+	if !config.Machine().Time().SyncWait() {
+		return nil
+	}
+
+	return conditions.WaitForTimeSync(constants.TimeSyncedPath, config.Machine().Time().SyncTimeout())
+}
+
+// withRTCDevice allows read and write access to the hardware clock device,
+// whose major number is dynamic.
+func withRTCDevice(path string) (oci.SpecOpts, error) {
+	var st unix.Stat_t
+
+	if err := unix.Stat(path, &st); err != nil {
+		return nil, err
+	}
+
+	major, minor := int64(unix.Major(st.Rdev)), int64(unix.Minor(st.Rdev))
+
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *oci.Spec) error {
+		s.Linux.Resources.Devices = append(s.Linux.Resources.Devices, specs.LinuxDeviceCgroup{
+			Allow:  true,
+			Type:   "c",
+			Major:  &major,
+			Minor:  &minor,
+			Access: "rw",
+		})
+
+		return nil
+	}, nil
+}
+
 // APIStartAllowed implements the APIStartableService interface.
 func (n *NTPd) APIStartAllowed(config runtime.Configurator) bool {
 	return true
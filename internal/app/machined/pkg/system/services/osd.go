@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	containerdapi "github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/oci"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/syndtr/gocapability/capability"
@@ -93,6 +94,7 @@ func (o *OSD) Runner(config runtime.Configurator) (runner.Runner, error) {
 		{Type: "bind", Destination: constants.DefaultLogPath, Source: constants.DefaultLogPath, Options: []string{"bind", "ro"}},
 		{Type: "bind", Destination: constants.SystemRunPath, Source: constants.SystemRunPath, Options: []string{"bind", "ro"}},
 		{Type: "bind", Destination: filepath.Dir(constants.OSSocketPath), Source: filepath.Dir(constants.OSSocketPath), Options: []string{"rbind", "rw"}},
+		{Type: "bind", Destination: "/dev/kmsg", Source: "/dev/kmsg", Options: []string{"bind", "ro"}},
 	}
 
 	env := []string{}
@@ -115,12 +117,29 @@ func (o *OSD) Runner(config runtime.Configurator) (runner.Runner, error) {
 			}),
 			oci.WithHostNamespace(specs.PIDNamespace),
 			oci.WithMounts(mounts),
+			withKmsgDevice,
 		),
 	),
 		restart.WithType(restart.Forever),
 	), nil
 }
 
+// withKmsgDevice allows read access to /dev/kmsg, which is used to stream
+// the kernel log.
+func withKmsgDevice(_ context.Context, _ oci.Client, _ *containers.Container, s *oci.Spec) error {
+	major, minor := int64(1), int64(11)
+
+	s.Linux.Resources.Devices = append(s.Linux.Resources.Devices, specs.LinuxDeviceCgroup{
+		Allow:  true,
+		Type:   "c",
+		Major:  &major,
+		Minor:  &minor,
+		Access: "r",
+	})
+
+	return nil
+}
+
 // HealthFunc implements the HealthcheckedService interface
 func (o *OSD) HealthFunc(runtime.Configurator) health.Check {
 	return func(ctx context.Context) error {
@@ -51,7 +51,7 @@ func (t *Trustd) PostFunc(config runtime.Configurator) (err error) {
 
 // Condition implements the Service interface.
 func (t *Trustd) Condition(config runtime.Configurator) conditions.Condition {
-	return nil
+	return timeSyncCondition(config)
 }
 
 // DependsOn implements the Service interface.
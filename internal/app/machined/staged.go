@@ -0,0 +1,78 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/internal/pkg/etcd"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+// stageUpgrade saves the upgrade to apply on the next reboot. It is kept on
+// the ephemeral partition, so that it survives a restart of machined.
+func stageUpgrade(req *machineapi.UpgradeRequest) error {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(constants.StagedUpgradePath), 0700); err != nil {
+		return err
+	}
+
+	tmp := constants.StagedUpgradePath + ".tmp"
+
+	if err = ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, constants.StagedUpgradePath)
+}
+
+// stagedUpgrade returns the staged upgrade, if any, and unstages it. The
+// etcd cluster is validated again, it may have changed since the upgrade was
+// staged; the upgrade stays staged if the validation fails.
+func stagedUpgrade() *machineapi.UpgradeRequest {
+	b, err := ioutil.ReadFile(constants.StagedUpgradePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("failed to read the staged upgrade: %v", err)
+		}
+
+		return nil
+	}
+
+	req := &machineapi.UpgradeRequest{}
+
+	if err = proto.Unmarshal(b, req); err != nil {
+		log.Printf("discarding invalid staged upgrade: %v", err)
+
+		// nolint: errcheck
+		os.Remove(constants.StagedUpgradePath)
+
+		return nil
+	}
+
+	if err = etcd.ValidateForUpgrade(req.GetForce()); err != nil {
+		log.Printf("staged upgrade to %q is not applied: %v", req.Image, err)
+
+		return nil
+	}
+
+	if err = os.Remove(constants.StagedUpgradePath); err != nil {
+		log.Printf("failed to unstage the upgrade to %q: %v", req.Image, err)
+
+		return nil
+	}
+
+	return req
+}
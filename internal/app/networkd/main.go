@@ -5,8 +5,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/networkd"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
@@ -36,7 +40,11 @@ func main() {
 	// Check to see if a static IP was set via kernel args;
 	// if so, we'll skip the networking configuration via networkd
 	if option := kernel.ProcCmdline().Get("ip").First(); option == nil {
-		configureNetworking(nwd)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		configureNetworking(ctx, nwd)
+
+		go releaseOnShutdown(cancel, nwd)
 	}
 
 	log.Fatalf("%+v", factory.ListenAndServe(
@@ -48,7 +56,21 @@ func main() {
 	)
 }
 
-func configureNetworking(n *networkd.Networkd) {
+// releaseOnShutdown stops the renewal of the leases and gives them back when
+// networkd is stopped.
+func releaseOnShutdown(cancel context.CancelFunc, n *networkd.Networkd) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+
+	<-sigCh
+
+	cancel()
+	n.Release()
+
+	os.Exit(0)
+}
+
+func configureNetworking(ctx context.Context, n *networkd.Networkd) {
 	// Convert links to nic
 	log.Println("discovering local network interfaces")
 
@@ -77,6 +99,8 @@ func configureNetworking(n *networkd.Networkd) {
 		log.Fatal(err)
 	}
 
+	n.SetNetwork(config.Machine().Network())
+
 	// Configure specified interface
 	netIfaces := make([]*nic.NetworkInterface, 0, len(netconf))
 
@@ -105,10 +129,23 @@ func configureNetworking(n *networkd.Networkd) {
 		log.Fatal(err)
 	}
 
+	// Treat as non fatal error when failing to add a rule
+	if err = n.ConfigureRules(config.Machine().Network().Rules()); err != nil {
+		log.Println(err)
+	}
+
+	// Restore the changes applied before a restart of networkd
+	if err = n.Restore(); err != nil {
+		log.Println(err)
+	}
+
 	log.Println("interface configuration")
 	n.PrintState()
 
+	log.Println("starting virtual ip elections")
+	n.RunVIPs(ctx, netIfaces...)
+
 	log.Println("starting renewal watcher")
 	// handle dhcp renewal
-	go n.Renew(netIfaces...)
+	n.Renew(ctx, netIfaces...)
 }
@@ -8,8 +8,6 @@ import (
 	"context"
 	"net"
 	"time"
-
-	"github.com/insomniacslk/dhcp/dhcpv4"
 )
 
 // Addressing provides an interface for abstracting the underlying network
@@ -32,4 +30,18 @@ type Addressing interface {
 }
 
 // Route is a representation of a network route
-type Route = dhcpv4.Route
+type Route struct {
+	Dest   *net.IPNet
+	Router net.IP
+	// Source is the preferred source address of the route, the address of
+	// the addressing method is used unless it is set.
+	Source net.IP
+	Metric uint32
+	MTU    uint32
+	// OnLink makes the router reachable over the link, even if it isn't
+	// within the subnet of the address.
+	OnLink bool
+	// Table is the routing table of the route, the main table is used unless
+	// it is set.
+	Table uint32
+}
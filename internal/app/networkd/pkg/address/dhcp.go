@@ -6,6 +6,7 @@ package address
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
 	"strings"
@@ -19,10 +20,16 @@ import (
 	"github.com/talos-systems/talos/pkg/constants"
 )
 
-// DHCP implements the Addressing interface
+// DHCP implements the Addressing and Lease interfaces
 type DHCP struct {
 	Ack   *dhcpv4.DHCPv4
 	NetIf *net.Interface
+
+	state    LeaseState
+	acquired time.Time
+	// requested is the address of the previous lease, it is asked for again
+	// when a new lease is discovered.
+	requested net.IP
 }
 
 // Name returns back the name of the address method.
@@ -38,11 +45,23 @@ func (d *DHCP) Link() *net.Interface {
 
 // Discover handles the DHCP client exchange stores the DHCP Ack.
 func (d *DHCP) Discover(ctx context.Context) error {
-	// TODO do something with context
-	ack, err := d.discover()
+	if d.Ack != nil {
+		d.requested = d.Ack.YourIPAddr
+	}
+
+	ack, err := d.discover(ctx)
 	d.Ack = ack
 
-	return err
+	if err != nil {
+		d.state = LeaseInit
+
+		return err
+	}
+
+	d.acquired = time.Now()
+	d.state = LeaseBound
+
+	return nil
 }
 
 // Address returns back the IP address from the received DHCP offer.
@@ -104,7 +123,11 @@ func (d *DHCP) Valid() bool {
 //   a Router option, the DHCP client MUST ignore the Router option.
 func (d *DHCP) Routes() (routes []*Route) {
 	if len(d.Ack.ClasslessStaticRoute()) > 0 {
-		return d.Ack.ClasslessStaticRoute()
+		for _, route := range d.Ack.ClasslessStaticRoute() {
+			routes = append(routes, &Route{Router: route.Router, Dest: route.Dest})
+		}
+
+		return routes
 	}
 
 	defRoute := &net.IPNet{
@@ -131,13 +154,141 @@ func (d *DHCP) Hostname() string {
 	return strings.Split(d.Ack.HostName(), ".")[0]
 }
 
-// discover handles the actual DHCP conversation.
-func (d *DHCP) discover() (*dhcpv4.DHCPv4, error) {
+// Info returns a snapshot of the lease.
+func (d *DHCP) Info() LeaseInfo {
+	info := LeaseInfo{State: d.state}
+
+	if d.Ack == nil {
+		return info
+	}
+
+	t1, t2 := d.timers()
+
+	info.Address = d.Address()
+	info.Server = d.server()
+	info.Acquired = d.acquired
+	info.Renew = d.acquired.Add(t1)
+	info.Rebind = d.acquired.Add(t2)
+	info.Expiry = d.acquired.Add(d.TTL())
+
+	return info
+}
+
+// Renew extends the lease with the server which granted it, the request is
+// unicast to the server from the leased address (RENEWING).
+func (d *DHCP) Renew(ctx context.Context) error {
+	d.state = LeaseRenewing
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: d.Ack.YourIPAddr, Port: dhcpv4.ClientPort})
+	if err != nil {
+		return err
+	}
+
+	return d.extend(ctx, conn, &net.UDPAddr{IP: d.server(), Port: dhcpv4.ServerPort})
+}
+
+// Rebind extends the lease with any server, the request is broadcast on the
+// link (REBINDING).
+func (d *DHCP) Rebind(ctx context.Context) error {
+	d.state = LeaseRebinding
+
+	conn, err := nclient4.NewRawUDPConn(d.NetIf.Name, dhcpv4.ClientPort)
+	if err != nil {
+		return err
+	}
+
+	return d.extend(ctx, conn, nclient4.DefaultServers)
+}
+
+// Release gives the lease back to the server which granted it. There is no
+// reply to a release.
+func (d *DHCP) Release() error {
+	if d.Ack == nil {
+		return nil
+	}
+
+	release, err := dhcpv4.New(
+		dhcpv4.WithHwAddr(d.NetIf.HardwareAddr),
+		dhcpv4.WithMessageType(dhcpv4.MessageTypeRelease),
+		dhcpv4.WithClientIP(d.Ack.YourIPAddr),
+		dhcpv4.WithOption(dhcpv4.OptServerIdentifier(d.server())),
+	)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialUDP("udp4",
+		&net.UDPAddr{IP: d.Ack.YourIPAddr, Port: dhcpv4.ClientPort},
+		&net.UDPAddr{IP: d.server(), Port: dhcpv4.ServerPort},
+	)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	if _, err = conn.Write(release.ToBytes()); err != nil {
+		return err
+	}
+
+	d.state = LeaseReleased
+
+	return nil
+}
+
+// server returns the address of the server which granted the lease.
+func (d *DHCP) server() net.IP {
+	if server := d.Ack.ServerIdentifier(); server != nil {
+		return server
+	}
+
+	return d.Ack.ServerIPAddr
+}
+
+// timers returns the renewal (T1) and rebinding (T2) times of the lease. The
+// times from the server are only used if T1 < T2 < lease time, otherwise the
+// defaults of RFC2131 section 4.4.5 are used.
+func (d *DHCP) timers() (t1, t2 time.Duration) {
+	ttl := d.TTL()
+
+	t1 = duration(d.Ack, dhcpv4.OptionRenewTimeValue)
+	t2 = duration(d.Ack, dhcpv4.OptionRebindingTimeValue)
+
+	if t1 <= 0 || t2 <= t1 || t2 >= ttl {
+		t1 = ttl / 2
+		t2 = ttl * 7 / 8
+	}
+
+	return t1, t2
+}
+
+// duration returns the duration of a time option, and 0 if the option is
+// missing.
+func duration(msg *dhcpv4.DHCPv4, code dhcpv4.OptionCode) time.Duration {
+	v := msg.Options.Get(code)
+	if v == nil {
+		return 0
+	}
+
+	var dur dhcpv4.Duration
+
+	if err := dur.FromBytes(v); err != nil {
+		return 0
+	}
+
+	return time.Duration(dur)
+}
+
+// requestedOptions returns the options which are requested from the server.
+func requestedOptions() []dhcpv4.OptionCode {
 	opts := []dhcpv4.OptionCode{
 		dhcpv4.OptionClasslessStaticRoute,
 		dhcpv4.OptionDomainNameServer,
 		dhcpv4.OptionDNSDomainSearchList,
 		dhcpv4.OptionHostName,
+		dhcpv4.OptionRenewTimeValue,
+		dhcpv4.OptionRebindingTimeValue,
 		// TODO: handle these options
 		dhcpv4.OptionNTPServers,
 		dhcpv4.OptionDomainName,
@@ -152,7 +303,12 @@ func (d *DHCP) discover() (*dhcpv4.DHCPv4, error) {
 		}
 	}
 
-	mods := []dhcpv4.Modifier{dhcpv4.WithRequestedOptions(opts...)}
+	return opts
+}
+
+// discover handles the actual DHCP conversation.
+func (d *DHCP) discover(ctx context.Context) (*dhcpv4.DHCPv4, error) {
+	mods := []dhcpv4.Modifier{dhcpv4.WithRequestedOptions(requestedOptions()...)}
 
 	// TODO expose this ( nclient4.WithDebugLogger() ) with some
 	// debug logging option
@@ -166,12 +322,87 @@ func (d *DHCP) discover() (*dhcpv4.DHCPv4, error) {
 	// nolint: errcheck
 	defer cli.Close()
 
-	_, ack, err := cli.Request(context.Background(), mods...)
+	d.state = LeaseSelecting
+
+	// Some servers hand out a new address on every discovery, unless the
+	// client asks for its previous address.
+	discoverMods := mods
+	if d.requested != nil {
+		discoverMods = append([]dhcpv4.Modifier{dhcpv4.WithOption(dhcpv4.OptRequestedIPAddress(d.requested))}, mods...)
+	}
+
+	offer, err := cli.DiscoverOffer(ctx, discoverMods...)
 	if err != nil {
 		// TODO: Make this a well defined error so we can make it not fatal
 		log.Println("failed dhcp request")
 		return nil, err
 	}
 
-	return ack, err
+	d.state = LeaseRequesting
+
+	request, err := dhcpv4.NewRequestFromOffer(offer, mods...)
+	if err != nil {
+		return nil, err
+	}
+
+	ack, err := cli.SendAndRead(ctx, nclient4.DefaultServers, request, nil)
+	if err != nil {
+		log.Println("failed dhcp request")
+		return nil, err
+	}
+
+	if ack.MessageType() != dhcpv4.MessageTypeAck {
+		return nil, fmt.Errorf("unexpected dhcp response %s", ack.MessageType())
+	}
+
+	return ack, nil
+}
+
+// extend sends a request to extend the lease to the server over the
+// connection, the lease is updated with the acknowledgement.
+func (d *DHCP) extend(ctx context.Context, conn net.PacketConn, server *net.UDPAddr) error {
+	cli, err := nclient4.NewWithConn(conn, d.NetIf.HardwareAddr,
+		nclient4.WithTimeout(2*time.Second),
+		nclient4.WithRetry(3),
+	)
+	if err != nil {
+		// nolint: errcheck
+		conn.Close()
+
+		return err
+	}
+	// nolint: errcheck
+	defer cli.Close()
+
+	// RFC2131 section 4.3.2: the client is identified by its address, the
+	// server identifier and requested IP address options are not set.
+	request, err := dhcpv4.New(
+		dhcpv4.WithHwAddr(d.NetIf.HardwareAddr),
+		dhcpv4.WithMessageType(dhcpv4.MessageTypeRequest),
+		dhcpv4.WithClientIP(d.Ack.YourIPAddr),
+		dhcpv4.WithRequestedOptions(requestedOptions()...),
+	)
+	if err != nil {
+		return err
+	}
+
+	ack, err := cli.SendAndRead(ctx, server, request, nil)
+	if err != nil {
+		return err
+	}
+
+	switch ack.MessageType() {
+	case dhcpv4.MessageTypeAck:
+		d.Ack = ack
+		d.acquired = time.Now()
+		d.state = LeaseBound
+
+		return nil
+	case dhcpv4.MessageTypeNak:
+		d.state = LeaseInit
+
+		return ErrNak
+	default:
+		return fmt.Errorf("unexpected dhcp response %s", ack.MessageType())
+	}
 }
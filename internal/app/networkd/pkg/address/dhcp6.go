@@ -0,0 +1,158 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package address
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv6"
+	"github.com/insomniacslk/dhcp/dhcpv6/nclient6"
+	"golang.org/x/sys/unix"
+)
+
+// DHCP6 implements the Addressing interface
+type DHCP6 struct {
+	Reply *dhcpv6.Message
+	NetIf *net.Interface
+}
+
+// Name returns back the name of the address method.
+func (d *DHCP6) Name() string {
+	return "dhcp6"
+}
+
+// Link returns the underlying net.Interface that this address
+// method is configured for
+func (d *DHCP6) Link() *net.Interface {
+	return d.NetIf
+}
+
+// Discover handles the DHCPv6 client exchange and stores the DHCPv6 reply.
+// Since DHCPv6 doesn't provide routes, router advertisements are accepted
+// for the default route.
+func (d *DHCP6) Discover(ctx context.Context) error {
+	if err := acceptRouterAdvertisements(d.NetIf.Name, false); err != nil {
+		return err
+	}
+
+	reply, err := d.discover(ctx)
+	d.Reply = reply
+
+	return err
+}
+
+// Address returns back the IPv6 address from the received DHCPv6 reply.
+func (d *DHCP6) Address() *net.IPNet {
+	return &net.IPNet{
+		IP:   d.iaAddress().IPv6Addr,
+		Mask: d.Mask(),
+	}
+}
+
+// Mask returns the netmask, DHCPv6 assigns single addresses, the prefix is
+// learned from router advertisements.
+func (d *DHCP6) Mask() net.IPMask {
+	return net.CIDRMask(128, 128)
+}
+
+// MTU returs the MTU size of the link.
+func (d *DHCP6) MTU() uint32 {
+	return uint32(d.NetIf.MTU)
+}
+
+// TTL denotes how long the DHCPv6 address is valid for.
+func (d *DHCP6) TTL() time.Duration {
+	if d.Reply == nil || d.iaAddress() == nil {
+		return 0
+	}
+
+	return time.Duration(d.iaAddress().ValidLifetime) * time.Second
+}
+
+// Family qualifies the address as ipv4 or ipv6
+func (d *DHCP6) Family() int {
+	return unix.AF_INET6
+}
+
+// Scope sets the address scope
+func (d *DHCP6) Scope() uint8 {
+	return unix.RT_SCOPE_UNIVERSE
+}
+
+// Valid denotes if this address method should be used.
+func (d *DHCP6) Valid() bool {
+	return d.Reply != nil && d.iaAddress() != nil
+}
+
+// Routes returns no routes, the routes are learned from router
+// advertisements.
+func (d *DHCP6) Routes() (routes []*Route) {
+	return nil
+}
+
+// Resolvers returns the DNS resolvers from the DHCPv6 reply.
+func (d *DHCP6) Resolvers() []net.IP {
+	opt, ok := d.Reply.GetOneOption(dhcpv6.OptionDNSRecursiveNameServer).(*dhcpv6.OptDNSRecursiveNameServer)
+	if !ok {
+		return nil
+	}
+
+	return opt.NameServers
+}
+
+// Hostname returns no hostname, DHCPv6 doesn't provide one.
+func (d *DHCP6) Hostname() string {
+	return ""
+}
+
+// iaAddress returns the address of the IA_NA option of the reply.
+func (d *DHCP6) iaAddress() *dhcpv6.OptIAAddress {
+	iana, ok := d.Reply.GetOneOption(dhcpv6.OptionIANA).(*dhcpv6.OptIANA)
+	if !ok {
+		return nil
+	}
+
+	addr, ok := iana.GetOneOption(dhcpv6.OptionIAAddr).(*dhcpv6.OptIAAddress)
+	if !ok {
+		return nil
+	}
+
+	return addr
+}
+
+// discover handles the actual DHCPv6 conversation.
+func (d *DHCP6) discover(ctx context.Context) (*dhcpv6.Message, error) {
+	cli, err := nclient6.New(d.NetIf.Name,
+		nclient6.WithTimeout(2*time.Second),
+		nclient6.WithRetry(5),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer cli.Close()
+
+	advertise, err := cli.Solicit(ctx)
+	if err != nil {
+		log.Println("failed dhcp6 solicit")
+		return nil, err
+	}
+
+	reply, err := cli.Request(ctx, advertise)
+	if err != nil {
+		log.Println("failed dhcp6 request")
+		return nil, err
+	}
+
+	if reply.MessageType != dhcpv6.MessageTypeReply {
+		return nil, errors.New("unexpected dhcp6 response")
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,91 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package address_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+)
+
+type DHCPSuite struct {
+	suite.Suite
+}
+
+func TestDHCPSuite(t *testing.T) {
+	suite.Run(t, new(DHCPSuite))
+}
+
+func (suite *DHCPSuite) ack(opts ...dhcpv4.Option) *address.DHCP {
+	mods := []dhcpv4.Modifier{
+		dhcpv4.WithMessageType(dhcpv4.MessageTypeAck),
+		dhcpv4.WithYourIP(net.ParseIP("192.168.0.10")),
+		dhcpv4.WithServerIP(net.ParseIP("192.168.0.2")),
+		dhcpv4.WithNetmask(net.CIDRMask(24, 32)),
+		dhcpv4.WithOption(dhcpv4.OptServerIdentifier(net.ParseIP("192.168.0.1"))),
+	}
+
+	for _, opt := range opts {
+		mods = append(mods, dhcpv4.WithOption(opt))
+	}
+
+	ack, err := dhcpv4.New(mods...)
+	suite.Require().NoError(err)
+
+	return &address.DHCP{Ack: ack, NetIf: &net.Interface{Name: "eth0"}}
+}
+
+func (suite *DHCPSuite) TestInfo() {
+	info := suite.ack(
+		dhcpv4.OptIPAddressLeaseTime(time.Hour),
+		dhcpv4.Option{Code: dhcpv4.OptionRenewTimeValue, Value: dhcpv4.Duration(20 * time.Minute)},
+		dhcpv4.Option{Code: dhcpv4.OptionRebindingTimeValue, Value: dhcpv4.Duration(40 * time.Minute)},
+	).Info()
+
+	suite.Assert().Equal("192.168.0.10/24", info.Address.String())
+	suite.Assert().Equal("192.168.0.1", info.Server.String())
+	suite.Assert().Equal(20*time.Minute, info.Renew.Sub(info.Acquired))
+	suite.Assert().Equal(40*time.Minute, info.Rebind.Sub(info.Acquired))
+	suite.Assert().Equal(time.Hour, info.Expiry.Sub(info.Acquired))
+}
+
+func (suite *DHCPSuite) TestInfoDefaultTimers() {
+	info := suite.ack(dhcpv4.OptIPAddressLeaseTime(time.Hour)).Info()
+
+	suite.Assert().Equal(30*time.Minute, info.Renew.Sub(info.Acquired))
+	suite.Assert().Equal(52*time.Minute+30*time.Second, info.Rebind.Sub(info.Acquired))
+}
+
+func (suite *DHCPSuite) TestInfoInvalidTimers() {
+	// T2 after the lease expired
+	info := suite.ack(
+		dhcpv4.OptIPAddressLeaseTime(time.Hour),
+		dhcpv4.Option{Code: dhcpv4.OptionRenewTimeValue, Value: dhcpv4.Duration(20 * time.Minute)},
+		dhcpv4.Option{Code: dhcpv4.OptionRebindingTimeValue, Value: dhcpv4.Duration(2 * time.Hour)},
+	).Info()
+
+	suite.Assert().Equal(30*time.Minute, info.Renew.Sub(info.Acquired))
+	suite.Assert().Equal(52*time.Minute+30*time.Second, info.Rebind.Sub(info.Acquired))
+}
+
+func (suite *DHCPSuite) TestInfoServerAddress() {
+	d := suite.ack(dhcpv4.OptIPAddressLeaseTime(time.Hour))
+	delete(d.Ack.Options, dhcpv4.OptionServerIdentifier.Code())
+
+	suite.Assert().Equal("192.168.0.2", d.Info().Server.String())
+}
+
+func (suite *DHCPSuite) TestInfoNoLease() {
+	info := (&address.DHCP{NetIf: &net.Interface{Name: "eth0"}}).Info()
+
+	suite.Assert().Equal(address.LeaseInit, info.State)
+	suite.Assert().Nil(info.Address)
+	suite.Assert().True(info.Expiry.IsZero())
+}
@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package address
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+// ErrNak is returned when the server refuses to extend a lease, the client
+// has to stop using the address right away.
+var ErrNak = errors.New("lease refused by the server")
+
+// LeaseState is the state of the client side of a lease, see RFC2131
+// section 4.4.
+type LeaseState int
+
+// Lease states, the values match the lease states of the network API.
+const (
+	LeaseInit LeaseState = iota
+	LeaseSelecting
+	LeaseRequesting
+	LeaseBound
+	LeaseRenewing
+	LeaseRebinding
+	LeaseReleased
+)
+
+var leaseStates = map[LeaseState]string{
+	LeaseInit:       "init",
+	LeaseSelecting:  "selecting",
+	LeaseRequesting: "requesting",
+	LeaseBound:      "bound",
+	LeaseRenewing:   "renewing",
+	LeaseRebinding:  "rebinding",
+	LeaseReleased:   "released",
+}
+
+func (s LeaseState) String() string {
+	return leaseStates[s]
+}
+
+// LeaseInfo is a snapshot of a lease.
+type LeaseInfo struct {
+	State   LeaseState
+	Address *net.IPNet
+	Server  net.IP
+	// Acquired is the time the lease was granted or last extended, the
+	// lease timers are relative to it.
+	Acquired time.Time
+	// Renew is the time the lease is renewed with the server which granted
+	// it (T1).
+	Renew time.Time
+	// Rebind is the time the lease is extended with any server (T2).
+	Rebind time.Time
+	// Expiry is the time the address must no longer be used.
+	Expiry time.Time
+}
+
+// Lease is implemented by the addressing methods which lease their address
+// from a server, and have to extend the lease before it expires.
+type Lease interface {
+	Addressing
+
+	// Info returns a snapshot of the lease.
+	Info() LeaseInfo
+	// Renew extends the lease with the server which granted it.
+	Renew(context.Context) error
+	// Rebind extends the lease with any server.
+	Rebind(context.Context) error
+	// Release gives the lease back to the server.
+	Release() error
+}
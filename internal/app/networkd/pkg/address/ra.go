@@ -0,0 +1,186 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package address
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv6"
+
+	"github.com/talos-systems/talos/pkg/sysctl"
+)
+
+// Neighbor discovery option types, see RFC4861 and RFC8106.
+const (
+	ndOptionPrefixInformation = 3
+	ndOptionMTU               = 5
+	ndOptionRDNSS             = 25
+)
+
+// RouterAdvertisement holds the options of a router advertisement which are
+// relevant to the addressing.
+type RouterAdvertisement struct {
+	// Managed is set if addresses are available via DHCPv6.
+	Managed  bool
+	Prefixes []*net.IPNet
+	MTU      uint32
+	DNS      []net.IP
+}
+
+// acceptRouterAdvertisements makes the kernel accept router advertisements on
+// the link, even with forwarding enabled. The kernel autoconfigures an address
+// from the advertised prefixes if autoconf is set.
+func acceptRouterAdvertisements(name string, autoconf bool) error {
+	props := []*sysctl.SystemProperty{
+		{Key: fmt.Sprintf("net.ipv6.conf.%s.accept_ra", name), Value: "2"},
+	}
+
+	if autoconf {
+		props = append(props, &sysctl.SystemProperty{Key: fmt.Sprintf("net.ipv6.conf.%s.autoconf", name), Value: "1"})
+	}
+
+	for _, prop := range props {
+		if err := sysctl.WriteSystemProperty(prop); err != nil {
+			return fmt.Errorf("failed to set %s: %w", prop.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// solicitRouterAdvertisement sends router solicitations on the link until a
+// router advertisement is received.
+func solicitRouterAdvertisement(ctx context.Context, link *net.Interface, timeout time.Duration, retries int) (*RouterAdvertisement, error) {
+	conn, err := icmp.ListenPacket("ip6:ipv6-icmp", "::")
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	pc := conn.IPv6PacketConn()
+
+	// RFC4861 requires a hop limit of 255 for neighbor discovery messages.
+	if err = pc.SetMulticastHopLimit(255); err != nil {
+		return nil, err
+	}
+
+	if err = pc.SetMulticastInterface(link); err != nil {
+		return nil, err
+	}
+
+	var filter ipv6.ICMPFilter
+
+	filter.SetAll(true)
+	filter.Accept(ipv6.ICMPTypeRouterAdvertisement)
+
+	if err = pc.SetICMPFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	rs, err := (&icmp.Message{Type: ipv6.ICMPTypeRouterSolicitation, Body: &icmp.RawBody{Data: make([]byte, 4)}}).Marshal(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := &net.IPAddr{IP: net.IPv6linklocalallrouters, Zone: link.Name}
+	b := make([]byte, 1500)
+
+	for i := 0; i < retries; i++ {
+		if _, err = conn.WriteTo(rs, dst); err != nil {
+			return nil, err
+		}
+
+		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+
+		for {
+			var (
+				n    int
+				peer net.Addr
+			)
+
+			n, peer, err = conn.ReadFrom(b)
+			if err != nil {
+				break
+			}
+
+			if addr, ok := peer.(*net.IPAddr); !ok || addr.Zone != link.Name {
+				continue
+			}
+
+			var ra *RouterAdvertisement
+
+			if ra, err = ParseRouterAdvertisement(b[:n]); err == nil {
+				return ra, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+	}
+
+	return nil, fmt.Errorf("no router advertisement received on %s", link.Name)
+}
+
+// ParseRouterAdvertisement parses an ICMPv6 router advertisement message.
+func ParseRouterAdvertisement(b []byte) (*RouterAdvertisement, error) {
+	// type, code, checksum, hop limit, flags, router lifetime, reachable
+	// time and retransmission timer
+	if len(b) < 16 || ipv6.ICMPType(b[0]) != ipv6.ICMPTypeRouterAdvertisement {
+		return nil, errors.New("not a router advertisement")
+	}
+
+	ra := &RouterAdvertisement{Managed: b[5]&0x80 != 0}
+
+	for options := b[16:]; len(options) > 0; {
+		if len(options) < 2 || options[1] == 0 || len(options) < int(options[1])*8 {
+			return nil, errors.New("malformed router advertisement option")
+		}
+
+		option := options[:int(options[1])*8]
+		options = options[len(option):]
+
+		switch option[0] {
+		case ndOptionPrefixInformation:
+			if len(option) != 32 {
+				return nil, errors.New("malformed prefix information option")
+			}
+
+			// only the prefixes usable for autonomous address configuration
+			if option[3]&0x40 == 0 {
+				continue
+			}
+
+			ra.Prefixes = append(ra.Prefixes, &net.IPNet{
+				IP:   net.IP(append([]byte(nil), option[16:32]...)),
+				Mask: net.CIDRMask(int(option[2]), 128),
+			})
+		case ndOptionMTU:
+			if len(option) != 8 {
+				return nil, errors.New("malformed mtu option")
+			}
+
+			ra.MTU = binary.BigEndian.Uint32(option[4:8])
+		case ndOptionRDNSS:
+			for servers := option[8:]; len(servers) >= net.IPv6len; servers = servers[net.IPv6len:] {
+				ra.DNS = append(ra.DNS, net.IP(append([]byte(nil), servers[:net.IPv6len]...)))
+			}
+		}
+	}
+
+	return ra, nil
+}
@@ -0,0 +1,72 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package address_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+)
+
+type RASuite struct {
+	suite.Suite
+}
+
+func TestRASuite(t *testing.T) {
+	suite.Run(t, new(RASuite))
+}
+
+func (suite *RASuite) TestParseRouterAdvertisement() {
+	b := []byte{
+		// type, code, checksum
+		134, 0, 0, 0,
+		// hop limit, flags (managed), router lifetime
+		64, 0x80, 0x07, 0x08,
+		// reachable time, retransmission timer
+		0, 0, 0, 0, 0, 0, 0, 0,
+		// prefix information, on-link and autonomous
+		3, 4, 64, 0xc0, 0, 0, 0x0e, 0x10, 0, 0, 0x0e, 0x10, 0, 0, 0, 0,
+		0x20, 0x01, 0x0d, 0xb8, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		// prefix information, on-link only
+		3, 4, 64, 0x80, 0, 0, 0x0e, 0x10, 0, 0, 0x0e, 0x10, 0, 0, 0, 0,
+		0x20, 0x01, 0x0d, 0xb8, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		// mtu
+		5, 1, 0, 0, 0, 0, 0x05, 0xdc,
+		// rdnss
+		25, 3, 0, 0, 0, 0, 0x0e, 0x10,
+		0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x53,
+	}
+
+	ra, err := address.ParseRouterAdvertisement(b)
+	suite.Require().NoError(err)
+
+	suite.Assert().True(ra.Managed)
+	suite.Require().Len(ra.Prefixes, 1)
+	suite.Assert().Equal("2001:db8:1::/64", ra.Prefixes[0].String())
+	suite.Assert().Equal(uint32(1500), ra.MTU)
+	suite.Assert().Equal([]net.IP{net.ParseIP("2001:db8::53")}, ra.DNS)
+}
+
+func (suite *RASuite) TestParseRouterAdvertisementMalformed() {
+	header := []byte{134, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	_, err := address.ParseRouterAdvertisement(header[:8])
+	suite.Assert().Error(err)
+
+	// router solicitation
+	_, err = address.ParseRouterAdvertisement(append([]byte{133}, header[1:]...))
+	suite.Assert().Error(err)
+
+	// zero length option
+	_, err = address.ParseRouterAdvertisement(append(header, 5, 0, 0, 0, 0, 0, 0, 0))
+	suite.Assert().Error(err)
+
+	// truncated option
+	_, err = address.ParseRouterAdvertisement(append(header, 25, 3, 0, 0, 0, 0, 0, 0))
+	suite.Assert().Error(err)
+}
@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package address
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// SLAAC implements the Addressing interface. The kernel configures the
+// address from the router advertisements, SLAAC waits for it.
+type SLAAC struct {
+	RA    *RouterAdvertisement
+	Addr  *net.IPNet
+	NetIf *net.Interface
+}
+
+// Name returns back the name of the address method.
+func (s *SLAAC) Name() string {
+	return "slaac"
+}
+
+// Link returns the underlying net.Interface that this address
+// method is configured for
+func (s *SLAAC) Link() *net.Interface {
+	return s.NetIf
+}
+
+// Discover solicits a router advertisement, and waits for the kernel to
+// configure an address from one of the advertised prefixes.
+func (s *SLAAC) Discover(ctx context.Context) (err error) {
+	if err = acceptRouterAdvertisements(s.NetIf.Name, true); err != nil {
+		return err
+	}
+
+	if s.RA, err = solicitRouterAdvertisement(ctx, s.NetIf, 4*time.Second, 3); err != nil {
+		return err
+	}
+
+	if len(s.RA.Prefixes) == 0 {
+		return fmt.Errorf("no prefix to autoconfigure advertised on %s", s.NetIf.Name)
+	}
+
+	for i := 0; i < 20; i++ {
+		if s.Addr = s.autoconfigured(); s.Addr != nil {
+			return nil
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return fmt.Errorf("no address autoconfigured on %s", s.NetIf.Name)
+}
+
+// autoconfigured returns the address of the link within the advertised
+// prefixes.
+func (s *SLAAC) autoconfigured() *net.IPNet {
+	addrs, err := s.NetIf.Addrs()
+	if err != nil {
+		return nil
+	}
+
+	for _, addr := range addrs {
+		ipnet, ok := addr.(*net.IPNet)
+		if !ok || ipnet.IP.To4() != nil {
+			continue
+		}
+
+		for _, prefix := range s.RA.Prefixes {
+			if prefix.Contains(ipnet.IP) {
+				return ipnet
+			}
+		}
+	}
+
+	return nil
+}
+
+// Address returns back the autoconfigured IPv6 address.
+func (s *SLAAC) Address() *net.IPNet {
+	return s.Addr
+}
+
+// Mask returns the netmask of the autoconfigured address.
+func (s *SLAAC) Mask() net.IPMask {
+	return s.Addr.Mask
+}
+
+// MTU returs the MTU size from the router advertisement.
+func (s *SLAAC) MTU() uint32 {
+	if s.RA != nil && s.RA.MTU != 0 {
+		return s.RA.MTU
+	}
+
+	return uint32(s.NetIf.MTU)
+}
+
+// TTL returns no TTL, the kernel renews the address from the router
+// advertisements.
+func (s *SLAAC) TTL() time.Duration {
+	return 0
+}
+
+// Family qualifies the address as ipv4 or ipv6
+func (s *SLAAC) Family() int {
+	return unix.AF_INET6
+}
+
+// Scope sets the address scope
+func (s *SLAAC) Scope() uint8 {
+	return unix.RT_SCOPE_UNIVERSE
+}
+
+// Valid denotes if this address method should be used.
+func (s *SLAAC) Valid() bool {
+	return s.Addr != nil
+}
+
+// Routes returns no routes, the kernel adds the routes from the router
+// advertisements.
+func (s *SLAAC) Routes() (routes []*Route) {
+	return nil
+}
+
+// Resolvers returns the DNS resolvers from the RDNSS option of the router
+// advertisement.
+func (s *SLAAC) Resolvers() []net.IP {
+	if s.RA == nil {
+		return nil
+	}
+
+	return s.RA.DNS
+}
+
+// Hostname returns no hostname, router advertisements don't provide one.
+func (s *SLAAC) Hostname() string {
+	return ""
+}
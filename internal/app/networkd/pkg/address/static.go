@@ -85,7 +85,15 @@ func (s *Static) Routes() (routes []*Route) {
 		// nolint: errcheck
 		_, ipnet, _ := net.ParseCIDR(route.Network)
 
-		routes = append(routes, &Route{Dest: ipnet, Router: net.ParseIP(route.Gateway)})
+		routes = append(routes, &Route{
+			Dest:   ipnet,
+			Router: net.ParseIP(route.Gateway),
+			Source: net.ParseIP(route.Source),
+			Metric: route.Metric,
+			MTU:    route.MTU,
+			OnLink: route.OnLink,
+			Table:  route.Table,
+		})
 	}
 
 	return routes
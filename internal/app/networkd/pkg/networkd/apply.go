@@ -0,0 +1,904 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"reflect"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
+)
+
+// ip6DefaultMetric is the metric the kernel sets on IPv6 routes without a
+// metric.
+const ip6DefaultMetric = 1024
+
+// pendingApply is a change of the network configuration which waits for
+// confirmation.
+type pendingApply struct {
+	prev  machine.Network
+	timer *time.Timer
+}
+
+// applyState is the saved state of the applied changes, it survives a
+// restart of networkd.
+type applyState struct {
+	Network *v1alpha1.NetworkConfig `yaml:"network"`
+	// Prev is set while the change waits for confirmation.
+	Prev *v1alpha1.NetworkConfig `yaml:"prev,omitempty"`
+}
+
+// change is a single change of the links.
+type change struct {
+	description string
+	apply       func() error
+}
+
+// linkState is the state of a link, as read from netlink.
+type linkState struct {
+	link      *net.Interface
+	addresses []*net.IPNet
+	routes    []*address.Route
+}
+
+// SetNetwork records the network configuration which the links were
+// configured with, the changes applied later on are computed against it.
+func (n *Networkd) SetNetwork(network machine.Network) {
+	n.applyMu.Lock()
+	defer n.applyMu.Unlock()
+
+	n.network = network
+
+	n.mu.Lock()
+	n.cacheDNS = network.DNSCache().Enabled
+	n.mu.Unlock()
+}
+
+// Apply reconfigures the links with the network configuration, and returns
+// the changes. The changes are rolled back unless they are confirmed within
+// the timeout. Only the network configuration of the running machine is
+// changed, the machine configuration is applied again on reboot.
+func (n *Networkd) Apply(network machine.Network, timeout time.Duration) ([]string, error) {
+	n.applyMu.Lock()
+	defer n.applyMu.Unlock()
+
+	if n.network == nil {
+		return nil, errors.New("the network is not configured by networkd")
+	}
+
+	if n.pending != nil {
+		return nil, errors.New("a network change is waiting for confirmation")
+	}
+
+	if err := validateNetwork(network); err != nil {
+		return nil, err
+	}
+
+	changes, err := n.apply(n.network, network)
+	if err != nil {
+		if _, rollbackErr := n.apply(network, n.network); rollbackErr != nil {
+			log.Printf("failed to roll back the network changes: %v", rollbackErr)
+		}
+
+		return nil, err
+	}
+
+	p := &pendingApply{prev: n.network}
+	p.timer = time.AfterFunc(timeout, func() { n.revert(p) })
+
+	n.network = network
+	n.pending = p
+
+	if err = n.saveState(); err != nil {
+		log.Printf("failed to save the network changes: %v", err)
+	}
+
+	return changes, nil
+}
+
+// Confirm confirms the pending change of the network configuration, it is no
+// longer rolled back.
+func (n *Networkd) Confirm() error {
+	n.applyMu.Lock()
+	defer n.applyMu.Unlock()
+
+	if n.pending == nil {
+		return errors.New("no network change is waiting for confirmation")
+	}
+
+	n.pending.timer.Stop()
+	n.pending = nil
+
+	log.Println("network changes confirmed")
+
+	return n.saveState()
+}
+
+// Restore reconfigures the links with the changes applied before networkd
+// was restarted. The confirmation window doesn't survive the restart, so a
+// change which wasn't confirmed yet is rolled back.
+func (n *Networkd) Restore() error {
+	n.applyMu.Lock()
+	defer n.applyMu.Unlock()
+
+	if n.network == nil {
+		return nil
+	}
+
+	state, err := n.loadState()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if _, err = n.apply(n.network, state.Network); err != nil {
+		return fmt.Errorf("failed to restore the network changes: %w", err)
+	}
+
+	n.network = state.Network
+
+	if state.Prev != nil {
+		log.Println("rolling back the network changes, they were not confirmed before networkd restarted")
+
+		if _, err = n.apply(state.Network, state.Prev); err != nil {
+			return fmt.Errorf("failed to roll back the network changes: %w", err)
+		}
+
+		n.network = state.Prev
+	}
+
+	return n.saveState()
+}
+
+// revert rolls back the change of the network configuration, unless it was
+// confirmed meanwhile.
+func (n *Networkd) revert(p *pendingApply) {
+	n.applyMu.Lock()
+	defer n.applyMu.Unlock()
+
+	if n.pending != p {
+		return
+	}
+
+	log.Println("rolling back the network changes, they were not confirmed in time")
+
+	if _, err := n.apply(n.network, p.prev); err != nil {
+		log.Printf("failed to roll back the network changes: %v", err)
+	}
+
+	n.network = p.prev
+	n.pending = nil
+
+	if err := n.saveState(); err != nil {
+		log.Printf("failed to save the network changes: %v", err)
+	}
+}
+
+// saveState saves the network configuration of the links, and the one to
+// roll back to while a change waits for confirmation.
+func (n *Networkd) saveState() error {
+	if n.statePath == "" {
+		return nil
+	}
+
+	var (
+		state applyState
+		ok    bool
+	)
+
+	if state.Network, ok = n.network.(*v1alpha1.NetworkConfig); !ok {
+		return fmt.Errorf("unsupported network configuration %T", n.network)
+	}
+
+	if n.pending != nil {
+		if state.Prev, ok = n.pending.prev.(*v1alpha1.NetworkConfig); !ok {
+			return fmt.Errorf("unsupported network configuration %T", n.pending.prev)
+		}
+	}
+
+	b, err := yaml.Marshal(&state)
+	if err != nil {
+		return err
+	}
+
+	tmp := n.statePath + ".tmp"
+
+	if err = ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, n.statePath)
+}
+
+// loadState reads the state saved by saveState.
+func (n *Networkd) loadState() (*applyState, error) {
+	b, err := ioutil.ReadFile(n.statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &applyState{}
+
+	if err = yaml.Unmarshal(b, state); err != nil {
+		return nil, err
+	}
+
+	if state.Network == nil {
+		return nil, fmt.Errorf("no network configuration in %s", n.statePath)
+	}
+
+	return state, nil
+}
+
+// apply changes the links from the managed to the desired network
+// configuration.
+func (n *Networkd) apply(managed, desired machine.Network) ([]string, error) {
+	m, err := n.interfaces(managed)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := n.interfaces(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := n.linkStates(m, d)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := n.plan(m, d, states)
+	if err != nil {
+		return nil, err
+	}
+
+	changes = append(changes, n.planRules(managed.Rules(), desired.Rules())...)
+
+	applied := make([]string, 0, len(changes))
+
+	for _, c := range changes {
+		log.Printf("network change: %s", c.description)
+
+		if err = c.apply(); err != nil {
+			return applied, fmt.Errorf("failed to %s: %w", c.description, err)
+		}
+
+		applied = append(applied, c.description)
+	}
+
+	return applied, nil
+}
+
+// interfaces returns the interfaces which the network configuration
+// configures, by name.
+func (n *Networkd) interfaces(network machine.Network) (map[string]*nic.NetworkInterface, error) {
+	netconf, err := n.Discover()
+	if err != nil {
+		return nil, err
+	}
+
+	return configuredInterfaces(netconf, network)
+}
+
+// configuredInterfaces creates the interfaces of the links which the network
+// configuration configures.
+func configuredInterfaces(netconf NetConf, network machine.Network) (map[string]*nic.NetworkInterface, error) {
+	if err := netconf.buildOptions(network); err != nil {
+		return nil, err
+	}
+
+	configured := map[string]bool{}
+
+	for _, device := range network.Devices() {
+		configured[device.Interface] = true
+
+		for _, vlan := range device.Vlans {
+			configured[fmt.Sprintf("%s.%d", device.Interface, vlan.ID)] = true
+		}
+	}
+
+	ifaces := map[string]*nic.NetworkInterface{}
+
+	for link, opts := range netconf {
+		if !configured[link.Name] {
+			continue
+		}
+
+		iface, err := nic.Create(link, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		ifaces[link.Name] = iface
+	}
+
+	return ifaces, nil
+}
+
+// linkStates reads the addresses and the routes of the existing links of the
+// interfaces.
+func (n *Networkd) linkStates(ifaces ...map[string]*nic.NetworkInterface) (map[string]*linkState, error) {
+	routes, err := n.NlConn.Route.List()
+	if err != nil {
+		return nil, err
+	}
+
+	states := map[string]*linkState{}
+
+	for _, m := range ifaces {
+		for name := range m {
+			if _, ok := states[name]; ok {
+				continue
+			}
+
+			link, err := net.InterfaceByName(name)
+			if err != nil {
+				// the link doesn't exist yet
+				continue
+			}
+
+			addrs, err := n.Conn.Addrs(link, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			state := &linkState{link: link, addresses: addrs}
+
+			for _, r := range routes {
+				table := r.Attributes.Table
+				if table == 0 {
+					table = uint32(r.Table)
+				}
+
+				if r.Attributes.OutIface != uint32(link.Index) || table == unix.RT_TABLE_LOCAL {
+					continue
+				}
+
+				// the main table is the default, and so is the metric the
+				// kernel sets on IPv6 routes
+				if table == unix.RT_TABLE_MAIN {
+					table = 0
+				}
+
+				metric := r.Attributes.Priority
+				if r.Family == unix.AF_INET6 && metric == ip6DefaultMetric {
+					metric = 0
+				}
+
+				dst := r.Attributes.Dst
+				if dst == nil {
+					dst = net.IPv6zero
+				}
+
+				if r.Family == unix.AF_INET {
+					dst = dst.To4()
+				}
+
+				state.routes = append(state.routes, &address.Route{
+					Dest:   &net.IPNet{IP: dst, Mask: net.CIDRMask(int(r.DstLength), len(dst)*8)},
+					Router: r.Attributes.Gateway,
+					Metric: metric,
+					Table:  table,
+				})
+			}
+
+			states[name] = state
+		}
+	}
+
+	return states, nil
+}
+
+// plan computes the changes from the managed to the desired interfaces. The
+// dynamic addressing methods are left alone, an interface can't be switched
+// between static and dynamic addressing without a reboot.
+func (n *Networkd) plan(managed, desired map[string]*nic.NetworkInterface, states map[string]*linkState) ([]change, error) {
+	names := []string{}
+
+	for name := range managed {
+		names = append(names, name)
+	}
+
+	for name := range desired {
+		if _, ok := managed[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	recreate := map[string]bool{}
+
+	for _, name := range names {
+		m, d := managed[name], desired[name]
+
+		if m != nil && d != nil && m.Type != d.Type {
+			return nil, fmt.Errorf("changing the type of %s requires a reboot", name)
+		}
+
+		if !vipOf(m).Equal(vipOf(d)) {
+			return nil, fmt.Errorf("changing the virtual ip of %s requires a reboot", name)
+		}
+
+		if dynamic(m, d) != dynamic(d, m) {
+			return nil, fmt.Errorf("switching %s between static and dynamic addressing requires a reboot", name)
+		}
+
+		if m != nil && d != nil && d.Type != nic.Single && !sameLink(m, d) {
+			recreate[name] = true
+		}
+	}
+
+	// the VLANs go away with their parent
+	for _, name := range names {
+		if d := desired[name]; d != nil && d.Type == nic.Vlan && recreate[d.Parent] {
+			recreate[name] = true
+		}
+	}
+
+	changes := n.planLinks(names, managed, desired, states, recreate)
+
+	for _, name := range names {
+		m, d := managed[name], desired[name]
+		if d == nil || d.Type != nic.Wireguard || (states[name] != nil && m != nil && sameWireguard(m, d)) {
+			continue
+		}
+
+		changes = append(changes, change{
+			description: fmt.Sprintf("configure wireguard %s", name),
+			apply:       func() error { return n.configureWireguard(d) },
+		})
+	}
+
+	for _, name := range names {
+		m, d := managed[name], desired[name]
+
+		// the addressing goes away with the removed links
+		if d == nil && m.Type != nic.Single {
+			continue
+		}
+
+		state := states[name]
+		if recreate[name] {
+			state = nil
+		}
+
+		changes = append(changes, n.planAddressing(name, m, d, state)...)
+	}
+
+	return changes, nil
+}
+
+// planLinks removes the bonds, the VLANs and the Wireguard interfaces which
+// are no longer configured, or have to be recreated, and creates the new ones.
+// VLANs are removed before and created after the bonds, since VLANs might be
+// created on top of bonds.
+func (n *Networkd) planLinks(names []string, managed, desired map[string]*nic.NetworkInterface, states map[string]*linkState, recreate map[string]bool) []change {
+	changes := []change{}
+
+	for _, t := range []int{nic.Vlan, nic.Bond, nic.Wireguard} {
+		for _, name := range names {
+			m, d := managed[name], desired[name]
+			if m == nil || m.Type != t || states[name] == nil || (d != nil && !recreate[name]) {
+				continue
+			}
+
+			name := name
+
+			changes = append(changes, change{
+				description: fmt.Sprintf("remove %s %s", linkType(t), name),
+				apply:       func() error { return n.deleteLink(name) },
+			})
+		}
+	}
+
+	for _, t := range []int{nic.Bond, nic.Vlan, nic.Wireguard} {
+		for _, name := range names {
+			d := desired[name]
+			if d == nil || d.Type != t || (states[name] != nil && !recreate[name]) {
+				continue
+			}
+
+			changes = append(changes, change{
+				description: fmt.Sprintf("create %s %s", linkType(t), name),
+				apply:       func() error { return n.createLink(d) },
+			})
+		}
+	}
+
+	return changes
+}
+
+// planAddressing changes the MTU, the static addresses and the static routes
+// of the link from the managed to the desired interface.
+// nolint: gocyclo
+func (n *Networkd) planAddressing(name string, m, d *nic.NetworkInterface, state *linkState) []change {
+	changes := []change{}
+
+	add := func(description string, apply func(link *net.Interface) error) {
+		changes = append(changes, change{
+			description: description,
+			apply: func() error {
+				link, err := net.InterfaceByName(name)
+				if err != nil {
+					return err
+				}
+
+				return apply(link)
+			},
+		})
+	}
+
+	if d != nil && !d.IsIgnored() && (state == nil || state.link.Flags&net.FlagUp == 0) {
+		add(fmt.Sprintf("bring up %s", name), n.Conn.LinkUp)
+	}
+
+	// the MTU falls back to the default once it is no longer configured
+	mtu := staticMTU(d)
+	if mtu == 0 && staticMTU(m) != 0 {
+		mtu = 1500
+
+		if m.Type == nic.Wireguard {
+			mtu = nic.WireguardMTU
+		}
+	}
+
+	if mtu != 0 && (state == nil || state.link.MTU != mtu) {
+		add(fmt.Sprintf("set mtu of %s to %d", name, mtu), func(link *net.Interface) error {
+			return n.setMTU(link.Index, uint32(mtu))
+		})
+	}
+
+	current, wanted := staticMethods(m), staticMethods(d)
+
+	// the routes which changed are removed, and added again
+	removed := []*address.Route{}
+
+	// the routes are removed first, since the removal of their source address
+	// removes them as well
+	if state != nil {
+		for _, method := range current {
+			for _, r := range method.Routes() {
+				r := r
+				if containsEqualRoute(routesOf(wanted), r) || !containsRoute(state.routes, r) {
+					continue
+				}
+
+				removed = append(removed, r)
+
+				add(fmt.Sprintf("remove route %s from %s", routeString(r), name), func(link *net.Interface) error {
+					return n.deleteRoute(link, r)
+				})
+			}
+		}
+
+		for _, method := range current {
+			addr := method.Address()
+			if containsAddress(addressesOf(wanted), addr) || !containsAddress(state.addresses, addr) {
+				continue
+			}
+
+			add(fmt.Sprintf("remove address %s from %s", addr, name), func(link *net.Interface) error {
+				return n.Conn.AddrDel(link, addr)
+			})
+		}
+	}
+
+	for _, method := range wanted {
+		addr := method.Address()
+		if state != nil && containsAddress(state.addresses, addr) {
+			continue
+		}
+
+		add(fmt.Sprintf("add address %s to %s", addr, name), func(link *net.Interface) error {
+			return ignoreExists(n.Conn.AddrAdd(link, addr))
+		})
+	}
+
+	for _, method := range wanted {
+		src := routeSource(method)
+
+		for _, r := range method.Routes() {
+			r := r
+			if state != nil && containsRoute(state.routes, r) && !containsRoute(removed, r) {
+				continue
+			}
+
+			add(fmt.Sprintf("add route %s to %s", routeString(r), name), func(link *net.Interface) error {
+				return ignoreExists(n.addRoute(link, r, src))
+			})
+		}
+	}
+
+	return changes
+}
+
+// validateNetwork checks the devices and the VLANs of the network
+// configuration.
+func validateNetwork(network machine.Network) error {
+	var result *multierror.Error
+
+	validate := func(device *machine.Device) {
+		checks := []v1alpha1.NetworkDeviceCheck{v1alpha1.CheckDeviceInterface(), v1alpha1.CheckDeviceRoutes(), v1alpha1.CheckDeviceWireguard(), v1alpha1.CheckDeviceVIP()}
+
+		// devices without addressing are addressed by DHCP
+		if device.CIDR != "" {
+			checks = append(checks, v1alpha1.CheckDeviceAddressing())
+		}
+
+		result = multierror.Append(result, v1alpha1.Validate(device, checks...))
+	}
+
+	for _, device := range network.Devices() {
+		device := device
+		validate(&device)
+
+		if device.Ignore {
+			continue
+		}
+
+		for _, vlan := range device.Vlans {
+			validate(vlanDevice(fmt.Sprintf("%s.%d", device.Interface, vlan.ID), vlan))
+		}
+	}
+
+	for _, rule := range network.Rules() {
+		rule := rule
+
+		if _, err := ruleMessages(&rule); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// planRules removes the rules which are no longer configured, and adds the
+// new ones.
+func (n *Networkd) planRules(managed, desired []machine.Rule) []change {
+	changes := []change{}
+
+	for _, rule := range managed {
+		rule := rule
+		if containsRule(desired, rule) {
+			continue
+		}
+
+		changes = append(changes, change{
+			description: fmt.Sprintf("remove rule %s", ruleString(&rule)),
+			apply:       func() error { return n.deleteRule(&rule) },
+		})
+	}
+
+	for _, rule := range desired {
+		rule := rule
+		if containsRule(managed, rule) {
+			continue
+		}
+
+		changes = append(changes, change{
+			description: fmt.Sprintf("add rule %s", ruleString(&rule)),
+			apply:       func() error { return ignoreExists(n.addRule(&rule)) },
+		})
+	}
+
+	return changes
+}
+
+// dynamic returns whether the interface is addressed by DHCP or SLAAC. An
+// interface which is no longer configured is addressed by DHCP, unless it is
+// a bond, a VLAN or a Wireguard interface, which is removed.
+func dynamic(iface, other *nic.NetworkInterface) bool {
+	if iface == nil {
+		return other.Type == nic.Single
+	}
+
+	if iface.IsIgnored() {
+		return false
+	}
+
+	for _, method := range iface.AddressMethod {
+		if _, ok := method.(*address.Static); !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameOption returns whether the bond options are both unset, or set to the
+// same value.
+func sameOption(a, b *uint8) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// sameLink returns whether the bond or VLAN links of the interfaces are the
+// same, the link has to be recreated otherwise.
+func sameLink(a, b *nic.NetworkInterface) bool {
+	if a.Type != b.Type || a.Parent != b.Parent || a.VlanID != b.VlanID {
+		return false
+	}
+
+	if a.BondMode != b.BondMode || !sameOption(a.BondHashPolicy, b.BondHashPolicy) || !sameOption(a.BondLACPRate, b.BondLACPRate) {
+		return false
+	}
+
+	if len(a.SubInterfaces) != len(b.SubInterfaces) {
+		return false
+	}
+
+	for i := range a.SubInterfaces {
+		if a.SubInterfaces[i] != b.SubInterfaces[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameWireguard returns whether the keys and the peers of the Wireguard
+// interfaces are the same, the interface has to be configured otherwise.
+func sameWireguard(a, b *nic.NetworkInterface) bool {
+	return bytes.Equal(a.WireguardPrivateKey, b.WireguardPrivateKey) &&
+		a.WireguardListenPort == b.WireguardListenPort &&
+		reflect.DeepEqual(a.WireguardPeers, b.WireguardPeers)
+}
+
+// vipOf returns the virtual IP of the interface, nil if the interface has no
+// virtual IP or isn't configured.
+func vipOf(iface *nic.NetworkInterface) net.IP {
+	if iface == nil {
+		return nil
+	}
+
+	return iface.VIP
+}
+
+// staticMethods returns the static addressing methods of the interface.
+func staticMethods(iface *nic.NetworkInterface) []*address.Static {
+	if iface == nil || iface.IsIgnored() {
+		return nil
+	}
+
+	methods := []*address.Static{}
+
+	for _, method := range iface.AddressMethod {
+		if static, ok := method.(*address.Static); ok {
+			methods = append(methods, static)
+		}
+	}
+
+	return methods
+}
+
+// staticMTU returns the configured MTU of the interface, zero if it isn't
+// configured.
+func staticMTU(iface *nic.NetworkInterface) int {
+	for _, method := range staticMethods(iface) {
+		if method.Device.MTU != 0 {
+			return method.Device.MTU
+		}
+	}
+
+	return 0
+}
+
+func addressesOf(methods []*address.Static) []*net.IPNet {
+	addrs := make([]*net.IPNet, 0, len(methods))
+
+	for _, method := range methods {
+		addrs = append(addrs, method.Address())
+	}
+
+	return addrs
+}
+
+func routesOf(methods []*address.Static) []*address.Route {
+	routes := []*address.Route{}
+
+	for _, method := range methods {
+		routes = append(routes, method.Routes()...)
+	}
+
+	return routes
+}
+
+// containsEqualRoute returns whether the routes contain the route with the
+// same attributes.
+func containsEqualRoute(routes []*address.Route, route *address.Route) bool {
+	for _, r := range routes {
+		if containsRoute([]*address.Route{r}, route) && r.Source.Equal(route.Source) && r.MTU == route.MTU && r.OnLink == route.OnLink {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsRule(rules []machine.Rule, rule machine.Rule) bool {
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// routeString formats the route like ip route does.
+func routeString(r *address.Route) string {
+	s := r.Dest.String()
+
+	if r.Router != nil {
+		s += fmt.Sprintf(" via %s", r.Router)
+	}
+
+	if r.Metric != 0 {
+		s += fmt.Sprintf(" metric %d", r.Metric)
+	}
+
+	if r.Table != 0 {
+		s += fmt.Sprintf(" table %d", r.Table)
+	}
+
+	return s
+}
+
+func containsAddress(addrs []*net.IPNet, addr *net.IPNet) bool {
+	for _, a := range addrs {
+		if a.String() == addr.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func linkType(t int) string {
+	switch t {
+	case nic.Bond:
+		return "bond"
+	case nic.Wireguard:
+		return "wireguard"
+	default:
+		return "vlan"
+	}
+}
+
+// ignoreExists ignores the netlink errors of addresses and routes which
+// already exist.
+func ignoreExists(err error) error {
+	var opErr *netlink.OpError
+	if errors.As(err, &opErr) && (os.IsExist(opErr.Err) || opErr.Err == syscall.ESRCH) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,365 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
+)
+
+type ApplySuite struct {
+	suite.Suite
+}
+
+func TestApplySuite(t *testing.T) {
+	suite.Run(t, new(ApplySuite))
+}
+
+func (suite *ApplySuite) TestPlanStatic() {
+	managed := suite.interfaces([]machine.Device{
+		{
+			Interface: "eth0",
+			CIDR:      "192.168.0.10/24",
+			MTU:       9000,
+			Routes:    []machine.Route{{Network: "10.0.0.0/8", Gateway: "192.168.0.1"}},
+		},
+	})
+
+	desired := suite.interfaces([]machine.Device{
+		{
+			Interface: "eth0",
+			CIDR:      "192.168.0.20/24",
+			Routes:    []machine.Route{{Network: "10.0.0.0/8", Gateway: "192.168.0.254"}},
+		},
+	})
+
+	states := map[string]*linkState{
+		"eth0": {
+			link:      &net.Interface{Index: 1, Name: "eth0", MTU: 9000, Flags: net.FlagUp},
+			addresses: []*net.IPNet{suite.cidr("192.168.0.10/24")},
+			routes:    []*address.Route{{Dest: suite.network("10.0.0.0/8"), Router: net.ParseIP("192.168.0.1")}},
+		},
+	}
+
+	changes, err := (&Networkd{}).plan(managed, desired, states)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal([]string{
+		"set mtu of eth0 to 1500",
+		"remove route 10.0.0.0/8 via 192.168.0.1 from eth0",
+		"remove address 192.168.0.10/24 from eth0",
+		"add address 192.168.0.20/24 to eth0",
+		"add route 10.0.0.0/8 via 192.168.0.254 to eth0",
+	}, descriptions(changes))
+}
+
+func (suite *ApplySuite) TestPlanUnchanged() {
+	devices := []machine.Device{
+		{
+			Interface: "eth0",
+			CIDR:      "192.168.0.10/24",
+			Routes:    []machine.Route{{Network: "0.0.0.0/0", Gateway: "192.168.0.1"}},
+		},
+	}
+
+	states := map[string]*linkState{
+		"eth0": {
+			link:      &net.Interface{Index: 1, Name: "eth0", MTU: 1500, Flags: net.FlagUp},
+			addresses: []*net.IPNet{suite.cidr("192.168.0.10/24")},
+			routes:    []*address.Route{{Dest: suite.network("0.0.0.0/0"), Router: net.ParseIP("192.168.0.1")}},
+		},
+	}
+
+	changes, err := (&Networkd{}).plan(suite.interfaces(devices), suite.interfaces(devices), states)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(changes)
+}
+
+func (suite *ApplySuite) TestPlanRouteAttributes() {
+	route := machine.Route{Network: "10.0.0.0/8", Gateway: "192.168.0.1", Table: 100}
+
+	managed := machine.Device{Interface: "eth0", CIDR: "192.168.0.10/24", Routes: []machine.Route{route}}
+
+	desired := managed
+	route.MTU = 9000
+	desired.Routes = []machine.Route{route}
+
+	states := map[string]*linkState{
+		"eth0": {
+			link:      &net.Interface{Index: 1, Name: "eth0", MTU: 1500, Flags: net.FlagUp},
+			addresses: []*net.IPNet{suite.cidr("192.168.0.10/24")},
+			routes:    []*address.Route{{Dest: suite.network("10.0.0.0/8"), Router: net.ParseIP("192.168.0.1"), Table: 100}},
+		},
+	}
+
+	changes, err := (&Networkd{}).plan(suite.interfaces([]machine.Device{managed}), suite.interfaces([]machine.Device{desired}), states)
+	suite.Require().NoError(err)
+
+	// the route is replaced to change the MTU
+	suite.Assert().Equal([]string{
+		"remove route 10.0.0.0/8 via 192.168.0.1 table 100 from eth0",
+		"add route 10.0.0.0/8 via 192.168.0.1 table 100 to eth0",
+	}, descriptions(changes))
+}
+
+func (suite *ApplySuite) TestPlanRules() {
+	managed := []machine.Rule{{From: "10.5.0.0/24", Table: 100}, {FwMark: 1, Table: 200}}
+	desired := []machine.Rule{{From: "10.5.0.0/24", Table: 100}, {From: "10.6.0.0/24", Table: 100, Priority: 1000}}
+
+	suite.Assert().Equal([]string{
+		"remove rule fwmark 0x1 lookup 200",
+		"add rule priority 1000 from 10.6.0.0/24 lookup 100",
+	}, descriptions((&Networkd{}).planRules(managed, desired)))
+}
+
+func (suite *ApplySuite) TestPlanVlan() {
+	eth0 := machine.Device{Interface: "eth0", CIDR: "192.168.0.10/24"}
+
+	managed := eth0
+	managed.Vlans = []*machine.Vlan{{ID: 100, CIDR: "10.0.100.10/24"}}
+
+	desired := eth0
+	desired.Vlans = []*machine.Vlan{{ID: 200, CIDR: "10.0.200.10/24"}}
+
+	states := map[string]*linkState{
+		"eth0": {
+			link:      &net.Interface{Index: 1, Name: "eth0", MTU: 1500, Flags: net.FlagUp},
+			addresses: []*net.IPNet{suite.cidr("192.168.0.10/24")},
+		},
+		"eth0.100": {
+			link:      &net.Interface{Index: 2, Name: "eth0.100", MTU: 1500, Flags: net.FlagUp},
+			addresses: []*net.IPNet{suite.cidr("10.0.100.10/24")},
+		},
+	}
+
+	changes, err := (&Networkd{}).plan(suite.interfaces([]machine.Device{managed}), suite.interfaces([]machine.Device{desired}), states)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal([]string{
+		"remove vlan eth0.100",
+		"create vlan eth0.200",
+		"bring up eth0.200",
+		"add address 10.0.200.10/24 to eth0.200",
+	}, descriptions(changes))
+}
+
+func (suite *ApplySuite) TestPlanBond() {
+	bond0 := machine.Device{
+		Interface: "bond0",
+		CIDR:      "192.168.0.10/24",
+		Bond:      &machine.Bond{Mode: "active-backup", Interfaces: []string{"eth0", "eth1"}},
+	}
+
+	managed := bond0
+
+	desired := bond0
+	desired.Bond = &machine.Bond{Mode: "802.3ad", Interfaces: []string{"eth0", "eth1"}}
+
+	states := map[string]*linkState{
+		"bond0": {
+			link:      &net.Interface{Index: 3, Name: "bond0", MTU: 1500, Flags: net.FlagUp},
+			addresses: []*net.IPNet{suite.cidr("192.168.0.10/24")},
+		},
+	}
+
+	changes, err := (&Networkd{}).plan(suite.interfaces([]machine.Device{managed}), suite.interfaces([]machine.Device{desired}), states)
+	suite.Require().NoError(err)
+
+	// the bond is recreated with the new mode
+	suite.Assert().Equal([]string{
+		"remove bond bond0",
+		"create bond bond0",
+		"bring up bond0",
+		"add address 192.168.0.10/24 to bond0",
+	}, descriptions(changes))
+}
+
+func (suite *ApplySuite) TestPlanWireguard() {
+	wg0 := machine.Device{
+		Interface: "wg0",
+		CIDR:      "10.10.0.2/24",
+		Routes:    []machine.Route{{Network: "10.96.0.0/12", Gateway: "10.10.0.1"}},
+		Wireguard: &machine.Wireguard{
+			PrivateKey: "EG6iSp2vW6r/ZgzUSEJjOMbFJtEl2RSWxkVwhUr8kHY=",
+			Peers: []machine.WireguardPeer{
+				{PublicKey: "ABCDEF+Gh1234abcdEFGH/ijkl5678MNOP90qrstuvw=", AllowedIPs: []string{"10.10.0.0/24"}},
+			},
+		},
+	}
+
+	changes, err := (&Networkd{}).plan(suite.interfaces(nil), suite.interfaces([]machine.Device{wg0}), nil)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal([]string{
+		"create wireguard wg0",
+		"configure wireguard wg0",
+		"bring up wg0",
+		"add address 10.10.0.2/24 to wg0",
+		"add route 10.96.0.0/12 via 10.10.0.1 to wg0",
+	}, descriptions(changes))
+
+	states := map[string]*linkState{
+		"wg0": {
+			link:      &net.Interface{Index: 4, Name: "wg0", MTU: 1420, Flags: net.FlagUp},
+			addresses: []*net.IPNet{suite.cidr("10.10.0.2/24")},
+			routes:    []*address.Route{{Dest: suite.network("10.96.0.0/12"), Router: net.ParseIP("10.10.0.1")}},
+		},
+	}
+
+	changes, err = (&Networkd{}).plan(suite.interfaces([]machine.Device{wg0}), suite.interfaces([]machine.Device{wg0}), states)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(changes)
+
+	// the peers are reconfigured in place
+	desired := wg0
+	desired.Wireguard = &machine.Wireguard{PrivateKey: wg0.Wireguard.PrivateKey}
+
+	changes, err = (&Networkd{}).plan(suite.interfaces([]machine.Device{wg0}), suite.interfaces([]machine.Device{desired}), states)
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"configure wireguard wg0"}, descriptions(changes))
+
+	changes, err = (&Networkd{}).plan(suite.interfaces([]machine.Device{wg0}), suite.interfaces(nil), states)
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"remove wireguard wg0"}, descriptions(changes))
+}
+
+func (suite *ApplySuite) TestPlanDynamic() {
+	static := suite.interfaces([]machine.Device{{Interface: "eth0", CIDR: "192.168.0.10/24"}})
+
+	_, err := (&Networkd{}).plan(static, suite.interfaces([]machine.Device{{Interface: "eth0", DHCP: true}}), nil)
+	suite.Assert().Error(err)
+
+	// eth0 is addressed by DHCP once it is no longer configured
+	_, err = (&Networkd{}).plan(static, suite.interfaces(nil), nil)
+	suite.Assert().Error(err)
+
+	vlan := suite.interfaces([]machine.Device{{Interface: "eth0", CIDR: "192.168.0.10/24", Vlans: []*machine.Vlan{{ID: 100, DHCP: true}}}})
+
+	_, err = (&Networkd{}).plan(static, vlan, nil)
+	suite.Assert().Error(err)
+}
+
+func (suite *ApplySuite) TestPlanVIP() {
+	devices := []machine.Device{{Interface: "eth0", CIDR: "192.168.0.10/24", VIP: &machine.VIP{IP: "192.168.0.15"}}}
+
+	_, err := (&Networkd{}).plan(suite.interfaces(devices), suite.interfaces(devices), nil)
+	suite.Assert().NoError(err)
+
+	_, err = (&Networkd{}).plan(suite.interfaces(devices), suite.interfaces([]machine.Device{{Interface: "eth0", CIDR: "192.168.0.10/24"}}), nil)
+	suite.Assert().Error(err)
+}
+
+func (suite *ApplySuite) TestValidateNetwork() {
+	suite.Assert().NoError(validateNetwork(&v1alpha1.NetworkConfig{
+		NetworkInterfaces: []machine.Device{
+			{Interface: "eth0", Vlans: []*machine.Vlan{{ID: 100, CIDR: "10.0.100.10/24"}}},
+		},
+	}))
+
+	suite.Assert().Error(validateNetwork(&v1alpha1.NetworkConfig{
+		NetworkInterfaces: []machine.Device{
+			{Interface: "eth0", CIDR: "192.168.0.300/24"},
+		},
+	}))
+
+	suite.Assert().Error(validateNetwork(&v1alpha1.NetworkConfig{
+		NetworkInterfaces: []machine.Device{
+			{Interface: "eth0", Vlans: []*machine.Vlan{{ID: 100, CIDR: "10.0.100.10/24", Routes: []machine.Route{{Network: "10.0.0.0/8"}}}}},
+		},
+	}))
+
+	suite.Assert().Error(validateNetwork(&v1alpha1.NetworkConfig{
+		NetworkInterfaces: []machine.Device{
+			{Interface: "eth0", CIDR: "192.168.0.10/24", VIP: &machine.VIP{IP: "192.168.0.300"}},
+		},
+	}))
+}
+
+func (suite *ApplySuite) TestSaveState() {
+	dir, err := ioutil.TempDir("", "networkd")
+	suite.Require().NoError(err)
+
+	defer os.RemoveAll(dir) //nolint: errcheck
+
+	prev := &v1alpha1.NetworkConfig{
+		NetworkInterfaces: []machine.Device{{Interface: "eth0", DHCP: true}},
+	}
+	network := &v1alpha1.NetworkConfig{
+		NetworkInterfaces: []machine.Device{{Interface: "eth0", CIDR: "192.168.0.10/24"}},
+	}
+
+	n := &Networkd{
+		network:   network,
+		pending:   &pendingApply{prev: prev},
+		statePath: filepath.Join(dir, "apply.yaml"),
+	}
+
+	suite.Require().NoError(n.saveState())
+
+	state, err := n.loadState()
+	suite.Require().NoError(err)
+	suite.Assert().Equal("192.168.0.10/24", state.Network.NetworkInterfaces[0].CIDR)
+	suite.Require().NotNil(state.Prev)
+	suite.Assert().True(state.Prev.NetworkInterfaces[0].DHCP)
+
+	n.pending = nil
+
+	suite.Require().NoError(n.saveState())
+
+	state, err = n.loadState()
+	suite.Require().NoError(err)
+	suite.Assert().Equal("192.168.0.10/24", state.Network.NetworkInterfaces[0].CIDR)
+	suite.Assert().Nil(state.Prev)
+}
+
+func (suite *ApplySuite) interfaces(devices []machine.Device) map[string]*nic.NetworkInterface {
+	netconf := NetConf{}
+
+	for i, name := range []string{"eth0", "eth1"} {
+		link := &net.Interface{Index: i + 1, Name: name, MTU: 1500}
+		netconf[link] = parseLinkMessage(link)
+	}
+
+	ifaces, err := configuredInterfaces(netconf, &v1alpha1.NetworkConfig{NetworkInterfaces: devices})
+	suite.Require().NoError(err)
+
+	return ifaces
+}
+
+func (suite *ApplySuite) cidr(s string) *net.IPNet {
+	ip, ipnet, err := net.ParseCIDR(s)
+	suite.Require().NoError(err)
+
+	ipnet.IP = ip
+
+	return ipnet
+}
+
+func (suite *ApplySuite) network(s string) *net.IPNet {
+	_, ipnet, err := net.ParseCIDR(s)
+	suite.Require().NoError(err)
+
+	return ipnet
+}
+
+func descriptions(changes []change) []string {
+	d := make([]string, 0, len(changes))
+
+	for _, c := range changes {
+		d = append(d, c.description)
+	}
+
+	return d
+}
@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mdlayher/ethernet"
+	"github.com/mdlayher/raw"
+)
+
+const (
+	arpHardwareEthernet = 1
+	arpOpRequest        = 1
+	arpPacketLen        = 28
+
+	// garpCount is the number of gratuitous ARP requests sent, in case some
+	// of them are lost.
+	garpCount = 3
+
+	// garpInterval is the time between the gratuitous ARP requests.
+	garpInterval = 500 * time.Millisecond
+)
+
+// sendGratuitousARP announces that the IPv4 address moved to the link, so that
+// the neighbors update their ARP caches.
+func sendGratuitousARP(link *net.Interface, ip net.IP) error {
+	// links without a hardware address, e.g. Wireguard, don't use ARP
+	if len(link.HardwareAddr) == 0 {
+		return nil
+	}
+
+	frame, err := gratuitousARP(link.HardwareAddr, ip)
+	if err != nil {
+		return err
+	}
+
+	conn, err := raw.ListenPacket(link, uint16(ethernet.EtherTypeARP), nil)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	for i := 0; i < garpCount; i++ {
+		if i > 0 {
+			time.Sleep(garpInterval)
+		}
+
+		if _, err = conn.WriteTo(frame, &raw.Addr{HardwareAddr: ethernet.Broadcast}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gratuitousARP returns the Ethernet frame of a gratuitous ARP request, a
+// broadcast request for the address with the address as the sender.
+func gratuitousARP(hw net.HardwareAddr, ip net.IP) ([]byte, error) {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return nil, fmt.Errorf("%s is not an IPv4 address", ip)
+	}
+
+	if len(hw) != 6 {
+		return nil, fmt.Errorf("%s is not an Ethernet address", hw)
+	}
+
+	packet := make([]byte, arpPacketLen)
+
+	binary.BigEndian.PutUint16(packet[0:2], arpHardwareEthernet)
+	binary.BigEndian.PutUint16(packet[2:4], uint16(ethernet.EtherTypeIPv4))
+	packet[4] = byte(len(hw))
+	packet[5] = net.IPv4len
+	binary.BigEndian.PutUint16(packet[6:8], arpOpRequest)
+	copy(packet[8:14], hw)
+	copy(packet[14:18], ip4)
+	// the target hardware address is unknown
+	copy(packet[24:28], ip4)
+
+	f := &ethernet.Frame{
+		Destination: ethernet.Broadcast,
+		Source:      hw,
+		EtherType:   ethernet.EtherTypeARP,
+		Payload:     packet,
+	}
+
+	return f.MarshalBinary()
+}
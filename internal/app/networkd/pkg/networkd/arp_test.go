@@ -0,0 +1,49 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"net"
+	"testing"
+
+	"github.com/mdlayher/ethernet"
+	"github.com/stretchr/testify/suite"
+)
+
+type ARPSuite struct {
+	suite.Suite
+}
+
+func TestARPSuite(t *testing.T) {
+	suite.Run(t, new(ARPSuite))
+}
+
+func (suite *ARPSuite) TestGratuitousARP() {
+	hw := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+
+	b, err := gratuitousARP(hw, net.ParseIP("192.168.0.15"))
+	suite.Require().NoError(err)
+
+	var f ethernet.Frame
+	suite.Require().NoError(f.UnmarshalBinary(b))
+
+	suite.Assert().Equal(ethernet.Broadcast, f.Destination)
+	suite.Assert().Equal(hw, f.Source)
+	suite.Assert().Equal(ethernet.EtherTypeARP, f.EtherType)
+
+	suite.Assert().Equal([]byte{
+		0x00, 0x01, // Ethernet
+		0x08, 0x00, // IPv4
+		0x06, 0x04, // address lengths
+		0x00, 0x01, // request
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x01, // sender hardware address
+		192, 168, 0, 15, // sender address
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // target hardware address
+		192, 168, 0, 15, // target address
+	}, f.Payload[:arpPacketLen])
+
+	_, err = gratuitousARP(hw, net.ParseIP("2001:db8::15"))
+	suite.Assert().Error(err)
+}
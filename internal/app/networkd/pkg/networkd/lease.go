@@ -0,0 +1,389 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+// maxLeaseEvents is the number of lease events which are kept.
+const maxLeaseEvents = 64
+
+var errNoReply = errors.New("no reply from the server")
+
+// Lease is the lease of an interface.
+type Lease struct {
+	Interface string
+	address.LeaseInfo
+}
+
+// LeaseEvent records a change of a lease.
+type LeaseEvent struct {
+	Time  time.Time
+	Lease Lease
+	// Error is the error which caused the change, if any.
+	Error string
+}
+
+// addressingState is the configuration of an addressing method at one point
+// in time, it is compared to the configuration after the addressing changed.
+type addressingState struct {
+	address   *net.IPNet
+	routes    []*address.Route
+	resolvers []net.IP
+}
+
+func newAddressingState(method address.Addressing) *addressingState {
+	if !method.Valid() {
+		return &addressingState{}
+	}
+
+	return &addressingState{
+		address:   method.Address(),
+		routes:    method.Routes(),
+		resolvers: method.Resolvers(),
+	}
+}
+
+// renewLease runs the client side of the lease state machine of RFC2131
+// section 4.4.5. The lease is renewed with the server which granted it from
+// T1 on, and extended with any server from T2 on. Once the lease expired, or
+// the server refused to extend it, the address is given up and a new lease is
+// discovered.
+func (n *Networkd) renewLease(ctx context.Context, lease address.Lease) {
+	for ctx.Err() == nil {
+		if lease.Info().State != address.LeaseBound {
+			n.rediscover(ctx, lease)
+			continue
+		}
+
+		prev := newAddressingState(lease)
+
+		err := n.extendLease(ctx, lease)
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err == nil:
+			if err = n.reconcile(prev, lease); err != nil {
+				log.Printf("failed to reconfigure %s: %v", lease.Link().Name, err)
+			}
+		default:
+			log.Printf("lost the lease of %s: %v", lease.Link().Name, err)
+
+			n.deconfigure(prev, lease)
+
+			info := lease.Info()
+			info.State = address.LeaseInit
+
+			n.recordEvent(Lease{Interface: lease.Link().Name, LeaseInfo: info}, err)
+		}
+	}
+}
+
+// extendLease renews the lease until T2, and then rebinds it until it
+// expires.
+func (n *Networkd) extendLease(ctx context.Context, lease address.Lease) error {
+	info := lease.Info()
+
+	err := n.retryLease(ctx, lease, lease.Renew, info.Renew, info.Rebind)
+	if err == nil || errors.Is(err, address.ErrNak) || ctx.Err() != nil {
+		return err
+	}
+
+	return n.retryLease(ctx, lease, lease.Rebind, info.Rebind, info.Expiry)
+}
+
+// retryLease waits until the start, and then tries to extend the lease until
+// the deadline. RFC2131 section 4.4.5 recommends to wait for half of the
+// remaining time between the attempts, down to a minimum of 60 seconds.
+func (n *Networkd) retryLease(ctx context.Context, lease address.Lease, extend func(context.Context) error, start, deadline time.Time) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(start)):
+	}
+
+	for time.Now().Before(deadline) {
+		attemptCtx, cancel := context.WithDeadline(ctx, deadline)
+		err := extend(attemptCtx)
+
+		cancel()
+
+		n.recordLease(lease, err)
+
+		if err == nil || errors.Is(err, address.ErrNak) {
+			return err
+		}
+
+		log.Printf("failed to extend the lease of %s: %v", lease.Link().Name, err)
+
+		wait := time.Until(deadline) / 2
+		if wait < time.Minute {
+			wait = time.Minute
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	return errNoReply
+}
+
+// rediscover discovers a new lease, until one is granted.
+func (n *Networkd) rediscover(ctx context.Context, lease address.Lease) {
+	for backoff := 10 * time.Second; ; backoff *= 2 {
+		err := n.configureInterface(ctx, lease)
+
+		n.recordLease(lease, err)
+
+		if err == nil {
+			n.setResolvers(lease, lease.Resolvers())
+
+			if err = n.updateResolvConf(); err != nil {
+				log.Printf("failed to write resolv.conf: %v", err)
+			}
+
+			return
+		}
+
+		if backoff > 5*time.Minute {
+			backoff = 5 * time.Minute
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// reconcile removes the address and the routes which are no longer part of
+// the addressing, and configures the current addressing.
+func (n *Networkd) reconcile(prev *addressingState, method address.Addressing) error {
+	current := newAddressingState(method)
+
+	addressChanged := prev.address.String() != current.address.String()
+
+	// The routes are set with the address as source, they are all replaced if
+	// the address changed.
+	var stale []*address.Route
+
+	for _, r := range prev.routes {
+		if addressChanged || !containsRoute(current.routes, r) {
+			stale = append(stale, r)
+		}
+	}
+
+	n.removeAddressing(method.Link(), prev.address, addressChanged, stale)
+
+	if err := n.setAddressing(method); err != nil {
+		return err
+	}
+
+	if equalIPs(prev.resolvers, current.resolvers) {
+		return nil
+	}
+
+	n.setResolvers(method, current.resolvers)
+
+	return n.updateResolvConf()
+}
+
+// deconfigure removes the address, the routes and the resolvers of the
+// addressing.
+func (n *Networkd) deconfigure(prev *addressingState, method address.Addressing) {
+	n.removeAddressing(method.Link(), prev.address, prev.address != nil, prev.routes)
+
+	n.setResolvers(method, nil)
+
+	if err := n.updateResolvConf(); err != nil {
+		log.Printf("failed to write resolv.conf: %v", err)
+	}
+}
+
+func (n *Networkd) removeAddressing(link *net.Interface, addr *net.IPNet, removeAddress bool, routes []*address.Route) {
+	for _, r := range routes {
+		if err := n.deleteRoute(link, r); err != nil {
+			log.Printf("failed to remove route %s for %s: %v", r.Dest, link.Name, err)
+		}
+	}
+
+	if !removeAddress {
+		return
+	}
+
+	if err := n.Conn.AddrDel(link, addr); err != nil {
+		log.Printf("failed to remove address %s from %s: %v", addr, link.Name, err)
+	}
+}
+
+// Release gives back the leases, and removes the leased addresses. The
+// virtual IPs are given up, and the renewals stop, once their context is
+// canceled.
+func (n *Networkd) Release() {
+	n.vips.Wait()
+	n.renewals.Wait()
+
+	n.mu.Lock()
+	methods := append([]address.Addressing(nil), n.methods...)
+	n.mu.Unlock()
+
+	for _, method := range methods {
+		lease, ok := method.(address.Lease)
+		if !ok || !lease.Valid() {
+			continue
+		}
+
+		switch lease.Info().State {
+		case address.LeaseBound, address.LeaseRenewing, address.LeaseRebinding:
+		default:
+			continue
+		}
+
+		prev := newAddressingState(lease)
+
+		log.Printf("releasing the lease of %s", lease.Link().Name)
+
+		err := lease.Release()
+
+		n.recordLease(lease, err)
+
+		if err != nil {
+			log.Printf("failed to release the lease of %s: %v", lease.Link().Name, err)
+			continue
+		}
+
+		n.deconfigure(prev, lease)
+	}
+}
+
+// Leases returns the current leases, and the recent lease events.
+func (n *Networkd) Leases() ([]Lease, []LeaseEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	leases := make([]Lease, 0, len(n.leases))
+
+	for _, lease := range n.leases {
+		leases = append(leases, lease)
+	}
+
+	sort.Slice(leases, func(i, j int) bool { return leases[i].Interface < leases[j].Interface })
+
+	return leases, append([]LeaseEvent(nil), n.events...)
+}
+
+// recordLease records the lease, and the change of the lease.
+func (n *Networkd) recordLease(lease address.Lease, err error) {
+	n.recordEvent(Lease{Interface: lease.Link().Name, LeaseInfo: lease.Info()}, err)
+}
+
+// recordEvent records the lease, an event is added if the lease changed or
+// on errors.
+func (n *Networkd) recordEvent(lease Lease, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	prev, ok := n.leases[lease.Interface]
+	n.leases[lease.Interface] = lease
+
+	if ok && err == nil && prev.State == lease.State && prev.Acquired.Equal(lease.Acquired) {
+		return
+	}
+
+	event := LeaseEvent{Time: time.Now(), Lease: lease}
+
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	log.Printf("lease of %s is %s", lease.Interface, lease.State)
+
+	n.events = append(n.events, event)
+
+	if len(n.events) > maxLeaseEvents {
+		n.events = n.events[len(n.events)-maxLeaseEvents:]
+	}
+}
+
+// setResolvers records the resolvers of the addressing method.
+func (n *Networkd) setResolvers(method address.Addressing, resolvers []net.IP) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if resolvers == nil {
+		delete(n.resolvers, method)
+		return
+	}
+
+	n.resolvers[method] = resolvers
+}
+
+// updateResolvConf writes out the resolvers of all the addressing methods.
+// The host resolves names with the DNS cache if it is enabled, which forwards
+// the queries to the resolvers.
+func (n *Networkd) updateResolvConf() error {
+	var resolvers []net.IP
+
+	n.mu.Lock()
+
+	for _, method := range n.methods {
+		resolvers = append(resolvers, n.resolvers[method]...)
+	}
+
+	cacheDNS := n.cacheDNS
+
+	n.mu.Unlock()
+
+	if !cacheDNS {
+		return writeResolvConf("/etc/resolv.conf", resolvers)
+	}
+
+	if err := writeResolvConf(constants.DNSUpstreamResolvConfPath, resolvers); err != nil {
+		return err
+	}
+
+	return writeResolvConf("/etc/resolv.conf", []net.IP{net.ParseIP(constants.DNSCacheAddress)})
+}
+
+// containsRoute returns whether the routes contain a route to the same
+// destination, via the same router, with the same metric and in the same
+// table.
+func containsRoute(routes []*address.Route, route *address.Route) bool {
+	for _, r := range routes {
+		if r.Dest.String() == route.Dest.String() && r.Router.Equal(route.Router) && r.Metric == route.Metric && r.Table == route.Table {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equalIPs(a, b []net.IP) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+)
+
+type LeaseSuite struct {
+	suite.Suite
+}
+
+func TestLeaseSuite(t *testing.T) {
+	suite.Run(t, new(LeaseSuite))
+}
+
+func (suite *LeaseSuite) TestRecordEvent() {
+	n := &Networkd{leases: map[string]Lease{}}
+
+	acquired := time.Now()
+	bound := Lease{Interface: "eth0", LeaseInfo: address.LeaseInfo{State: address.LeaseBound, Acquired: acquired}}
+
+	n.recordEvent(bound, nil)
+	// unchanged
+	n.recordEvent(bound, nil)
+
+	renewing := bound
+	renewing.State = address.LeaseRenewing
+
+	n.recordEvent(renewing, nil)
+	// failed attempts are always recorded
+	n.recordEvent(renewing, errors.New("no reply"))
+	n.recordEvent(renewing, errors.New("no reply"))
+
+	renewed := bound
+	renewed.Acquired = acquired.Add(30 * time.Minute)
+
+	n.recordEvent(renewed, nil)
+	n.recordEvent(Lease{Interface: "eth1", LeaseInfo: address.LeaseInfo{State: address.LeaseSelecting}}, nil)
+
+	leases, events := n.Leases()
+
+	suite.Require().Len(leases, 2)
+	suite.Assert().Equal("eth0", leases[0].Interface)
+	suite.Assert().Equal(address.LeaseBound, leases[0].State)
+	suite.Assert().Equal("eth1", leases[1].Interface)
+
+	suite.Require().Len(events, 6)
+	suite.Assert().Equal(address.LeaseRenewing, events[2].Lease.State)
+	suite.Assert().Equal("no reply", events[3].Error)
+	suite.Assert().Equal(address.LeaseBound, events[4].Lease.State)
+}
+
+func (suite *LeaseSuite) TestRecordEventLimit() {
+	n := &Networkd{leases: map[string]Lease{}}
+
+	for i := 0; i < 2*maxLeaseEvents; i++ {
+		n.recordEvent(Lease{Interface: "eth0"}, errors.New("failed"))
+	}
+
+	_, events := n.Leases()
+
+	suite.Assert().Len(events, maxLeaseEvents)
+}
+
+func (suite *LeaseSuite) TestContainsRoute() {
+	_, defaultRoute, err := net.ParseCIDR("0.0.0.0/0")
+	suite.Require().NoError(err)
+
+	routes := []*address.Route{{Dest: defaultRoute, Router: net.ParseIP("192.168.0.1")}}
+
+	suite.Assert().True(containsRoute(routes, &address.Route{Dest: defaultRoute, Router: net.ParseIP("192.168.0.1")}))
+	suite.Assert().False(containsRoute(routes, &address.Route{Dest: defaultRoute, Router: net.ParseIP("192.168.0.254")}))
+}
@@ -27,6 +27,10 @@ func filterInterfaceByName(links []*net.Interface) (filteredLinks []*net.Interfa
 			filteredLinks = append(filteredLinks, link)
 		case strings.HasPrefix(link.Name, "lo"):
 			filteredLinks = append(filteredLinks, link)
+		case strings.HasPrefix(link.Name, "bond"):
+			filteredLinks = append(filteredLinks, link)
+		case strings.HasPrefix(link.Name, "wg"):
+			filteredLinks = append(filteredLinks, link)
 		}
 	}
 
@@ -59,8 +63,9 @@ func parseLinkMessage(link *net.Interface) []nic.Option {
 	return opts
 }
 
-// writeResolvConf generates a /etc/resolv.conf with the specified nameservers.
-func writeResolvConf(resolvers []net.IP) error {
+// writeResolvConf generates a resolv.conf at the path with the specified
+// nameservers.
+func writeResolvConf(path string, resolvers []net.IP) error {
 	if len(resolvers) == 0 {
 		log.Printf("no DNS servers defined, using defaults %s and %s\n", DefaultPrimaryResolver, DefaultSecondaryResolver)
 		resolvers = []net.IP{net.ParseIP(DefaultPrimaryResolver), net.ParseIP(DefaultSecondaryResolver)}
@@ -85,5 +90,5 @@ func writeResolvConf(resolvers []net.IP) error {
 
 	log.Println("writing resolvconf")
 
-	return ioutil.WriteFile("/etc/resolv.conf", []byte(resolvconf.String()), 0644)
+	return ioutil.WriteFile(path, []byte(resolvconf.String()), 0644)
 }
@@ -5,11 +5,14 @@
 package networkd
 
 import (
+	"fmt"
 	"net"
+	"time"
 
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/config/machine"
 )
 
 // NetConf provides a mapping between an interface link and the functional
@@ -18,8 +21,17 @@ type NetConf map[*net.Interface][]nic.Option
 
 // BuildOptions translates the supplied config to functional options.
 func (n *NetConf) BuildOptions(config runtime.Configurator) error {
+	return n.buildOptions(config.Machine().Network())
+}
+
+// buildOptions translates the network configuration to functional options.
+func (n *NetConf) buildOptions(network machine.Network) error {
+	n.addBonds(network.Devices())
+	n.addWireguards(network.Devices())
+	n.addVlans(network.Devices(), network.Resolvers())
+
 	for link, opts := range *n {
-		for _, device := range config.Machine().Network().Devices() {
+		for _, device := range network.Devices() {
 			device := device
 			if link.Name != device.Interface {
 				continue
@@ -30,18 +42,165 @@ func (n *NetConf) BuildOptions(config runtime.Configurator) error {
 				continue
 			}
 
+			if device.Bond != nil {
+				opts = append(opts, bondOptions(device.Bond)...)
+			}
+
+			if device.Wireguard != nil {
+				opts = append(opts, wireguardOptions(device.Wireguard)...)
+			}
+
+			if device.VIP != nil {
+				opts = append(opts, nic.WithVIP(device.VIP.IP))
+			}
+
 			// Configure Addressing
-			if device.DHCP {
-				d := &address.DHCP{NetIf: link}
-				(*n)[link] = append(opts, nic.WithAddressing(d))
+			(*n)[link] = append(opts, addressing(&device, link, network.Resolvers())...)
+		}
+	}
+
+	return nil
+}
+
+// addBonds adds the bonds, which don't exist until networkd creates them, and
+// leaves the members of the bonds alone, since the addressing is configured
+// on the bond.
+func (n *NetConf) addBonds(devices []machine.Device) {
+	for _, device := range devices {
+		if device.Bond == nil || device.Ignore {
+			continue
+		}
+
+		if n.link(device.Interface) == nil {
+			link := &net.Interface{Name: device.Interface, MTU: 1500}
+			(*n)[link] = parseLinkMessage(link)
+		}
+
+		for _, member := range device.Bond.Interfaces {
+			if link := n.link(member); link != nil {
+				(*n)[link] = append((*n)[link], nic.WithIgnore())
 			}
+		}
+	}
+}
 
-			if device.CIDR != "" {
-				s := &address.Static{Device: &device, NetIf: link, NameServers: config.Machine().Network().Resolvers()}
-				(*n)[link] = append(opts, nic.WithAddressing(s))
+// addWireguards adds the Wireguard interfaces, which don't exist until
+// networkd creates them.
+func (n *NetConf) addWireguards(devices []machine.Device) {
+	for _, device := range devices {
+		if device.Wireguard == nil || device.Ignore {
+			continue
+		}
+
+		if n.link(device.Interface) == nil {
+			link := &net.Interface{Name: device.Interface, MTU: nic.WireguardMTU}
+			(*n)[link] = parseLinkMessage(link)
+		}
+	}
+}
+
+// addVlans adds the VLANs, which don't exist until networkd creates them.
+func (n *NetConf) addVlans(devices []machine.Device, resolvers []string) {
+	for _, device := range devices {
+		if device.Ignore {
+			continue
+		}
+
+		for _, vlan := range device.Vlans {
+			name := fmt.Sprintf("%s.%d", device.Interface, vlan.ID)
+
+			link := n.link(name)
+			if link == nil {
+				link = &net.Interface{Name: name, MTU: 1500}
 			}
+
+			opts := append(parseLinkMessage(link), nic.WithType(nic.Vlan), nic.WithParent(device.Interface), nic.WithVlanID(vlan.ID))
+
+			(*n)[link] = append(opts, addressing(vlanDevice(name, vlan), link, resolvers)...)
+		}
+	}
+}
+
+// vlanDevice returns the addressing of the VLAN as a device.
+func vlanDevice(name string, vlan *machine.Vlan) *machine.Device {
+	return &machine.Device{
+		Interface: name,
+		CIDR:      vlan.CIDR,
+		Routes:    vlan.Routes,
+		MTU:       vlan.MTU,
+		DHCP:      vlan.DHCP,
+		DHCP6:     vlan.DHCP6,
+		SLAAC:     vlan.SLAAC,
+	}
+}
+
+// addressing returns the addressing methods of the device. IPv4 and IPv6
+// addressing methods can be combined for dual-stack interfaces.
+func addressing(device *machine.Device, link *net.Interface, resolvers []string) []nic.Option {
+	opts := []nic.Option{}
+
+	if device.DHCP {
+		opts = append(opts, nic.WithAddressing(&address.DHCP{NetIf: link}))
+	}
+
+	if device.CIDR != "" {
+		opts = append(opts, nic.WithAddressing(&address.Static{Device: device, NetIf: link, NameServers: resolvers}))
+	}
+
+	if device.DHCP6 {
+		opts = append(opts, nic.WithAddressing(&address.DHCP6{NetIf: link}))
+	}
+
+	if device.SLAAC {
+		opts = append(opts, nic.WithAddressing(&address.SLAAC{NetIf: link}))
+	}
+
+	return opts
+}
+
+func (n *NetConf) link(name string) *net.Interface {
+	for link := range *n {
+		if link.Name == name {
+			return link
 		}
 	}
 
 	return nil
 }
+
+func bondOptions(bond *machine.Bond) []nic.Option {
+	opts := []nic.Option{nic.WithType(nic.Bond)}
+
+	if bond.Mode != "" {
+		opts = append(opts, nic.WithBondMode(bond.Mode))
+	}
+
+	if bond.HashPolicy != "" {
+		opts = append(opts, nic.WithHashPolicy(bond.HashPolicy))
+	}
+
+	if bond.LACPRate != "" {
+		opts = append(opts, nic.WithLACPRate(bond.LACPRate))
+	}
+
+	for _, member := range bond.Interfaces {
+		opts = append(opts, nic.WithSubInterface(member))
+	}
+
+	return opts
+}
+
+func wireguardOptions(wg *machine.Wireguard) []nic.Option {
+	opts := []nic.Option{
+		nic.WithType(nic.Wireguard),
+		nic.WithWireguardPrivateKey(wg.PrivateKey),
+		nic.WithWireguardListenPort(wg.ListenPort),
+	}
+
+	for _, peer := range wg.Peers {
+		keepalive := time.Duration(peer.PersistentKeepalive) * time.Second
+		opts = append(opts, nic.WithWireguardPeer(peer.PublicKey, peer.Endpoint, peer.AllowedIPs, keepalive))
+	}
+
+	return opts
+}
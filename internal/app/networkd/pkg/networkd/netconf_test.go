@@ -7,8 +7,10 @@ package networkd
 import (
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
 
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
@@ -44,6 +46,269 @@ func (suite *NetconfSuite) TestNetconf() {
 	suite.Assert().Equal(iface.AddressMethod[0].Address().IP, addr)
 }
 
+func (suite *NetconfSuite) TestNetconfBond() {
+	conf := sampleConfig()
+	conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces = []machine.Device{
+		{
+			Interface: "bond0",
+			CIDR:      "192.168.0.10/24",
+			Bond: &machine.Bond{
+				Mode:       "802.3ad",
+				HashPolicy: "layer3+4",
+				LACPRate:   "fast",
+				Interfaces: []string{"eth0", "eth1"},
+			},
+		},
+	}
+
+	eth0 := &net.Interface{Index: 1, MTU: 1500, Name: "eth0"}
+	eth1 := &net.Interface{Index: 2, MTU: 1500, Name: "eth1"}
+	nc := NetConf{eth0: parseLinkMessage(eth0), eth1: parseLinkMessage(eth1)}
+	suite.Require().NoError(nc.BuildOptions(conf))
+	suite.Require().Len(nc, 3)
+
+	for _, link := range []*net.Interface{eth0, eth1} {
+		iface, err := nic.Create(link, nc[link]...)
+		suite.Require().NoError(err)
+		suite.Assert().True(iface.IsIgnored())
+	}
+
+	bond0 := nc.link("bond0")
+	suite.Require().NotNil(bond0)
+
+	iface, err := nic.Create(bond0, nc[bond0]...)
+	suite.Require().NoError(err)
+	suite.Assert().False(iface.IsIgnored())
+	suite.Assert().Equal(nic.Bond, iface.Type)
+	suite.Assert().Equal([]string{"eth0", "eth1"}, iface.SubInterfaces)
+	suite.Assert().Equal(uint8(4), iface.BondMode)
+	suite.Require().NotNil(iface.BondHashPolicy)
+	suite.Assert().Equal(uint8(1), *iface.BondHashPolicy)
+	suite.Require().NotNil(iface.BondLACPRate)
+	suite.Assert().Equal(uint8(1), *iface.BondLACPRate)
+	suite.Assert().Equal("static", iface.AddressMethod[0].Name())
+	suite.Assert().Equal(bond0, iface.AddressMethod[0].Link())
+}
+
+func (suite *NetconfSuite) TestNetconfBondInvalidMode() {
+	conf := sampleConfig()
+	conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces = []machine.Device{
+		{
+			Interface: "bond0",
+			DHCP:      true,
+			Bond:      &machine.Bond{Mode: "lacp", Interfaces: []string{"eth0"}},
+		},
+	}
+
+	nc := NetConf{}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	bond0 := nc.link("bond0")
+	suite.Require().NotNil(bond0)
+
+	_, err := nic.Create(bond0, nc[bond0]...)
+	suite.Assert().Error(err)
+}
+
+func (suite *NetconfSuite) TestNetconfVlan() {
+	conf := sampleConfig()
+	device := &conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces[0]
+	device.Vlans = []*machine.Vlan{
+		{ID: 100, CIDR: "10.100.0.5/24", MTU: 1400},
+		{ID: 200, DHCP: true},
+	}
+
+	eth0 := &net.Interface{Index: 1, MTU: 1500, Name: "eth0"}
+	nc := NetConf{eth0: parseLinkMessage(eth0)}
+	suite.Require().NoError(nc.BuildOptions(conf))
+	suite.Require().Len(nc, 3)
+
+	vlan100 := nc.link("eth0.100")
+	suite.Require().NotNil(vlan100)
+
+	iface, err := nic.Create(vlan100, nc[vlan100]...)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(nic.Vlan, iface.Type)
+	suite.Assert().Equal("eth0", iface.Parent)
+	suite.Assert().Equal(uint16(100), iface.VlanID)
+	suite.Require().Len(iface.AddressMethod, 1)
+	suite.Assert().Equal("static", iface.AddressMethod[0].Name())
+	suite.Assert().Equal(uint32(1400), iface.AddressMethod[0].MTU())
+	suite.Assert().Equal(vlan100, iface.AddressMethod[0].Link())
+
+	vlan200 := nc.link("eth0.200")
+	suite.Require().NotNil(vlan200)
+
+	iface, err = nic.Create(vlan200, nc[vlan200]...)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(uint16(200), iface.VlanID)
+	suite.Require().Len(iface.AddressMethod, 1)
+	suite.Assert().Equal("dhcp", iface.AddressMethod[0].Name())
+
+	// the parent keeps its own addressing
+	iface, err = nic.Create(eth0, nc[eth0]...)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(nic.Single, iface.Type)
+	suite.Assert().Equal("static", iface.AddressMethod[0].Name())
+}
+
+func (suite *NetconfSuite) TestNetconfVlanInvalidID() {
+	conf := sampleConfig()
+	device := &conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces[0]
+	device.Vlans = []*machine.Vlan{{ID: 4095, DHCP: true}}
+
+	nc := NetConf{}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	vlan := nc.link("eth0.4095")
+	suite.Require().NotNil(vlan)
+
+	_, err := nic.Create(vlan, nc[vlan]...)
+	suite.Assert().Error(err)
+}
+
+func (suite *NetconfSuite) TestNetconfDualStack() {
+	conf := sampleConfig()
+	device := &conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces[0]
+	device.DHCP6 = true
+	device.SLAAC = true
+
+	eth0 := &net.Interface{Index: 1, MTU: 1500, Name: "eth0"}
+	nc := NetConf{eth0: parseLinkMessage(eth0)}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	iface, err := nic.Create(eth0, nc[eth0]...)
+	suite.Require().NoError(err)
+	suite.Require().Len(iface.AddressMethod, 3)
+
+	suite.Assert().Equal("static", iface.AddressMethod[0].Name())
+	suite.Assert().Equal(unix.AF_INET, iface.AddressMethod[0].Family())
+	suite.Assert().Equal("dhcp6", iface.AddressMethod[1].Name())
+	suite.Assert().Equal(unix.AF_INET6, iface.AddressMethod[1].Family())
+	suite.Assert().Equal("slaac", iface.AddressMethod[2].Name())
+	suite.Assert().Equal(unix.AF_INET6, iface.AddressMethod[2].Family())
+}
+
+func (suite *NetconfSuite) TestNetconfStaticIPv6() {
+	conf := sampleConfig()
+	device := &conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces[0]
+	device.CIDR = "2001:db8::5/64"
+	device.Routes = []machine.Route{{Network: "::/0", Gateway: "2001:db8::1"}}
+
+	eth0 := &net.Interface{Index: 1, MTU: 1500, Name: "eth0"}
+	nc := NetConf{eth0: parseLinkMessage(eth0)}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	iface, err := nic.Create(eth0, nc[eth0]...)
+	suite.Require().NoError(err)
+	suite.Require().Len(iface.AddressMethod, 1)
+
+	method := iface.AddressMethod[0]
+	suite.Assert().Equal(unix.AF_INET6, method.Family())
+	suite.Assert().Equal("2001:db8::5/64", method.Address().String())
+	suite.Require().Len(method.Routes(), 1)
+	suite.Assert().Equal("::/0", method.Routes()[0].Dest.String())
+	suite.Assert().Equal(net.ParseIP("2001:db8::1"), method.Routes()[0].Router)
+}
+
+func (suite *NetconfSuite) TestNetconfWireguard() {
+	conf := sampleConfig()
+	conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces = []machine.Device{
+		{
+			Interface: "wg0",
+			CIDR:      "10.10.0.2/24",
+			Wireguard: &machine.Wireguard{
+				PrivateKey: "EG6iSp2vW6r/ZgzUSEJjOMbFJtEl2RSWxkVwhUr8kHY=",
+				ListenPort: 51820,
+				Peers: []machine.WireguardPeer{
+					{
+						PublicKey:           "ABCDEF+Gh1234abcdEFGH/ijkl5678MNOP90qrstuvw=",
+						Endpoint:            "192.168.0.1:51820",
+						AllowedIPs:          []string{"10.10.0.0/24", "10.96.0.0/12"},
+						PersistentKeepalive: 25,
+					},
+				},
+			},
+		},
+	}
+
+	nc := NetConf{}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	wg0 := nc.link("wg0")
+	suite.Require().NotNil(wg0)
+
+	iface, err := nic.Create(wg0, nc[wg0]...)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(nic.Wireguard, iface.Type)
+	suite.Assert().Len(iface.WireguardPrivateKey, nic.WireguardKeyLen)
+	suite.Assert().Equal(uint16(51820), iface.WireguardListenPort)
+	suite.Require().Len(iface.WireguardPeers, 1)
+
+	peer := iface.WireguardPeers[0]
+	suite.Assert().Len(peer.PublicKey, nic.WireguardKeyLen)
+	suite.Assert().Equal("192.168.0.1:51820", peer.Endpoint)
+	suite.Assert().Equal(25*time.Second, peer.PersistentKeepalive)
+	suite.Require().Len(peer.AllowedIPs, 2)
+	suite.Assert().Equal("10.96.0.0/12", peer.AllowedIPs[1].String())
+
+	suite.Require().Len(iface.AddressMethod, 1)
+	suite.Assert().Equal("static", iface.AddressMethod[0].Name())
+	suite.Assert().Equal(uint32(nic.WireguardMTU), iface.AddressMethod[0].MTU())
+
+	// Wireguard interfaces aren't addressed by DHCP
+	conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces[0].CIDR = ""
+
+	nc = NetConf{}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	wg0 = nc.link("wg0")
+	iface, err = nic.Create(wg0, nc[wg0]...)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(iface.AddressMethod)
+}
+
+func (suite *NetconfSuite) TestNetconfWireguardInvalidKey() {
+	conf := sampleConfig()
+	conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces = []machine.Device{
+		{
+			Interface: "wg0",
+			Wireguard: &machine.Wireguard{PrivateKey: "c2hvcnQ="},
+		},
+	}
+
+	nc := NetConf{}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	wg0 := nc.link("wg0")
+	suite.Require().NotNil(wg0)
+
+	_, err := nic.Create(wg0, nc[wg0]...)
+	suite.Assert().Error(err)
+}
+
+func (suite *NetconfSuite) TestNetconfVIP() {
+	conf := sampleConfig()
+	device := &conf.Machine().Network().(*v1alpha1.NetworkConfig).NetworkInterfaces[0]
+	device.VIP = &machine.VIP{IP: "192.168.0.15"}
+
+	eth0 := &net.Interface{Index: 1, MTU: 1500, Name: "eth0"}
+	nc := NetConf{eth0: parseLinkMessage(eth0)}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	iface, err := nic.Create(eth0, nc[eth0]...)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(net.ParseIP("192.168.0.15").To4(), iface.VIP)
+
+	device.VIP.IP = "2001:db8::15"
+
+	nc = NetConf{eth0: parseLinkMessage(eth0)}
+	suite.Require().NoError(nc.BuildOptions(conf))
+
+	_, err = nic.Create(eth0, nc[eth0]...)
+	suite.Assert().Error(err)
+}
+
 func sampleConfig() runtime.Configurator {
 	return &v1alpha1.Config{
 		MachineConfig: &v1alpha1.MachineConfig{
@@ -5,9 +5,29 @@
 package networkd
 
 import (
+	"fmt"
 	"log"
+	"net"
 
 	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+)
+
+// Bonding and VLAN driver attributes, see include/uapi/linux/if_link.h.
+const (
+	iflaBondMode           = 1
+	iflaBondMiimon         = 3
+	iflaBondAdLACPRate     = 13
+	iflaBondXmitHashPolicy = 14
+
+	iflaVlanID = 1
+
+	// bondMiimon is the link monitoring interval of the bond members in
+	// milliseconds.
+	bondMiimon = 100
 )
 
 // setMTU sets the link MTU
@@ -31,3 +51,152 @@ func (n *Networkd) setMTU(idx int, mtu uint32) error {
 
 	return err
 }
+
+// createBond creates the bond link unless it already exists, and enslaves
+// the members of the bond.
+func (n *Networkd) createBond(iface *nic.NetworkInterface) (*net.Interface, error) {
+	link, err := net.InterfaceByName(iface.Name)
+	if err != nil {
+		var data []byte
+
+		if data, err = bondData(iface); err != nil {
+			return nil, err
+		}
+
+		log.Printf("creating bond %s", iface.Name)
+
+		err = n.NlConn.Link.New(&rtnetlink.LinkMessage{
+			Family: unix.AF_UNSPEC,
+			Attributes: &rtnetlink.LinkAttributes{
+				Name: iface.Name,
+				Info: &rtnetlink.LinkInfo{Kind: "bond", Data: data},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bond %s: %w", iface.Name, err)
+		}
+
+		if link, err = net.InterfaceByName(iface.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range iface.SubInterfaces {
+		if err = n.enslave(link, name); err != nil {
+			return nil, fmt.Errorf("failed to add %s to bond %s: %w", name, iface.Name, err)
+		}
+	}
+
+	// the bond takes the hardware address of its first member
+	return net.InterfaceByName(iface.Name)
+}
+
+// enslave adds the link with the specified name to the master link. The link
+// has to be down to be enslaved.
+func (n *Networkd) enslave(master *net.Interface, name string) error {
+	link, err := net.InterfaceByName(name)
+	if err != nil {
+		return err
+	}
+
+	msg, err := n.NlConn.Link.Get(uint32(link.Index))
+	if err != nil {
+		return err
+	}
+
+	if msg.Attributes.Master != nil && *msg.Attributes.Master == uint32(master.Index) {
+		return nil
+	}
+
+	if err = n.Conn.LinkDown(link); err != nil {
+		return err
+	}
+
+	index := uint32(master.Index)
+
+	return n.NlConn.Link.Set(&rtnetlink.LinkMessage{
+		Family: msg.Family,
+		Type:   msg.Type,
+		Index:  uint32(link.Index),
+		Flags:  msg.Flags &^ unix.IFF_UP,
+		Change: 0,
+		Attributes: &rtnetlink.LinkAttributes{
+			Master: &index,
+		},
+	})
+}
+
+// bondData encodes the bonding driver options of the interface. The options
+// which are not configured are left out, the kernel refuses to set the LACP
+// rate in any other mode than 802.3ad.
+func bondData(iface *nic.NetworkInterface) ([]byte, error) {
+	ae := netlink.NewAttributeEncoder()
+
+	ae.Uint8(iflaBondMode, iface.BondMode)
+	ae.Uint32(iflaBondMiimon, bondMiimon)
+
+	if iface.BondHashPolicy != nil {
+		ae.Uint8(iflaBondXmitHashPolicy, *iface.BondHashPolicy)
+	}
+
+	if iface.BondMode == nic.BondMode8023AD && iface.BondLACPRate != nil {
+		ae.Uint8(iflaBondAdLACPRate, *iface.BondLACPRate)
+	}
+
+	return ae.Encode()
+}
+
+// createVlan creates the VLAN link on top of its parent unless it already
+// exists. The parent is brought up, since the VLAN can't be used otherwise.
+func (n *Networkd) createVlan(iface *nic.NetworkInterface) (*net.Interface, error) {
+	parent, err := net.InterfaceByName(iface.Parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the parent of vlan %s: %w", iface.Name, err)
+	}
+
+	if err = n.Conn.LinkUp(parent); err != nil {
+		return nil, fmt.Errorf("failed to bring up %s: %w", parent.Name, err)
+	}
+
+	if link, err := net.InterfaceByName(iface.Name); err == nil {
+		return link, nil
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	ae.Uint16(iflaVlanID, iface.VlanID)
+
+	data, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("creating vlan %s", iface.Name)
+
+	err = n.NlConn.Link.New(&rtnetlink.LinkMessage{
+		Family: unix.AF_UNSPEC,
+		Attributes: &rtnetlink.LinkAttributes{
+			Name: iface.Name,
+			// encoded as IFLA_LINK, the index of the parent link
+			Type: uint32(parent.Index),
+			Info: &rtnetlink.LinkInfo{Kind: "vlan", Data: data},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vlan %s: %w", iface.Name, err)
+	}
+
+	return net.InterfaceByName(iface.Name)
+}
+
+// deleteLink removes the link with the specified name, the members of a bond
+// are released, and the VLANs on top of the link are removed along with it.
+func (n *Networkd) deleteLink(name string) error {
+	link, err := net.InterfaceByName(name)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("removing link %s", name)
+
+	return n.NlConn.Link.Delete(uint32(link.Index))
+}
@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"testing"
+
+	"github.com/mdlayher/netlink"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+)
+
+type NetlinkSuite struct {
+	suite.Suite
+}
+
+func TestNetlinkSuite(t *testing.T) {
+	suite.Run(t, new(NetlinkSuite))
+}
+
+// decodeBondData returns the bond attributes of the interface by type.
+func (suite *NetlinkSuite) decodeBondData(iface *nic.NetworkInterface) map[uint16]uint8 {
+	data, err := bondData(iface)
+	suite.Require().NoError(err)
+
+	ad, err := netlink.NewAttributeDecoder(data)
+	suite.Require().NoError(err)
+
+	attrs := map[uint16]uint8{}
+
+	for ad.Next() {
+		if ad.Type() == iflaBondMiimon {
+			continue
+		}
+
+		attrs[ad.Type()] = ad.Uint8()
+	}
+
+	suite.Require().NoError(ad.Err())
+
+	return attrs
+}
+
+func (suite *NetlinkSuite) TestBondDataActiveBackup() {
+	iface, err := nic.Create(nil, nic.WithName("bond0"), nic.WithType(nic.Bond), nic.WithBondMode("active-backup"), nic.WithLACPRate("fast"))
+	suite.Require().NoError(err)
+
+	attrs := suite.decodeBondData(iface)
+
+	suite.Assert().Equal(map[uint16]uint8{iflaBondMode: 1}, attrs)
+	suite.Assert().NotContains(attrs, uint16(iflaBondAdLACPRate))
+}
+
+func (suite *NetlinkSuite) TestBondData8023AD() {
+	iface, err := nic.Create(nil, nic.WithName("bond0"), nic.WithType(nic.Bond), nic.WithBondMode("802.3ad"), nic.WithHashPolicy("layer3+4"), nic.WithLACPRate("fast"))
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(map[uint16]uint8{
+		iflaBondMode:           nic.BondMode8023AD,
+		iflaBondXmitHashPolicy: 1,
+		iflaBondAdLACPRate:     1,
+	}, suite.decodeBondData(iface))
+}
@@ -21,6 +21,8 @@ import (
 
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/constants"
 )
 
 // Set up default nameservers
@@ -35,6 +37,32 @@ const (
 type Networkd struct {
 	Conn   *rtnl.Conn
 	NlConn *rtnetlink.Conn
+
+	rawConn *netlink.Conn
+
+	mu sync.Mutex
+	// methods are the configured addressing methods, in the order of
+	// configuration.
+	methods   []address.Addressing
+	resolvers map[address.Addressing][]net.IP
+	leases    map[string]Lease
+	events    []LeaseEvent
+	// cacheDNS is set if the host resolves names with the DNS cache.
+	cacheDNS bool
+
+	// applyMu serializes the changes of the network configuration.
+	applyMu sync.Mutex
+	// network is the network configuration which the links are configured
+	// with, nil unless networkd configured the network.
+	network machine.Network
+	pending *pendingApply
+	// statePath is the path the state of the applied changes is saved to.
+	statePath string
+
+	// vips tracks the elections of the virtual IPs, and renewals the
+	// renewals of the addressing.
+	vips     sync.WaitGroup
+	renewals sync.WaitGroup
 }
 
 // New instantiates a new rtnetlink connection that is used for all subsequent
@@ -53,7 +81,20 @@ func New() (*Networkd, error) {
 		return nil, err
 	}
 
-	return &Networkd{Conn: conn, NlConn: nlConn}, err
+	// Need raw netlink for the route metrics and the routing policy rules
+	rawConn, err := netlink.Dial(unix.NETLINK_ROUTE, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Networkd{
+		Conn:      conn,
+		NlConn:    nlConn,
+		rawConn:   rawConn,
+		resolvers: map[address.Addressing][]net.IP{},
+		leases:    map[string]Lease{},
+		statePath: constants.NetworkApplyStatePath,
+	}, err
 }
 
 // Discover enumerates a list of network links on the host and creates a
@@ -77,12 +118,19 @@ func (n *Networkd) Discover() (NetConf, error) {
 // the address discovery ( static vs dhcp ) as well as the netlink interaction
 // to set an address on the link and create any routes.
 func (n *Networkd) Configure(ifaces ...*nic.NetworkInterface) error {
-	var (
-		err       error
-		mu        sync.Mutex
-		resolvers []net.IP
-		wg        sync.WaitGroup
-	)
+	var wg sync.WaitGroup
+
+	// Bonds, VLANs and Wireguard interfaces are created first, and one after
+	// the other, since VLANs might be created on top of bonds.
+	ifaces = n.createLinks(ifaces)
+
+	n.mu.Lock()
+
+	for _, iface := range ifaces {
+		n.methods = append(n.methods, iface.AddressMethod...)
+	}
+
+	n.mu.Unlock()
 
 	wg.Add(len(ifaces))
 
@@ -90,7 +138,7 @@ func (n *Networkd) Configure(ifaces ...*nic.NetworkInterface) error {
 		go func(i *nic.NetworkInterface) {
 			defer wg.Done()
 			// Bring up the interface
-			if err = n.Conn.LinkUp(&net.Interface{Index: int(i.Index)}); err != nil {
+			if err := n.Conn.LinkUp(&net.Interface{Index: int(i.Index)}); err != nil {
 				log.Printf("failed to bring up %s: %v", i.Name, err)
 				return
 			}
@@ -100,7 +148,13 @@ func (n *Networkd) Configure(ifaces ...*nic.NetworkInterface) error {
 			for _, method := range i.AddressMethod {
 				log.Printf("configuring %s addressing for %s\n", method.Name(), i.Name)
 
-				if err = n.configureInterface(method); err != nil {
+				err := n.configureInterface(context.Background(), method)
+
+				if lease, ok := method.(address.Lease); ok {
+					n.recordLease(lease, err)
+				}
+
+				if err != nil {
 					// Treat as non fatal error when failing to configure an interface
 					log.Println(err)
 					return
@@ -111,9 +165,7 @@ func (n *Networkd) Configure(ifaces ...*nic.NetworkInterface) error {
 				}
 
 				// Aggregate a list of DNS servers/resolvers
-				mu.Lock()
-				resolvers = append(resolvers, method.Resolvers()...)
-				mu.Unlock()
+				n.setResolvers(method, method.Resolvers())
 			}
 		}(iface)
 	}
@@ -121,64 +173,159 @@ func (n *Networkd) Configure(ifaces ...*nic.NetworkInterface) error {
 	wg.Wait()
 
 	// Write out resolv.conf
-	if err = writeResolvConf(resolvers); err != nil {
+	if err := n.updateResolvConf(); err != nil {
 		return err
 	}
 
+	// The endpoints of the Wireguard peers are resolved with the resolvers
+	// of the other interfaces
+	if err := n.configureWireguards(ifaces); err != nil {
+		// Treat as non fatal error when failing to configure an interface
+		log.Println(err)
+	}
+
 	return nil
 }
 
-// Renew sets up a long running loop to refresh a network interfaces
-// addressing configuration. Currently this only applies to interfaces
-// configured by DHCP.
-func (n *Networkd) Renew(ifaces ...*nic.NetworkInterface) {
+// createLinks creates the bonds, the VLANs, and then the Wireguard
+// interfaces. The interfaces which failed to be created are left out of the
+// returned interfaces.
+func (n *Networkd) createLinks(ifaces []*nic.NetworkInterface) []*nic.NetworkInterface {
+	created := make([]*nic.NetworkInterface, 0, len(ifaces))
+
+	for _, iface := range ifaces {
+		if iface.Type == nic.Single {
+			created = append(created, iface)
+		}
+	}
+
+	for _, t := range []int{nic.Bond, nic.Vlan, nic.Wireguard} {
+		for _, iface := range ifaces {
+			if iface.Type != t {
+				continue
+			}
+
+			if err := n.createLink(iface); err != nil {
+				log.Println(err)
+				continue
+			}
+
+			created = append(created, iface)
+		}
+	}
+
+	return created
+}
+
+// createLink creates the link of a bond, VLAN or Wireguard interface, and
+// points the addressing methods of the interface to the created link.
+func (n *Networkd) createLink(iface *nic.NetworkInterface) (err error) {
+	var link *net.Interface
+
+	switch iface.Type {
+	case nic.Bond:
+		link, err = n.createBond(iface)
+	case nic.Vlan:
+		link, err = n.createVlan(iface)
+	case nic.Wireguard:
+		link, err = n.createWireguard(iface)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	iface.Index = uint32(link.Index)
+
+	for _, method := range iface.AddressMethod {
+		*method.Link() = *link
+	}
+
+	return nil
+}
+
+// Renew sets up long running loops to refresh a network interfaces
+// addressing configuration, until the context is canceled. Currently this
+// only applies to interfaces configured by DHCP.
+func (n *Networkd) Renew(ctx context.Context, ifaces ...*nic.NetworkInterface) {
 	for _, iface := range ifaces {
 		for _, method := range iface.AddressMethod {
+			if lease, ok := method.(address.Lease); ok {
+				n.renewals.Add(1)
+
+				go func(lease address.Lease) {
+					defer n.renewals.Done()
+
+					n.renewLease(ctx, lease)
+				}(lease)
+
+				continue
+			}
+
 			if method.TTL() == 0 {
 				continue
 			}
 
-			go n.renew(method)
+			n.renewals.Add(1)
+
+			go func(method address.Addressing) {
+				defer n.renewals.Done()
+
+				n.renew(ctx, method)
+			}(method)
 		}
 	}
-
-	// TODO switch this out with context when that gets added
-	select {}
 }
 
 // renew sets up the looping to ensure we keep the addressing information
 // up to date. We attempt to do our first reconfiguration halfway through
 // address TTL. If that fails, we'll continue to attempt to retry every
 // halflife.
-func (n *Networkd) renew(method address.Addressing) {
+func (n *Networkd) renew(ctx context.Context, method address.Addressing) {
 	renewDuration := method.TTL() / 2
 
 	for {
-		<-time.After(renewDuration)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(renewDuration):
+		}
+
+		prev := newAddressingState(method)
 
-		if err := n.configureInterface(method); err != nil {
+		if err := method.Discover(ctx); err != nil {
 			log.Printf("failed to renew interface address for %s: %v\n", method.Link().Name, err)
 
 			renewDuration = (renewDuration / 2)
-		} else {
-			renewDuration = method.TTL() / 2
+
+			continue
+		}
+
+		if err := n.reconcile(prev, method); err != nil {
+			log.Printf("failed to reconfigure %s: %v\n", method.Link().Name, err)
 		}
+
+		renewDuration = method.TTL() / 2
 	}
 }
 
 // configureInterface handles the actual address discovery mechanism and
 // netlink interaction to configure the interface
-// nolint: gocyclo
-func (n *Networkd) configureInterface(method address.Addressing) error {
+func (n *Networkd) configureInterface(ctx context.Context, method address.Addressing) error {
 	// TODO s/Discover/Something else/
-	// TODO make context more relevant
-	var err error
-	if err = method.Discover(context.Background()); err != nil {
+	if err := method.Discover(ctx); err != nil {
 		// Right now this would only happen during dhcp discovery failure
 		log.Printf("failed to prep %s: %v", method.Link().Name, err)
 		return err
 	}
 
+	return n.setAddressing(method)
+}
+
+// setAddressing sets the MTU, the address and the routes of the addressing
+// method.
+// nolint: gocyclo
+func (n *Networkd) setAddressing(method address.Addressing) (err error) {
 	// Set link MTU if we got a response
 	if err = n.setMTU(method.Link().Index, method.MTU()); err != nil {
 		log.Printf("failed to set mtu %d for %s: %v", method.MTU(), method.Link().Name, err)
@@ -217,7 +364,7 @@ func (n *Networkd) configureInterface(method address.Addressing) error {
 
 	// Add any routes
 	for _, r := range method.Routes() {
-		if err = n.Conn.RouteAddSrc(method.Link(), *r.Dest, method.Address(), r.Router); err != nil {
+		if err = n.addRoute(method.Link(), r, routeSource(method)); err != nil {
 			switch err := err.(type) {
 			case *netlink.OpError:
 				// ignore the error if it's -EEXIST or -ESRCH
@@ -234,6 +381,17 @@ func (n *Networkd) configureInterface(method address.Addressing) error {
 	return nil
 }
 
+// routeSource returns the preferred source address of the routes of the
+// addressing method. The kernel picks the source address of IPv6 routes by
+// itself.
+func routeSource(method address.Addressing) net.IP {
+	if method.Family() == unix.AF_INET6 {
+		return nil
+	}
+
+	return method.Address().IP
+}
+
 // Hostname returns the first hostname found from the addressing methods.
 func (n *Networkd) Hostname(ifaces ...*nic.NetworkInterface) string {
 	for _, iface := range ifaces {
@@ -0,0 +1,344 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+// Routing policy rule attributes, see include/uapi/linux/fib_rules.h.
+const (
+	fraDst      = 1
+	fraSrc      = 2
+	fraIIFName  = 3
+	fraPriority = 6
+	fraFwMark   = 10
+	fraTable    = 15
+	fraOIFName  = 17
+
+	frActToTbl = 1
+
+	// sizeofFibRuleHdr is the size of struct fib_rule_hdr.
+	sizeofFibRuleHdr = 12
+)
+
+// addRoute adds the route via the link. The source address is the preferred
+// source of the route, unless the route specifies it.
+func (n *Networkd) addRoute(link *net.Interface, r *address.Route, src net.IP) error {
+	if r.Source != nil {
+		src = r.Source
+	}
+
+	b, err := routeMessage(link, r, src)
+	if err != nil {
+		return err
+	}
+
+	return n.execute(unix.RTM_NEWROUTE, netlink.Create|netlink.Excl, b)
+}
+
+// deleteRoute removes the route via the link.
+func (n *Networkd) deleteRoute(link *net.Interface, r *address.Route) error {
+	b, err := routeMessage(link, r, nil)
+	if err != nil {
+		return err
+	}
+
+	return n.execute(unix.RTM_DELROUTE, 0, b)
+}
+
+// ConfigureRules adds the routing policy rules.
+func (n *Networkd) ConfigureRules(rules []machine.Rule) error {
+	var result *multierror.Error
+
+	for _, rule := range rules {
+		rule := rule
+
+		log.Printf("adding rule %s", ruleString(&rule))
+
+		if err := ignoreExists(n.addRule(&rule)); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to add rule %s: %w", ruleString(&rule), err))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// addRule adds the routing policy rule.
+func (n *Networkd) addRule(rule *machine.Rule) error {
+	msgs, err := ruleMessages(rule)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range msgs {
+		if err = n.execute(unix.RTM_NEWRULE, netlink.Create|netlink.Excl, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteRule removes the routing policy rule.
+func (n *Networkd) deleteRule(rule *machine.Rule) error {
+	msgs, err := ruleMessages(rule)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range msgs {
+		if err = n.execute(unix.RTM_DELRULE, 0, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// execute sends the rtnetlink request, rtnetlink doesn't encode the route
+// metrics and the routing policy rules.
+func (n *Networkd) execute(t netlink.HeaderType, flags netlink.HeaderFlags, data []byte) error {
+	_, err := n.rawConn.Execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  t,
+			Flags: netlink.Request | netlink.Acknowledge | flags,
+		},
+		Data: data,
+	})
+
+	return err
+}
+
+// routeMessage encodes the route as struct rtmsg followed by the route
+// attributes.
+func routeMessage(link *net.Interface, r *address.Route, src net.IP) ([]byte, error) {
+	family, dst := ipFamily(r.Dest.IP)
+	ones, _ := r.Dest.Mask.Size()
+
+	table := r.Table
+	if table == 0 {
+		table = unix.RT_TABLE_MAIN
+	}
+
+	// routes via a router are reachable beyond the link
+	scope := unix.RT_SCOPE_LINK
+	if r.Router != nil && !r.Router.IsUnspecified() {
+		scope = unix.RT_SCOPE_UNIVERSE
+	}
+
+	b := make([]byte, unix.SizeofRtMsg)
+	b[0] = family
+	b[1] = uint8(ones)
+	b[4] = tableID(table)
+	b[5] = unix.RTPROT_BOOT
+	b[6] = uint8(scope)
+	b[7] = unix.RTN_UNICAST
+
+	if r.OnLink {
+		nlenc.PutUint32(b[8:12], unix.RTNH_F_ONLINK)
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	ae.Bytes(unix.RTA_DST, dst)
+	ae.Uint32(unix.RTA_OIF, uint32(link.Index))
+	ae.Uint32(unix.RTA_TABLE, table)
+
+	if scope == unix.RT_SCOPE_UNIVERSE {
+		_, gw := ipFamily(r.Router)
+		ae.Bytes(unix.RTA_GATEWAY, gw)
+	}
+
+	if src != nil {
+		_, prefsrc := ipFamily(src)
+		ae.Bytes(unix.RTA_PREFSRC, prefsrc)
+	}
+
+	if r.Metric != 0 {
+		ae.Uint32(unix.RTA_PRIORITY, r.Metric)
+	}
+
+	if r.MTU != 0 {
+		ae.Nested(unix.RTA_METRICS, func(nae *netlink.AttributeEncoder) error {
+			nae.Uint32(unix.RTAX_MTU, r.MTU)
+			return nil
+		})
+	}
+
+	attrs, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, attrs...), nil
+}
+
+// ruleMessages encodes the rule as struct fib_rule_hdr followed by the rule
+// attributes. A rule without prefixes is encoded for both IPv4 and IPv6.
+func ruleMessages(rule *machine.Rule) ([][]byte, error) {
+	from, err := parsePrefix(rule.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := parsePrefix(rule.To)
+	if err != nil {
+		return nil, err
+	}
+
+	families := []uint8{unix.AF_INET, unix.AF_INET6}
+
+	for _, prefix := range []*net.IPNet{from, to} {
+		if prefix != nil {
+			family, _ := ipFamily(prefix.IP)
+			families = []uint8{family}
+		}
+	}
+
+	if from != nil && to != nil {
+		if f, _ := ipFamily(from.IP); f != families[0] {
+			return nil, fmt.Errorf("the prefixes of rule %s are of different families", ruleString(rule))
+		}
+	}
+
+	table := rule.Table
+	if table == 0 {
+		table = unix.RT_TABLE_MAIN
+	}
+
+	msgs := make([][]byte, 0, len(families))
+
+	for _, family := range families {
+		b := make([]byte, sizeofFibRuleHdr)
+		b[0] = family
+		b[4] = tableID(table)
+		b[7] = frActToTbl
+
+		ae := netlink.NewAttributeEncoder()
+
+		if from != nil {
+			ones, _ := from.Mask.Size()
+			_, ip := ipFamily(from.IP)
+			b[2] = uint8(ones)
+			ae.Bytes(fraSrc, ip)
+		}
+
+		if to != nil {
+			ones, _ := to.Mask.Size()
+			_, ip := ipFamily(to.IP)
+			b[1] = uint8(ones)
+			ae.Bytes(fraDst, ip)
+		}
+
+		if rule.IIF != "" {
+			ae.String(fraIIFName, rule.IIF)
+		}
+
+		if rule.OIF != "" {
+			ae.String(fraOIFName, rule.OIF)
+		}
+
+		if rule.FwMark != 0 {
+			ae.Uint32(fraFwMark, rule.FwMark)
+		}
+
+		if rule.Priority != 0 {
+			ae.Uint32(fraPriority, rule.Priority)
+		}
+
+		ae.Uint32(fraTable, table)
+
+		attrs, err := ae.Encode()
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, append(b, attrs...))
+	}
+
+	return msgs, nil
+}
+
+// ruleString formats the rule like ip rule does.
+func ruleString(rule *machine.Rule) string {
+	s := []string{}
+
+	if rule.Priority != 0 {
+		s = append(s, fmt.Sprintf("priority %d", rule.Priority))
+	}
+
+	if rule.From != "" {
+		s = append(s, "from "+rule.From)
+	}
+
+	if rule.To != "" {
+		s = append(s, "to "+rule.To)
+	}
+
+	if rule.IIF != "" {
+		s = append(s, "iif "+rule.IIF)
+	}
+
+	if rule.OIF != "" {
+		s = append(s, "oif "+rule.OIF)
+	}
+
+	if rule.FwMark != 0 {
+		s = append(s, fmt.Sprintf("fwmark %#x", rule.FwMark))
+	}
+
+	table := rule.Table
+	if table == 0 {
+		table = unix.RT_TABLE_MAIN
+	}
+
+	return strings.Join(append(s, fmt.Sprintf("lookup %d", table)), " ")
+}
+
+// parsePrefix parses the prefix of a rule, an empty prefix matches all
+// addresses.
+func parsePrefix(s string) (*net.IPNet, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	_, prefix, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix %q: %w", s, err)
+	}
+
+	return prefix, nil
+}
+
+// ipFamily returns the family of the address, and the address in the length
+// of the family.
+func ipFamily(ip net.IP) (uint8, net.IP) {
+	if ip4 := ip.To4(); ip4 != nil {
+		return unix.AF_INET, ip4
+	}
+
+	return unix.AF_INET6, ip.To16()
+}
+
+// tableID returns the table ID for the header of the message, the tables
+// beyond 255 are only set with the table attribute.
+func tableID(table uint32) uint8 {
+	if table > 255 {
+		return unix.RT_TABLE_UNSPEC
+	}
+
+	return uint8(table)
+}
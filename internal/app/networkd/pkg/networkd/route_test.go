@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"net"
+	"testing"
+
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+type RouteSuite struct {
+	suite.Suite
+}
+
+func TestRouteSuite(t *testing.T) {
+	suite.Run(t, new(RouteSuite))
+}
+
+func (suite *RouteSuite) TestRouteMessage() {
+	_, dst, err := net.ParseCIDR("10.0.0.0/8")
+	suite.Require().NoError(err)
+
+	r := &address.Route{
+		Dest:   dst,
+		Router: net.ParseIP("192.168.0.1"),
+		Metric: 100,
+		MTU:    9000,
+		OnLink: true,
+		Table:  300,
+	}
+
+	b, err := routeMessage(&net.Interface{Index: 2}, r, net.ParseIP("192.168.0.10"))
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(uint8(unix.AF_INET), b[0])
+	suite.Assert().Equal(uint8(8), b[1])
+	// tables beyond 255 are only set with the attribute
+	suite.Assert().Equal(uint8(unix.RT_TABLE_UNSPEC), b[4])
+	suite.Assert().Equal(uint8(unix.RT_SCOPE_UNIVERSE), b[6])
+	suite.Assert().Equal(uint32(unix.RTNH_F_ONLINK), nlenc.Uint32(b[8:12]))
+
+	attrs := suite.attributes(b[unix.SizeofRtMsg:])
+
+	suite.Assert().Equal(net.IP{10, 0, 0, 0}, net.IP(attrs[unix.RTA_DST]))
+	suite.Assert().Equal(net.IP{192, 168, 0, 1}, net.IP(attrs[unix.RTA_GATEWAY]))
+	suite.Assert().Equal(net.IP{192, 168, 0, 10}, net.IP(attrs[unix.RTA_PREFSRC]))
+	suite.Assert().Equal(uint32(2), nlenc.Uint32(attrs[unix.RTA_OIF]))
+	suite.Assert().Equal(uint32(100), nlenc.Uint32(attrs[unix.RTA_PRIORITY]))
+	suite.Assert().Equal(uint32(300), nlenc.Uint32(attrs[unix.RTA_TABLE]))
+
+	metrics := suite.attributes(attrs[unix.RTA_METRICS])
+	suite.Assert().Equal(uint32(9000), nlenc.Uint32(metrics[unix.RTAX_MTU]))
+}
+
+func (suite *RouteSuite) TestRouteMessageLink() {
+	_, dst, err := net.ParseCIDR("2001:db8::/64")
+	suite.Require().NoError(err)
+
+	b, err := routeMessage(&net.Interface{Index: 2}, &address.Route{Dest: dst}, nil)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(uint8(unix.AF_INET6), b[0])
+	suite.Assert().Equal(uint8(unix.RT_TABLE_MAIN), b[4])
+	suite.Assert().Equal(uint8(unix.RT_SCOPE_LINK), b[6])
+
+	attrs := suite.attributes(b[unix.SizeofRtMsg:])
+
+	suite.Assert().NotContains(attrs, uint16(unix.RTA_GATEWAY))
+	suite.Assert().NotContains(attrs, uint16(unix.RTA_PREFSRC))
+	suite.Assert().NotContains(attrs, uint16(unix.RTA_METRICS))
+	suite.Assert().Equal(uint32(unix.RT_TABLE_MAIN), nlenc.Uint32(attrs[unix.RTA_TABLE]))
+}
+
+func (suite *RouteSuite) TestRuleMessages() {
+	msgs, err := ruleMessages(&machine.Rule{From: "10.5.0.0/24", IIF: "eth1", Table: 100, Priority: 1000})
+	suite.Require().NoError(err)
+	suite.Require().Len(msgs, 1)
+
+	b := msgs[0]
+
+	suite.Assert().Equal(uint8(unix.AF_INET), b[0])
+	suite.Assert().Equal(uint8(24), b[2])
+	suite.Assert().Equal(uint8(100), b[4])
+	suite.Assert().Equal(uint8(frActToTbl), b[7])
+
+	attrs := suite.attributes(b[sizeofFibRuleHdr:])
+
+	suite.Assert().Equal(net.IP{10, 5, 0, 0}, net.IP(attrs[fraSrc]))
+	suite.Assert().Equal("eth1\x00", string(attrs[fraIIFName]))
+	suite.Assert().Equal(uint32(1000), nlenc.Uint32(attrs[fraPriority]))
+	suite.Assert().Equal(uint32(100), nlenc.Uint32(attrs[fraTable]))
+
+	// rules without prefixes apply to both families
+	msgs, err = ruleMessages(&machine.Rule{FwMark: 1, Table: 100})
+	suite.Require().NoError(err)
+	suite.Require().Len(msgs, 2)
+	suite.Assert().Equal(uint8(unix.AF_INET), msgs[0][0])
+	suite.Assert().Equal(uint8(unix.AF_INET6), msgs[1][0])
+
+	_, err = ruleMessages(&machine.Rule{From: "10.5.0.0/24", To: "2001:db8::/64"})
+	suite.Assert().Error(err)
+
+	_, err = ruleMessages(&machine.Rule{From: "10.5.0.300/24"})
+	suite.Assert().Error(err)
+}
+
+func (suite *RouteSuite) TestRuleString() {
+	suite.Assert().Equal("priority 1000 from 10.5.0.0/24 iif eth1 fwmark 0x10 lookup 100", ruleString(&machine.Rule{
+		From:     "10.5.0.0/24",
+		IIF:      "eth1",
+		FwMark:   16,
+		Table:    100,
+		Priority: 1000,
+	}))
+
+	suite.Assert().Equal("to 10.6.0.0/16 lookup 254", ruleString(&machine.Rule{To: "10.6.0.0/16"}))
+}
+
+func (suite *RouteSuite) attributes(b []byte) map[uint16][]byte {
+	ad, err := netlink.NewAttributeDecoder(b)
+	suite.Require().NoError(err)
+
+	attrs := map[uint16][]byte{}
+
+	for ad.Next() {
+		attrs[ad.Type()] = ad.Bytes()
+	}
+
+	suite.Require().NoError(ad.Err())
+
+	return attrs
+}
@@ -0,0 +1,237 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/concurrency"
+	"github.com/coreos/etcd/pkg/transport"
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+	"github.com/talos-systems/talos/pkg/constants"
+	talosnet "github.com/talos-systems/talos/pkg/net"
+)
+
+const (
+	// vipSessionTTL is the TTL in seconds of the etcd lease of the owner of a
+	// virtual IP, the virtual IP moves to another node within the TTL once its
+	// owner goes away.
+	vipSessionTTL = 10
+
+	// vipElectionPrefix is the etcd prefix of the elections of the virtual
+	// IPs.
+	vipElectionPrefix = "/talos/vip/"
+
+	// vipRetryInterval is the time between the attempts to join the election
+	// of a virtual IP, and between the checks of the address of the owner.
+	vipRetryInterval = 5 * time.Second
+)
+
+// RunVIPs campaigns for the virtual IPs of the interfaces, until the context
+// is canceled. The node which wins the election of a virtual IP adds the
+// address to the interface, and announces it with gratuitous ARP.
+func (n *Networkd) RunVIPs(ctx context.Context, ifaces ...*nic.NetworkInterface) {
+	for _, iface := range ifaces {
+		if iface.VIP == nil {
+			continue
+		}
+
+		n.vips.Add(1)
+
+		go func(name string, ip net.IP) {
+			defer n.vips.Done()
+
+			n.runVIP(ctx, name, ip)
+		}(iface.Name, iface.VIP)
+	}
+}
+
+// runVIP joins the election of the virtual IP again whenever the node loses
+// its connection to etcd.
+func (n *Networkd) runVIP(ctx context.Context, name string, ip net.IP) {
+	for {
+		err := n.campaign(ctx, name, ip)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("election of virtual ip %s failed, retrying in %s: %v", ip, vipRetryInterval, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(vipRetryInterval):
+		}
+	}
+}
+
+// campaign waits until the node wins the election of the virtual IP, and
+// owns the address until the etcd session expires, or the context is
+// canceled. The address is removed, and the election resigned, before it
+// returns.
+func (n *Networkd) campaign(ctx context.Context, name string, ip net.IP) error {
+	client, err := newEtcdClient()
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer client.Close()
+
+	// the session outlives the context, so that its lease is revoked on
+	// close, and another node takes the virtual IP over right away
+	session, err := concurrency.NewSession(client, concurrency.WithTTL(vipSessionTTL))
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer session.Close()
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	election := concurrency.NewElection(session, vipElectionPrefix+ip.String())
+
+	if err = election.Campaign(ctx, hostname); err != nil {
+		return err
+	}
+
+	log.Printf("won the election of virtual ip %s", ip)
+
+	defer n.removeVIP(name, ip)
+
+	// the address is added again if it goes away with the link, e.g. when
+	// the link is recreated
+	for {
+		if err = n.addVIP(name, ip); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-session.Done():
+			return errors.New("etcd session expired")
+		case <-time.After(vipRetryInterval):
+		}
+	}
+}
+
+// addVIP adds the virtual IP to the link, and announces it with gratuitous
+// ARP if the address wasn't on the link yet.
+func (n *Networkd) addVIP(name string, ip net.IP) error {
+	link, err := net.InterfaceByName(name)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := n.Conn.Addrs(link, 0)
+	if err != nil {
+		return err
+	}
+
+	addr := vipAddress(ip)
+	if containsAddress(addrs, addr) {
+		return nil
+	}
+
+	log.Printf("adding virtual ip %s to %s", ip, name)
+
+	msg, err := vipMessage(link, ip)
+	if err != nil {
+		return err
+	}
+
+	if err = ignoreExists(n.execute(unix.RTM_NEWADDR, netlink.Create|netlink.Excl, msg)); err != nil {
+		return err
+	}
+
+	if err = sendGratuitousARP(link, ip); err != nil {
+		log.Printf("failed to announce virtual ip %s on %s: %v", ip, name, err)
+	}
+
+	return nil
+}
+
+// removeVIP removes the virtual IP from the link.
+func (n *Networkd) removeVIP(name string, ip net.IP) {
+	log.Printf("removing virtual ip %s from %s", ip, name)
+
+	link, err := net.InterfaceByName(name)
+	if err != nil {
+		log.Printf("failed to remove virtual ip %s from %s: %v", ip, name, err)
+		return
+	}
+
+	if err = n.Conn.AddrDel(link, vipAddress(ip)); err != nil {
+		log.Printf("failed to remove virtual ip %s from %s: %v", ip, name, err)
+	}
+}
+
+// vipMessage encodes the address of the virtual IP as struct ifaddrmsg
+// followed by the address attributes. The address is labeled, so that it
+// isn't taken for an address of the node, rtnetlink doesn't encode the
+// labels.
+func vipMessage(link *net.Interface, ip net.IP) ([]byte, error) {
+	ip4 := ip.To4()
+
+	b := make([]byte, unix.SizeofIfAddrmsg)
+	b[0] = unix.AF_INET
+	b[1] = 8 * net.IPv4len
+	b[3] = unix.RT_SCOPE_UNIVERSE
+	nlenc.PutUint32(b[4:8], uint32(link.Index))
+
+	ae := netlink.NewAttributeEncoder()
+	ae.Bytes(unix.IFA_LOCAL, ip4)
+	ae.Bytes(unix.IFA_ADDRESS, ip4)
+	ae.String(unix.IFA_LABEL, talosnet.VIPLabel(link.Name))
+
+	attrs, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, attrs...), nil
+}
+
+// vipAddress returns the host address of the virtual IP, the routes of the
+// subnet come from the address of the interface.
+func vipAddress(ip net.IP) *net.IPNet {
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(8*net.IPv4len, 8*net.IPv4len)}
+}
+
+// newEtcdClient returns a client of the local etcd member. networkd doesn't
+// use the etcd package, which links the Kubernetes client.
+func newEtcdClient() (*clientv3.Client, error) {
+	tlsInfo := transport.TLSInfo{
+		CertFile:      constants.KubernetesEtcdPeerCert,
+		KeyFile:       constants.KubernetesEtcdPeerKey,
+		TrustedCAFile: constants.KubernetesEtcdCACert,
+	}
+
+	tlsConfig, err := tlsInfo.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return clientv3.New(clientv3.Config{
+		Endpoints:   []string{"127.0.0.1:" + constants.KubernetesEtcdListenClientPort},
+		DialTimeout: 5 * time.Second,
+		TLS:         tlsConfig,
+	})
+}
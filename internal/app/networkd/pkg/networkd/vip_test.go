@@ -0,0 +1,37 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"net"
+	"testing"
+
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
+)
+
+type VIPSuite struct {
+	suite.Suite
+}
+
+func TestVIPSuite(t *testing.T) {
+	suite.Run(t, new(VIPSuite))
+}
+
+func (suite *VIPSuite) TestVIPMessage() {
+	b, err := vipMessage(&net.Interface{Index: 2, Name: "eth0"}, net.ParseIP("192.168.0.100"))
+	suite.Require().NoError(err)
+
+	var msg rtnetlink.AddressMessage
+	suite.Require().NoError(msg.UnmarshalBinary(b))
+
+	suite.Assert().Equal(uint8(unix.AF_INET), msg.Family)
+	suite.Assert().Equal(uint8(32), msg.PrefixLength)
+	suite.Assert().Equal(uint32(2), msg.Index)
+	suite.Assert().Equal(net.IP{192, 168, 0, 100}, msg.Attributes.Local)
+	suite.Assert().Equal(net.IP{192, 168, 0, 100}, msg.Attributes.Address)
+	suite.Assert().Equal("eth0:vip", msg.Attributes.Label)
+}
@@ -0,0 +1,124 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// The netlink multicast groups of the watched changes.
+const (
+	WatchLinks     = 1 << (unix.RTNLGRP_LINK - 1)
+	WatchAddresses = 1<<(unix.RTNLGRP_IPV4_IFADDR-1) | 1<<(unix.RTNLGRP_IPV6_IFADDR-1)
+	WatchRoutes    = 1<<(unix.RTNLGRP_IPV4_ROUTE-1) | 1<<(unix.RTNLGRP_IPV6_ROUTE-1)
+)
+
+// WatchEvent is a change of a link, an address or a route. Exactly one of
+// Link, Address and Route is set.
+type WatchEvent struct {
+	Time    time.Time
+	Deleted bool
+
+	Link    *rtnetlink.LinkMessage
+	Address *rtnetlink.AddressMessage
+	Route   *rtnetlink.RouteMessage
+}
+
+// Watch calls fn with the changes of the links, the addresses and the routes
+// of the netlink multicast groups, until the context is canceled, or fn fails.
+func (n *Networkd) Watch(ctx context.Context, groups uint32, fn func(*WatchEvent) error) error {
+	conn, err := netlink.Dial(unix.NETLINK_ROUTE, &netlink.Config{Groups: groups})
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	// unblock Receive once the context is canceled
+	go func() {
+		select {
+		case <-ctx.Done():
+			// nolint: errcheck
+			conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	for {
+		msgs, err := conn.Receive()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			// the kernel drops the changes which don't fit the receive
+			// buffer, the watcher can't be trusted anymore
+			return fmt.Errorf("failed to receive network changes: %w", err)
+		}
+
+		now := time.Now()
+
+		for _, msg := range msgs {
+			event, err := watchEvent(msg)
+			if err != nil {
+				return err
+			}
+
+			if event == nil {
+				continue
+			}
+
+			event.Time = now
+
+			if err = fn(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchEvent decodes the netlink message, it returns nil for the messages
+// which aren't changes of links, addresses or routes.
+func watchEvent(msg netlink.Message) (*WatchEvent, error) {
+	var (
+		event = &WatchEvent{}
+		m     interface{ UnmarshalBinary([]byte) error }
+	)
+
+	switch msg.Header.Type {
+	case unix.RTM_NEWLINK, unix.RTM_DELLINK:
+		event.Link = &rtnetlink.LinkMessage{}
+		m = event.Link
+	case unix.RTM_NEWADDR, unix.RTM_DELADDR:
+		event.Address = &rtnetlink.AddressMessage{}
+		m = event.Address
+	case unix.RTM_NEWROUTE, unix.RTM_DELROUTE:
+		event.Route = &rtnetlink.RouteMessage{}
+		m = event.Route
+	default:
+		return nil, nil
+	}
+
+	switch msg.Header.Type {
+	case unix.RTM_DELLINK, unix.RTM_DELADDR, unix.RTM_DELROUTE:
+		event.Deleted = true
+	}
+
+	if err := m.UnmarshalBinary(msg.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode network change: %w", err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"net"
+	"testing"
+
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/netlink"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
+)
+
+type WatchSuite struct {
+	suite.Suite
+}
+
+func TestWatchSuite(t *testing.T) {
+	suite.Run(t, new(WatchSuite))
+}
+
+func (suite *WatchSuite) TestWatchEvent() {
+	link := &rtnetlink.LinkMessage{
+		Family: unix.AF_UNSPEC,
+		Index:  2,
+		Flags:  unix.IFF_UP,
+		Attributes: &rtnetlink.LinkAttributes{
+			Name:             "eth0",
+			MTU:              1500,
+			OperationalState: rtnetlink.OperStateDown,
+		},
+	}
+
+	event := suite.event(unix.RTM_NEWLINK, link)
+	suite.Assert().False(event.Deleted)
+	suite.Require().NotNil(event.Link)
+	suite.Assert().Nil(event.Address)
+	suite.Assert().Nil(event.Route)
+	suite.Assert().Equal("eth0", event.Link.Attributes.Name)
+	suite.Assert().Equal(rtnetlink.OperStateDown, event.Link.Attributes.OperationalState)
+
+	// AddressMessage encodes the unset addresses, which it can't decode
+	attrs, err := netlink.MarshalAttributes([]netlink.Attribute{
+		{Type: unix.IFA_ADDRESS, Data: net.ParseIP("192.168.0.10").To4()},
+		{Type: unix.IFA_LOCAL, Data: net.ParseIP("192.168.0.10").To4()},
+	})
+	suite.Require().NoError(err)
+
+	addr := append([]byte{unix.AF_INET, 24, 0, unix.RT_SCOPE_UNIVERSE, 2, 0, 0, 0}, attrs...)
+
+	event = suite.event(unix.RTM_DELADDR, rawMessage(addr))
+	suite.Assert().True(event.Deleted)
+	suite.Require().NotNil(event.Address)
+	suite.Assert().Equal(uint8(24), event.Address.PrefixLength)
+	suite.Assert().Equal(uint32(2), event.Address.Index)
+	suite.Assert().True(net.ParseIP("192.168.0.10").Equal(event.Address.Attributes.Local))
+
+	route := &rtnetlink.RouteMessage{
+		Family:    unix.AF_INET,
+		DstLength: 8,
+		Table:     unix.RT_TABLE_MAIN,
+		Attributes: rtnetlink.RouteAttributes{
+			Dst:      net.ParseIP("10.0.0.0").To4(),
+			Gateway:  net.ParseIP("192.168.0.1").To4(),
+			OutIface: 2,
+		},
+	}
+
+	event = suite.event(unix.RTM_NEWROUTE, route)
+	suite.Assert().False(event.Deleted)
+	suite.Require().NotNil(event.Route)
+	suite.Assert().Equal(uint32(2), event.Route.Attributes.OutIface)
+
+	// other messages aren't changes of links, addresses or routes
+	event, err = watchEvent(netlink.Message{Header: netlink.Header{Type: unix.RTM_NEWNEIGH}})
+	suite.Require().NoError(err)
+	suite.Assert().Nil(event)
+}
+
+func (suite *WatchSuite) event(t uint16, m interface{ MarshalBinary() ([]byte, error) }) *WatchEvent {
+	b, err := m.MarshalBinary()
+	suite.Require().NoError(err)
+
+	event, err := watchEvent(netlink.Message{Header: netlink.Header{Type: netlink.HeaderType(t)}, Data: b})
+	suite.Require().NoError(err)
+	suite.Require().NotNil(event)
+
+	return event
+}
+
+type rawMessage []byte
+
+func (m rawMessage) MarshalBinary() ([]byte, error) {
+	return m, nil
+}
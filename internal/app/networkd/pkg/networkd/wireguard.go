@@ -0,0 +1,221 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/genetlink"
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+)
+
+// Wireguard generic netlink commands and attributes, see
+// include/uapi/linux/wireguard.h.
+const (
+	wgGenlName    = "wireguard"
+	wgGenlVersion = 1
+
+	wgCmdSetDevice = 1
+
+	wgDeviceAIfname     = 2
+	wgDeviceAPrivateKey = 3
+	wgDeviceAFlags      = 5
+	wgDeviceAListenPort = 6
+	wgDeviceAPeers      = 8
+
+	wgDeviceFReplacePeers = 1
+
+	wgPeerAPublicKey                   = 1
+	wgPeerAFlags                       = 3
+	wgPeerAEndpoint                    = 4
+	wgPeerAPersistentKeepaliveInterval = 5
+	wgPeerAAllowedIPs                  = 9
+
+	wgPeerFReplaceAllowedIPs = 2
+
+	wgAllowedIPAFamily   = 1
+	wgAllowedIPAIPAddr   = 2
+	wgAllowedIPACidrMask = 3
+)
+
+// createWireguard creates the Wireguard link unless it already exists. The
+// keys and the peers are configured once the resolvers are known, since the
+// endpoints of the peers might have to be resolved.
+func (n *Networkd) createWireguard(iface *nic.NetworkInterface) (*net.Interface, error) {
+	if link, err := net.InterfaceByName(iface.Name); err == nil {
+		return link, nil
+	}
+
+	log.Printf("creating wireguard %s", iface.Name)
+
+	err := n.NlConn.Link.New(&rtnetlink.LinkMessage{
+		Family: unix.AF_UNSPEC,
+		Attributes: &rtnetlink.LinkAttributes{
+			Name: iface.Name,
+			Info: &rtnetlink.LinkInfo{Kind: "wireguard"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wireguard %s: %w", iface.Name, err)
+	}
+
+	return net.InterfaceByName(iface.Name)
+}
+
+// configureWireguards configures the keys and the peers of the Wireguard
+// interfaces.
+func (n *Networkd) configureWireguards(ifaces []*nic.NetworkInterface) error {
+	var result *multierror.Error
+
+	for _, iface := range ifaces {
+		if iface.Type != nic.Wireguard {
+			continue
+		}
+
+		log.Printf("configuring wireguard %s", iface.Name)
+
+		if err := n.configureWireguard(iface); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to configure wireguard %s: %w", iface.Name, err))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// configureWireguard sets the private key, the listen port and the peers of
+// the Wireguard link, the peers which are no longer configured are removed.
+func (n *Networkd) configureWireguard(iface *nic.NetworkInterface) error {
+	endpoints := make([]*net.UDPAddr, len(iface.WireguardPeers))
+
+	for i, peer := range iface.WireguardPeers {
+		if peer.Endpoint == "" {
+			continue
+		}
+
+		addr, err := net.ResolveUDPAddr("udp", peer.Endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to resolve the endpoint %s: %w", peer.Endpoint, err)
+		}
+
+		endpoints[i] = addr
+	}
+
+	data, err := wireguardMessage(iface, endpoints)
+	if err != nil {
+		return err
+	}
+
+	conn, err := genetlink.Dial(nil)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	family, err := conn.GetFamily(wgGenlName)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Execute(genetlink.Message{
+		Header: genetlink.Header{
+			Command: wgCmdSetDevice,
+			Version: wgGenlVersion,
+		},
+		Data: data,
+	}, family.ID, netlink.Request|netlink.Acknowledge)
+
+	return err
+}
+
+// wireguardMessage encodes the configuration of the Wireguard link, the
+// endpoints are the resolved endpoints of the peers.
+func wireguardMessage(iface *nic.NetworkInterface, endpoints []*net.UDPAddr) ([]byte, error) {
+	ae := netlink.NewAttributeEncoder()
+
+	ae.String(wgDeviceAIfname, iface.Name)
+	ae.Bytes(wgDeviceAPrivateKey, iface.WireguardPrivateKey)
+	ae.Uint16(wgDeviceAListenPort, iface.WireguardListenPort)
+	ae.Uint32(wgDeviceAFlags, wgDeviceFReplacePeers)
+
+	ae.Nested(wgDeviceAPeers, func(nae *netlink.AttributeEncoder) error {
+		for i, peer := range iface.WireguardPeers {
+			peer, endpoint := peer, endpoints[i]
+
+			nae.Nested(uint16(i), func(pae *netlink.AttributeEncoder) error {
+				pae.Bytes(wgPeerAPublicKey, peer.PublicKey)
+				pae.Uint32(wgPeerAFlags, wgPeerFReplaceAllowedIPs)
+				pae.Uint16(wgPeerAPersistentKeepaliveInterval, uint16(peer.PersistentKeepalive.Seconds()))
+
+				if endpoint != nil {
+					pae.Bytes(wgPeerAEndpoint, sockaddr(endpoint))
+				}
+
+				pae.Nested(wgPeerAAllowedIPs, func(aae *netlink.AttributeEncoder) error {
+					for j, ipnet := range peer.AllowedIPs {
+						ipnet := ipnet
+
+						aae.Nested(uint16(j), func(iae *netlink.AttributeEncoder) error {
+							family, ip := ipFamily(ipnet.IP)
+							ones, _ := ipnet.Mask.Size()
+
+							iae.Uint16(wgAllowedIPAFamily, uint16(family))
+							iae.Bytes(wgAllowedIPAIPAddr, ip)
+							iae.Uint8(wgAllowedIPACidrMask, uint8(ones))
+
+							return nil
+						})
+					}
+
+					return nil
+				})
+
+				return nil
+			})
+		}
+
+		return nil
+	})
+
+	return ae.Encode()
+}
+
+// sockaddr encodes the address as struct sockaddr_in or struct sockaddr_in6,
+// the port is in network byte order.
+func sockaddr(addr *net.UDPAddr) []byte {
+	family, ip := ipFamily(addr.IP)
+
+	if family == unix.AF_INET {
+		b := make([]byte, unix.SizeofSockaddrInet4)
+		nlenc.PutUint16(b[0:2], unix.AF_INET)
+		binary.BigEndian.PutUint16(b[2:4], uint16(addr.Port))
+		copy(b[4:8], ip)
+
+		return b
+	}
+
+	b := make([]byte, unix.SizeofSockaddrInet6)
+	nlenc.PutUint16(b[0:2], unix.AF_INET6)
+	binary.BigEndian.PutUint16(b[2:4], uint16(addr.Port))
+	copy(b[8:24], ip)
+
+	if addr.Zone != "" {
+		if link, err := net.InterfaceByName(addr.Zone); err == nil {
+			nlenc.PutUint32(b[24:28], uint32(link.Index))
+		}
+	}
+
+	return b
+}
@@ -0,0 +1,113 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+)
+
+type WireguardSuite struct {
+	suite.Suite
+}
+
+func TestWireguardSuite(t *testing.T) {
+	suite.Run(t, new(WireguardSuite))
+}
+
+func (suite *WireguardSuite) TestWireguardMessage() {
+	_, allowed, err := net.ParseCIDR("10.10.0.0/24")
+	suite.Require().NoError(err)
+
+	iface := &nic.NetworkInterface{
+		Name:                "wg0",
+		WireguardPrivateKey: bytes.Repeat([]byte{1}, nic.WireguardKeyLen),
+		WireguardListenPort: 51820,
+		WireguardPeers: []nic.WireguardPeer{
+			{
+				PublicKey:           bytes.Repeat([]byte{2}, nic.WireguardKeyLen),
+				Endpoint:            "192.168.0.1:51821",
+				AllowedIPs:          []*net.IPNet{allowed},
+				PersistentKeepalive: 25 * time.Second,
+			},
+			{
+				PublicKey: bytes.Repeat([]byte{3}, nic.WireguardKeyLen),
+			},
+		},
+	}
+
+	endpoints := []*net.UDPAddr{{IP: net.ParseIP("192.168.0.1"), Port: 51821}, nil}
+
+	b, err := wireguardMessage(iface, endpoints)
+	suite.Require().NoError(err)
+
+	attrs := suite.attributes(b)
+
+	suite.Assert().Equal("wg0\x00", string(attrs[wgDeviceAIfname]))
+	suite.Assert().Equal(iface.WireguardPrivateKey, attrs[wgDeviceAPrivateKey])
+	suite.Assert().Equal(uint16(51820), nlenc.Uint16(attrs[wgDeviceAListenPort]))
+	suite.Assert().Equal(uint32(wgDeviceFReplacePeers), nlenc.Uint32(attrs[wgDeviceAFlags]))
+
+	peers := suite.attributes(attrs[wgDeviceAPeers])
+	suite.Require().Len(peers, 2)
+
+	peer := suite.attributes(peers[0])
+	suite.Assert().Equal(iface.WireguardPeers[0].PublicKey, peer[wgPeerAPublicKey])
+	suite.Assert().Equal(uint32(wgPeerFReplaceAllowedIPs), nlenc.Uint32(peer[wgPeerAFlags]))
+	suite.Assert().Equal(uint16(25), nlenc.Uint16(peer[wgPeerAPersistentKeepaliveInterval]))
+
+	endpoint := peer[wgPeerAEndpoint]
+	suite.Require().Len(endpoint, unix.SizeofSockaddrInet4)
+	suite.Assert().Equal(uint16(unix.AF_INET), nlenc.Uint16(endpoint[0:2]))
+	// the port is in network byte order
+	suite.Assert().Equal([]byte{0xca, 0x6d}, endpoint[2:4])
+	suite.Assert().Equal([]byte{192, 168, 0, 1}, endpoint[4:8])
+
+	allowedIPs := suite.attributes(peer[wgPeerAAllowedIPs])
+	suite.Require().Len(allowedIPs, 1)
+
+	allowedIP := suite.attributes(allowedIPs[0])
+	suite.Assert().Equal(uint16(unix.AF_INET), nlenc.Uint16(allowedIP[wgAllowedIPAFamily]))
+	suite.Assert().Equal([]byte{10, 10, 0, 0}, allowedIP[wgAllowedIPAIPAddr])
+	suite.Assert().Equal([]byte{24}, allowedIP[wgAllowedIPACidrMask])
+
+	// peers without an endpoint wait for the peer to connect
+	peer = suite.attributes(peers[1])
+	suite.Assert().NotContains(peer, uint16(wgPeerAEndpoint))
+	suite.Assert().Empty(suite.attributes(peer[wgPeerAAllowedIPs]))
+}
+
+func (suite *WireguardSuite) TestSockaddrIPv6() {
+	b := sockaddr(&net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 51820})
+
+	suite.Require().Len(b, unix.SizeofSockaddrInet6)
+	suite.Assert().Equal(uint16(unix.AF_INET6), nlenc.Uint16(b[0:2]))
+	suite.Assert().Equal([]byte{0xca, 0x6c}, b[2:4])
+	suite.Assert().Equal(net.ParseIP("2001:db8::1"), net.IP(b[8:24]))
+}
+
+func (suite *WireguardSuite) attributes(b []byte) map[uint16][]byte {
+	ad, err := netlink.NewAttributeDecoder(b)
+	suite.Require().NoError(err)
+
+	attrs := map[uint16][]byte{}
+
+	for ad.Next() {
+		attrs[ad.Type()] = ad.Bytes()
+	}
+
+	suite.Require().NoError(ad.Err())
+
+	return attrs
+}
@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nic
+
+import (
+	"fmt"
+)
+
+// BondMode8023AD is the 802.3ad (LACP) bonding mode, the only mode which
+// supports the LACP rate option.
+const BondMode8023AD = 4
+
+// https://www.kernel.org/doc/Documentation/networking/bonding.txt
+var (
+	bondModes = map[string]uint8{
+		"balance-rr":    0,
+		"active-backup": 1,
+		"balance-xor":   2,
+		"broadcast":     3,
+		"802.3ad":       BondMode8023AD,
+		"balance-tlb":   5,
+		"balance-alb":   6,
+	}
+
+	bondHashPolicies = map[string]uint8{
+		"layer2":   0,
+		"layer3+4": 1,
+		"layer2+3": 2,
+		"encap2+3": 3,
+		"encap3+4": 4,
+	}
+
+	bondLACPRates = map[string]uint8{
+		"slow": 0,
+		"fast": 1,
+	}
+)
+
+// WithBondMode sets the bonding mode, e.g. 802.3ad.
+func WithBondMode(o string) Option {
+	return func(n *NetworkInterface) (err error) {
+		mode, ok := bondModes[o]
+		if !ok {
+			return fmt.Errorf("unsupported bond mode %q", o)
+		}
+
+		n.BondMode = mode
+
+		return err
+	}
+}
+
+// WithHashPolicy sets the transmit hash policy of the bond.
+func WithHashPolicy(o string) Option {
+	return func(n *NetworkInterface) (err error) {
+		policy, ok := bondHashPolicies[o]
+		if !ok {
+			return fmt.Errorf("unsupported bond hash policy %q", o)
+		}
+
+		n.BondHashPolicy = &policy
+
+		return err
+	}
+}
+
+// WithLACPRate sets the rate at which the LACP partner is asked to send
+// LACPDUs in 802.3ad mode.
+func WithLACPRate(o string) Option {
+	return func(n *NetworkInterface) (err error) {
+		rate, ok := bondLACPRates[o]
+		if !ok {
+			return fmt.Errorf("unsupported bond lacp rate %q", o)
+		}
+
+		n.BondLACPRate = &rate
+
+		return err
+	}
+}
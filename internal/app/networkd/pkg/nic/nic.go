@@ -20,6 +20,8 @@ import (
 const (
 	Bond = iota
 	Single
+	Vlan
+	Wireguard
 
 	// https://tools.ietf.org/html/rfc791
 	MinimumMTU = 68
@@ -36,6 +38,21 @@ type NetworkInterface struct {
 	Index         uint32
 	SubInterfaces []string
 	AddressMethod []address.Addressing
+
+	// BondHashPolicy and BondLACPRate are nil unless they are configured,
+	// the kernel defaults apply then.
+	BondMode       uint8
+	BondHashPolicy *uint8
+	BondLACPRate   *uint8
+
+	Parent string
+	VlanID uint16
+
+	VIP net.IP
+
+	WireguardPrivateKey []byte
+	WireguardListenPort uint16
+	WireguardPeers      []WireguardPeer
 }
 
 // IsIgnored checks the network interface to see if it should be ignored and not configured
@@ -56,7 +73,7 @@ func Create(link *net.Interface, setters ...Option) (*NetworkInterface, error) {
 	// Configure interface with any specified options
 	var result *multierror.Error
 	for _, setter := range setters {
-		result = multierror.Append(setter(iface))
+		result = multierror.Append(result, setter(iface))
 	}
 
 	// TODO: May need to look at switching this around to filter by Interface.HardwareAddr
@@ -68,7 +85,9 @@ func Create(link *net.Interface, setters ...Option) (*NetworkInterface, error) {
 	// If no addressing methods have been configured, default to DHCP
 	// TODO: do we want this behavior or to be explicit with config
 	// so we dont configure every interface be default?
-	if len(iface.AddressMethod) == 0 {
+	// Wireguard interfaces have no DHCP server on the other side of the
+	// tunnel.
+	if len(iface.AddressMethod) == 0 && iface.Type != Wireguard {
 		iface.AddressMethod = append(iface.AddressMethod, &address.DHCP{NetIf: link})
 	}
 
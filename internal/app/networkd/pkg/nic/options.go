@@ -6,6 +6,8 @@ package nic
 
 import (
 	"errors"
+	"fmt"
+	"net"
 
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
 )
@@ -46,7 +48,8 @@ func WithIndex(o uint32) Option {
 	}
 }
 
-// WithType defines how the interface should be configured - bonded or single.
+// WithType defines how the interface should be configured - bonded, single,
+// VLAN or Wireguard.
 func WithType(o int) Option {
 	return func(n *NetworkInterface) (err error) {
 		switch o {
@@ -54,6 +57,10 @@ func WithType(o int) Option {
 			n.Type = Bond
 		case Single:
 			n.Type = Single
+		case Vlan:
+			n.Type = Vlan
+		case Wireguard:
+			n.Type = Wireguard
 		default:
 			return errors.New("unsupported network interface type")
 		}
@@ -91,3 +98,38 @@ func WithAddressing(a address.Addressing) Option {
 		return err
 	}
 }
+
+// WithParent defines the interface a VLAN is created on.
+func WithParent(o string) Option {
+	return func(n *NetworkInterface) (err error) {
+		n.Parent = o
+		return err
+	}
+}
+
+// WithVlanID defines the 802.1Q VLAN ID of the interface.
+func WithVlanID(o uint16) Option {
+	return func(n *NetworkInterface) (err error) {
+		// 0 and 4095 are reserved
+		if o == 0 || o >= 4095 {
+			return fmt.Errorf("vlan id %d is out of acceptable range", o)
+		}
+
+		n.VlanID = o
+		return err
+	}
+}
+
+// WithVIP defines the virtual IPv4 address shared by the control plane nodes
+// on the interface.
+func WithVIP(o string) Option {
+	return func(n *NetworkInterface) (err error) {
+		ip := net.ParseIP(o)
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("invalid virtual ip %q", o)
+		}
+
+		n.VIP = ip.To4()
+		return err
+	}
+}
@@ -0,0 +1,111 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nic
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// WireguardKeyLen is the length of the Wireguard keys in bytes.
+	WireguardKeyLen = 32
+
+	// WireguardMTU is the default MTU of Wireguard interfaces, it leaves room
+	// for the encapsulation in a 1500 bytes packet.
+	WireguardMTU = 1420
+)
+
+// WireguardPeer represents a peer of a Wireguard interface.
+type WireguardPeer struct {
+	PublicKey           []byte
+	Endpoint            string
+	AllowedIPs          []*net.IPNet
+	PersistentKeepalive time.Duration
+}
+
+// WithWireguardPrivateKey sets the base64 encoded private key of the
+// Wireguard interface.
+func WithWireguardPrivateKey(o string) Option {
+	return func(n *NetworkInterface) (err error) {
+		key, err := parseWireguardKey(o)
+		if err != nil {
+			return fmt.Errorf("invalid wireguard private key: %w", err)
+		}
+
+		n.WireguardPrivateKey = key
+
+		return err
+	}
+}
+
+// WithWireguardListenPort sets the UDP port the Wireguard interface listens
+// on, a random port is picked if it is not set.
+func WithWireguardListenPort(o int) Option {
+	return func(n *NetworkInterface) (err error) {
+		if o < 0 || o > 65535 {
+			return fmt.Errorf("wireguard listen port %d is out of acceptable range", o)
+		}
+
+		n.WireguardListenPort = uint16(o)
+
+		return err
+	}
+}
+
+// WithWireguardPeer adds a peer to the Wireguard interface. The public key is
+// base64 encoded, and the endpoint is a host:port pair, which is resolved
+// when the interface is configured.
+func WithWireguardPeer(publicKey, endpoint string, allowedIPs []string, keepalive time.Duration) Option {
+	return func(n *NetworkInterface) (err error) {
+		peer := WireguardPeer{
+			Endpoint:            endpoint,
+			PersistentKeepalive: keepalive,
+		}
+
+		if peer.PublicKey, err = parseWireguardKey(publicKey); err != nil {
+			return fmt.Errorf("invalid wireguard public key %q: %w", publicKey, err)
+		}
+
+		if endpoint != "" {
+			if _, _, err = net.SplitHostPort(endpoint); err != nil {
+				return fmt.Errorf("invalid wireguard endpoint %q: %w", endpoint, err)
+			}
+		}
+
+		if keepalive < 0 || keepalive > 65535*time.Second {
+			return fmt.Errorf("wireguard keepalive %s is out of acceptable range", keepalive)
+		}
+
+		for _, cidr := range allowedIPs {
+			var ipnet *net.IPNet
+
+			if _, ipnet, err = net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid wireguard allowed IPs %q: %w", cidr, err)
+			}
+
+			peer.AllowedIPs = append(peer.AllowedIPs, ipnet)
+		}
+
+		n.WireguardPeers = append(n.WireguardPeers, peer)
+
+		return nil
+	}
+}
+
+func parseWireguardKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) != WireguardKeyLen {
+		return nil, fmt.Errorf("key is %d bytes long, expected %d", len(key), WireguardKeyLen)
+	}
+
+	return key, nil
+}
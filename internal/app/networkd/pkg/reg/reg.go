@@ -9,15 +9,25 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/jsimonetti/rtnetlink"
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
+	yaml "gopkg.in/yaml.v2"
 
 	networkapi "github.com/talos-systems/talos/api/network"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/networkd"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
 )
 
+// defaultApplyTimeout is the time to confirm a network change, unless the
+// request specifies it.
+const defaultApplyTimeout = time.Minute
+
 // Registrator is the concrete type that implements the factory.Registrator and
 // networkapi.NetworkServer interfaces.
 type Registrator struct {
@@ -56,17 +66,7 @@ func (r *Registrator) Routes(ctx context.Context, in *empty.Empty) (reply *netwo
 			continue
 		}
 
-		routes = append(routes, &networkapi.Route{
-			Interface:   ifaceData.Name,
-			Destination: toCIDR(rMesg.Family, rMesg.Attributes.Dst, int(rMesg.DstLength)),
-			Gateway:     toCIDR(rMesg.Family, rMesg.Attributes.Gateway, 32),
-			Metric:      rMesg.Attributes.Priority,
-			Scope:       uint32(rMesg.Scope),
-			Source:      toCIDR(rMesg.Family, rMesg.Attributes.Src, int(rMesg.SrcLength)),
-			Family:      networkapi.AddressFamily(rMesg.Family),
-			Protocol:    networkapi.RouteProtocol(rMesg.Protocol),
-			Flags:       rMesg.Flags,
-		})
+		routes = append(routes, toRoute(&rMesg, ifaceData.Name))
 	}
 
 	return &networkapi.RoutesReply{
@@ -81,9 +81,10 @@ func (r *Registrator) Routes(ctx context.Context, in *empty.Empty) (reply *netwo
 // Interfaces returns the hosts network interfaces and addresses.
 func (r *Registrator) Interfaces(ctx context.Context, in *empty.Empty) (reply *networkapi.InterfacesReply, err error) {
 	var (
-		ifaces  []*net.Interface
-		addrs   []string
-		ifaddrs []*net.IPNet
+		ifaces    []*net.Interface
+		addrs     []string
+		addresses []*networkapi.InterfaceAddress
+		ifaddrs   []*net.IPNet
 	)
 
 	// List out all interfaces/links
@@ -92,10 +93,24 @@ func (r *Registrator) Interfaces(ctx context.Context, in *empty.Empty) (reply *n
 		return reply, err
 	}
 
+	links, err := r.Networkd.NlConn.Link.List()
+	if err != nil {
+		return reply, err
+	}
+
+	operStates := map[uint32]networkapi.OperState{}
+
+	for _, link := range links {
+		if link.Attributes != nil {
+			operStates[link.Index] = networkapi.OperState(link.Attributes.OperationalState)
+		}
+	}
+
 	resp := &networkapi.InterfacesResponse{}
 
 	for _, iface := range ifaces {
 		addrs = []string{}
+		addresses = []*networkapi.InterfaceAddress{}
 		// Gather addresses configured on the given interface
 		// both ipv4 and ipv6
 		for _, fam := range []int{unix.AF_INET, unix.AF_INET6} {
@@ -106,6 +121,10 @@ func (r *Registrator) Interfaces(ctx context.Context, in *empty.Empty) (reply *n
 
 			for _, ifaddr := range ifaddrs {
 				addrs = append(addrs, ifaddr.String())
+				addresses = append(addresses, &networkapi.InterfaceAddress{
+					Address: ifaddr.String(),
+					Family:  networkapi.AddressFamily(fam),
+				})
 			}
 		}
 
@@ -116,6 +135,8 @@ func (r *Registrator) Interfaces(ctx context.Context, in *empty.Empty) (reply *n
 			Hardwareaddr: iface.HardwareAddr.String(),
 			Flags:        networkapi.InterfaceFlags(iface.Flags),
 			Ipaddress:    addrs,
+			Addresses:    addresses,
+			Operstate:    operStates[uint32(iface.Index)],
 		}
 
 		resp.Interfaces = append(resp.Interfaces, ifmsg)
@@ -128,13 +149,247 @@ func (r *Registrator) Interfaces(ctx context.Context, in *empty.Empty) (reply *n
 	}, nil
 }
 
-func toCIDR(family uint8, prefix net.IP, prefixLen int) string {
-	netLen := 32
+// Leases returns the DHCP leases and the recent changes of the leases.
+func (r *Registrator) Leases(ctx context.Context, in *empty.Empty) (reply *networkapi.LeasesReply, err error) {
+	leases, events := r.Networkd.Leases()
+
+	resp := &networkapi.LeasesResponse{}
+
+	for _, lease := range leases {
+		resp.Leases = append(resp.Leases, toLease(lease))
+	}
+
+	for _, event := range events {
+		resp.Events = append(resp.Events, &networkapi.LeaseEvent{
+			Ts:    toTimestamp(event.Time),
+			Lease: toLease(event.Lease),
+			Error: event.Error,
+		})
+	}
 
+	return &networkapi.LeasesReply{
+		Response: []*networkapi.LeasesResponse{
+			resp,
+		},
+	}, nil
+}
+
+// Apply reconfigures the network with the network section of the machine
+// config. The change is rolled back unless it is confirmed within the timeout.
+func (r *Registrator) Apply(ctx context.Context, in *networkapi.ApplyRequest) (reply *networkapi.ApplyReply, err error) {
+	network := &v1alpha1.NetworkConfig{}
+
+	if err = yaml.Unmarshal(in.Network, network); err != nil {
+		return nil, fmt.Errorf("failed to parse the network config: %w", err)
+	}
+
+	timeout := defaultApplyTimeout
+	if in.Timeout != 0 {
+		timeout = time.Duration(in.Timeout) * time.Second
+	}
+
+	changes, err := r.Networkd.Apply(network, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networkapi.ApplyReply{
+		Response: []*networkapi.ApplyResponse{
+			{
+				Changes: changes,
+			},
+		},
+	}, nil
+}
+
+// Confirm confirms the pending network change.
+func (r *Registrator) Confirm(ctx context.Context, in *empty.Empty) (reply *networkapi.ConfirmReply, err error) {
+	if err = r.Networkd.Confirm(); err != nil {
+		return nil, err
+	}
+
+	return &networkapi.ConfirmReply{
+		Response: []*networkapi.ConfirmResponse{
+			{},
+		},
+	}, nil
+}
+
+// Watch streams the changes of the interfaces, the addresses and the routes
+// as they happen.
+func (r *Registrator) Watch(in *networkapi.WatchRequest, srv networkapi.Network_WatchServer) error {
+	types := in.Types
+	if len(types) == 0 {
+		types = []networkapi.WatchType{networkapi.WatchType_LINK, networkapi.WatchType_ADDRESS, networkapi.WatchType_ROUTE}
+	}
+
+	var groups uint32
+
+	for _, t := range types {
+		switch t {
+		case networkapi.WatchType_LINK:
+			groups |= networkd.WatchLinks
+		case networkapi.WatchType_ADDRESS:
+			groups |= networkd.WatchAddresses
+		case networkapi.WatchType_ROUTE:
+			groups |= networkd.WatchRoutes
+		default:
+			return fmt.Errorf("unknown watch type %d", t)
+		}
+	}
+
+	return r.Networkd.Watch(srv.Context(), groups, func(event *networkd.WatchEvent) error {
+		return srv.Send(r.toWatchEvent(event))
+	})
+}
+
+func (r *Registrator) toWatchEvent(event *networkd.WatchEvent) *networkapi.WatchEvent {
+	e := &networkapi.WatchEvent{
+		Ts:     toTimestamp(event.Time),
+		Action: networkapi.WatchAction_UPDATE,
+	}
+
+	if event.Deleted {
+		e.Action = networkapi.WatchAction_DELETE
+	}
+
+	switch {
+	case event.Link != nil:
+		e.Type = networkapi.WatchType_LINK
+		e.Interface = toInterface(event.Link)
+	case event.Address != nil:
+		e.Type = networkapi.WatchType_ADDRESS
+		e.Interface = &networkapi.Interface{
+			Index: event.Address.Index,
+			Name:  r.linkName(event.Address.Index),
+		}
+		e.Address = toAddress(event.Address)
+	case event.Route != nil:
+		e.Type = networkapi.WatchType_ROUTE
+		e.Route = toRoute(event.Route, r.linkName(event.Route.Attributes.OutIface))
+	}
+
+	return e
+}
+
+// linkName returns the name of the link, or an empty name if the link is
+// gone.
+func (r *Registrator) linkName(index uint32) string {
+	link, err := r.Networkd.Conn.LinkByIndex(int(index))
+	if err != nil {
+		return ""
+	}
+
+	return link.Name
+}
+
+func toInterface(link *rtnetlink.LinkMessage) *networkapi.Interface {
+	iface := &networkapi.Interface{
+		Index: link.Index,
+		Flags: networkapi.InterfaceFlags(linkFlags(link.Flags)),
+	}
+
+	if link.Attributes != nil {
+		iface.Mtu = link.Attributes.MTU
+		iface.Name = link.Attributes.Name
+		iface.Hardwareaddr = link.Attributes.Address.String()
+		iface.Operstate = networkapi.OperState(link.Attributes.OperationalState)
+	}
+
+	return iface
+}
+
+// linkFlags converts the flags of the link like the net package does.
+func linkFlags(flags uint32) net.Flags {
+	var f net.Flags
+
+	for iff, flag := range map[uint32]net.Flags{
+		unix.IFF_UP:          net.FlagUp,
+		unix.IFF_BROADCAST:   net.FlagBroadcast,
+		unix.IFF_LOOPBACK:    net.FlagLoopback,
+		unix.IFF_POINTOPOINT: net.FlagPointToPoint,
+		unix.IFF_MULTICAST:   net.FlagMulticast,
+	} {
+		if flags&iff != 0 {
+			f |= flag
+		}
+	}
+
+	return f
+}
+
+func toAddress(addr *rtnetlink.AddressMessage) *networkapi.InterfaceAddress {
+	// the address of a point-to-point link is the address of the peer
+	ip := addr.Attributes.Local
+	if ip == nil {
+		ip = addr.Attributes.Address
+	}
+
+	return &networkapi.InterfaceAddress{
+		Address: toCIDR(addr.Family, ip, int(addr.PrefixLength)),
+		Family:  networkapi.AddressFamily(addr.Family),
+	}
+}
+
+func toRoute(route *rtnetlink.RouteMessage, iface string) *networkapi.Route {
+	return &networkapi.Route{
+		Interface:   iface,
+		Destination: toCIDR(route.Family, route.Attributes.Dst, int(route.DstLength)),
+		Gateway:     toCIDR(route.Family, route.Attributes.Gateway, hostLength(route.Family)),
+		Metric:      route.Attributes.Priority,
+		Scope:       uint32(route.Scope),
+		Source:      toCIDR(route.Family, route.Attributes.Src, int(route.SrcLength)),
+		Family:      networkapi.AddressFamily(route.Family),
+		Protocol:    networkapi.RouteProtocol(route.Protocol),
+		Flags:       route.Flags,
+	}
+}
+
+func toLease(lease networkd.Lease) *networkapi.Lease {
+	l := &networkapi.Lease{
+		Interface: lease.Interface,
+		State:     networkapi.LeaseState(lease.State),
+		Acquired:  toTimestamp(lease.Acquired),
+		Renew:     toTimestamp(lease.Renew),
+		Rebind:    toTimestamp(lease.Rebind),
+		Expiry:    toTimestamp(lease.Expiry),
+	}
+
+	if lease.Address != nil {
+		l.Address = lease.Address.String()
+	}
+
+	if lease.Server != nil {
+		l.Server = lease.Server.String()
+	}
+
+	return l
+}
+
+// toTimestamp converts the time, the zero time is left unset.
+func toTimestamp(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	// nolint: errcheck
+	ts, _ := ptypes.TimestampProto(t)
+
+	return ts
+}
+
+// hostLength returns the prefix length of a single address of the family.
+func hostLength(family uint8) int {
 	if family == unix.AF_INET6 {
-		netLen = 128
+		return 128
 	}
 
+	return 32
+}
+
+func toCIDR(family uint8, prefix net.IP, prefixLen int) string {
+	netLen := hostLength(family)
+
 	// Set a friendly readable value instead of "<nil>"
 	if prefix == nil {
 		switch family {
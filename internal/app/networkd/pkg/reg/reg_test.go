@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/jsimonetti/rtnetlink"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
@@ -74,6 +75,27 @@ func (suite *NetworkdSuite) TestInterfaces() {
 	suite.Assert().Greater(len(resp.Response[0].Interfaces), 0)
 }
 
+// nolint: dupl
+func (suite *NetworkdSuite) TestLeases() {
+	server, listener := suite.fakeNetworkdRPC()
+
+	// nolint: errcheck
+	defer os.Remove(listener.Addr().String())
+
+	defer server.Stop()
+
+	// nolint: errcheck
+	go server.Serve(listener)
+
+	conn, err := grpc.Dial(fmt.Sprintf("%s://%s", "unix", listener.Addr().String()), grpc.WithInsecure())
+	suite.Assert().NoError(err)
+
+	nClient := networkapi.NewNetworkClient(conn)
+	resp, err := nClient.Leases(context.Background(), &empty.Empty{})
+	suite.Assert().NoError(err)
+	suite.Assert().Len(resp.Response, 1)
+}
+
 func (suite *NetworkdSuite) fakeNetworkdRPC() (*grpc.Server, net.Listener) {
 	// Create networkd instance
 	n, err := networkd.New()
@@ -99,4 +121,37 @@ func (suite *NetworkdSuite) TestToCIDR() {
 	suite.Assert().Equal(toCIDR(unix.AF_INET6, net.ParseIP("2001:db8::"), 16), "2001:db8::/16")
 	suite.Assert().Equal(toCIDR(unix.AF_INET, nil, 0), "0.0.0.0/0")
 	suite.Assert().Equal(toCIDR(unix.AF_INET6, nil, 0), "::/0")
+	suite.Assert().Equal(toCIDR(unix.AF_INET6, net.ParseIP("fe80::1"), hostLength(unix.AF_INET6)), "fe80::1/128")
+}
+
+func (suite *NetworkdSuite) TestLinkFlags() {
+	suite.Assert().Equal(net.FlagUp|net.FlagBroadcast|net.FlagMulticast, linkFlags(unix.IFF_UP|unix.IFF_BROADCAST|unix.IFF_MULTICAST|unix.IFF_RUNNING))
+	suite.Assert().Equal(net.FlagUp|net.FlagLoopback, linkFlags(unix.IFF_UP|unix.IFF_LOOPBACK))
+	suite.Assert().Equal(net.Flags(0), linkFlags(0))
+}
+
+func (suite *NetworkdSuite) TestToAddress() {
+	addr := &rtnetlink.AddressMessage{
+		Family:       unix.AF_INET,
+		PrefixLength: 24,
+		Attributes: rtnetlink.AddressAttributes{
+			Address: net.ParseIP("192.168.0.10"),
+			Local:   net.ParseIP("192.168.0.10"),
+		},
+	}
+	suite.Assert().Equal(&networkapi.InterfaceAddress{Address: "192.168.0.10/24", Family: networkapi.AddressFamily_AF_INET}, toAddress(addr))
+
+	// point-to-point links
+	addr.PrefixLength = 32
+	addr.Attributes.Address = net.ParseIP("10.0.0.2")
+	suite.Assert().Equal("192.168.0.10/32", toAddress(addr).Address)
+
+	addr = &rtnetlink.AddressMessage{
+		Family:       unix.AF_INET6,
+		PrefixLength: 64,
+		Attributes: rtnetlink.AddressAttributes{
+			Address: net.ParseIP("2001:db8::10"),
+		},
+	}
+	suite.Assert().Equal(&networkapi.InterfaceAddress{Address: "2001:db8::10/64", Family: networkapi.AddressFamily_AF_INET6}, toAddress(addr))
 }
@@ -35,14 +35,14 @@ func init() {
 	flag.Parse()
 }
 
-// New instantiates a new ntp instance against a given server
+// New instantiates a new ntp instance against the given servers
 // If no servers are specified, the default will be used
 func main() {
 	if err := startup.RandSeed(); err != nil {
 		log.Fatalf("startup: %v", err)
 	}
 
-	server := DefaultServer
+	servers := []string{DefaultServer}
 
 	content, err := config.FromFile(*configPath)
 	if err != nil {
@@ -55,13 +55,15 @@ func main() {
 	}
 
 	// Check if ntp servers are defined
-	// Support for only a single time server currently
 	if len(config.Machine().Time().Servers()) >= 1 {
-		server = config.Machine().Time().Servers()[0]
+		servers = config.Machine().Time().Servers()
 	}
 
 	n, err := ntp.NewNTPClient(
-		ntp.WithServer(server),
+		ntp.WithServers(servers...),
+		ntp.WithSyncedPath(constants.TimeSyncedPath),
+		ntp.WithLastKnownTimePath(constants.LastKnownTimePath),
+		ntp.WithRTCPath(constants.RTCPath),
 	)
 	if err != nil {
 		log.Fatalf("failed to create ntp client: %v", err)
@@ -0,0 +1,115 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package ntp
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// synced records a successful synchronization. The failures are only
+// logged, the time is synchronized anyway.
+func (n *NTP) synced() {
+	if n.SyncedPath != "" {
+		if err := ioutil.WriteFile(n.SyncedPath, nil, 0644); err != nil {
+			log.Printf("failed to mark the time as synchronized: %v", err)
+		}
+	}
+
+	if n.LastKnownTimePath != "" {
+		if err := SaveLastKnownTime(n.LastKnownTimePath, time.Now()); err != nil {
+			log.Printf("failed to save the last known time: %v", err)
+		}
+	}
+
+	if n.RTCPath != "" {
+		if err := setRTC(n.RTCPath); err != nil {
+			log.Printf("failed to sync the hardware clock: %v", err)
+		}
+	}
+}
+
+// SaveLastKnownTime saves the time as the modification time of the file.
+func SaveLastKnownTime(path string, t time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Chtimes(path, t, t)
+}
+
+// LastKnownTime returns the time saved by SaveLastKnownTime.
+func LastKnownTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
+// verificationTime is the time the certificates of the NTS-KE servers are
+// verified at. Until the time is synchronized the clock may be wrong, it is
+// never before the last known time.
+func (n *NTP) verificationTime() time.Time {
+	now := time.Now()
+
+	if n.LastKnownTimePath == "" {
+		return now
+	}
+
+	if last, err := LastKnownTime(n.LastKnownTimePath); err == nil && last.After(now) {
+		return last
+	}
+
+	return now
+}
+
+// setRTC sets the hardware clock, which keeps UTC, to the system time. The
+// hardware clock only has a resolution of a second, it is set on the next
+// second boundary.
+func setRTC(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer f.Close()
+
+	next := time.Now().Truncate(time.Second).Add(time.Second)
+	time.Sleep(time.Until(next))
+
+	return unix.IoctlSetRTCTime(int(f.Fd()), toRTCTime(next))
+}
+
+func toRTCTime(t time.Time) *unix.RTCTime {
+	t = t.UTC()
+
+	return &unix.RTCTime{
+		Sec:  int32(t.Second()),
+		Min:  int32(t.Minute()),
+		Hour: int32(t.Hour()),
+		Mday: int32(t.Day()),
+		Mon:  int32(t.Month() - 1),
+		Year: int32(t.Year() - 1900),
+		Wday: int32(t.Weekday()),
+		Yday: int32(t.YearDay() - 1),
+	}
+}
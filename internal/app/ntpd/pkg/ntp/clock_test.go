@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package ntp
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
+)
+
+type ClockSuite struct {
+	suite.Suite
+
+	tmpDir string
+}
+
+func TestClockSuite(t *testing.T) {
+	suite.Run(t, new(ClockSuite))
+}
+
+func (suite *ClockSuite) SetupTest() {
+	var err error
+
+	suite.tmpDir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+}
+
+func (suite *ClockSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.tmpDir))
+}
+
+func (suite *ClockSuite) TestLastKnownTime() {
+	path := filepath.Join(suite.tmpDir, "time", "last-known")
+
+	_, err := LastKnownTime(path)
+	suite.Assert().True(os.IsNotExist(err))
+
+	for _, t := range []time.Time{time.Unix(1573603200, 0), time.Unix(1573689600, 0)} {
+		suite.Require().NoError(SaveLastKnownTime(path, t))
+
+		last, err := LastKnownTime(path)
+		suite.Require().NoError(err)
+		suite.Assert().True(t.Equal(last))
+	}
+}
+
+func (suite *ClockSuite) TestSynced() {
+	n := &NTP{
+		SyncedPath:        filepath.Join(suite.tmpDir, "synced"),
+		LastKnownTimePath: filepath.Join(suite.tmpDir, "time", "last-known"),
+	}
+
+	n.synced()
+
+	_, err := os.Stat(n.SyncedPath)
+	suite.Assert().NoError(err)
+
+	last, err := LastKnownTime(n.LastKnownTimePath)
+	suite.Require().NoError(err)
+	suite.Assert().WithinDuration(time.Now(), last, time.Minute)
+}
+
+func (suite *ClockSuite) TestVerificationTime() {
+	n := &NTP{LastKnownTimePath: filepath.Join(suite.tmpDir, "time", "last-known")}
+
+	suite.Assert().WithinDuration(time.Now(), n.verificationTime(), time.Minute)
+
+	last := time.Now().Add(365 * 24 * time.Hour)
+	suite.Require().NoError(SaveLastKnownTime(n.LastKnownTimePath, last))
+
+	suite.Assert().WithinDuration(last, n.verificationTime(), time.Second)
+
+	suite.Require().NoError(SaveLastKnownTime(n.LastKnownTimePath, time.Now().Add(-365*24*time.Hour)))
+
+	suite.Assert().WithinDuration(time.Now(), n.verificationTime(), time.Minute)
+}
+
+func (suite *ClockSuite) TestToRTCTime() {
+	t := time.Date(2019, time.November, 13, 1, 2, 3, 0, time.FixedZone("", 3600))
+
+	suite.Assert().Equal(&unix.RTCTime{
+		Sec:  3,
+		Min:  2,
+		Hour: 0,
+		Mday: 13,
+		Mon:  10,
+		Year: 119,
+		Wday: 3,
+		Yday: 316,
+	}, toRTCTime(t))
+}
@@ -5,27 +5,51 @@
 package ntp
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/beevik/ntp"
 	"github.com/hashicorp/go-multierror"
+	"golang.org/x/sys/unix"
 
 	"github.com/talos-systems/talos/pkg/retry"
 )
 
-// NTP contains a server address
+const (
+	// stepThreshold is the offset above which the clock is stepped, smaller
+	// offsets are slewed.
+	stepThreshold = 128 * time.Millisecond
+
+	// adjOffsetSingleshot is the adjtime mode of adjtimex, which slews the
+	// clock by the offset in microseconds.
+	adjOffsetSingleshot = 0x8001
+)
+
+// NTP contains the server addresses and their state
 type NTP struct {
-	Server  string
+	Servers []string
 	MinPoll time.Duration
 	MaxPoll time.Duration
+
+	// SyncedPath, LastKnownTimePath and RTCPath are updated after a
+	// successful synchronization, unless they are empty.
+	SyncedPath        string
+	LastKnownTimePath string
+	RTCPath           string
+
+	mu      sync.Mutex
+	sources []*source
 }
 
 // NewNTPClient instantiates a new ntp client for the
-// specified server.
+// specified servers.
 func NewNTPClient(opts ...Option) (*NTP, error) {
 	ntp := defaultOptions()
 
@@ -34,6 +58,12 @@ func NewNTPClient(opts ...Option) (*NTP, error) {
 		result = multierror.Append(setter(ntp))
 	}
 
+	tlsConfig := &tls.Config{Time: ntp.verificationTime}
+
+	for _, server := range ntp.Servers {
+		ntp.sources = append(ntp.sources, newSource(server, tlsConfig))
+	}
+
 	return ntp, result.ErrorOrNil()
 }
 
@@ -58,19 +88,16 @@ func (n *NTP) Daemon() (err error) {
 	}
 }
 
-// Query polls the ntp server and verifies a successful response.
+// Query polls the ntp servers and returns the response of the selected
+// server.
 func (n *NTP) Query() (resp *ntp.Response, err error) {
 	err = retry.Constant(n.MaxPoll, retry.WithUnits(n.MinPoll), retry.WithJitter(250*time.Millisecond)).Retry(func() error {
-		resp, err = ntp.Query(n.Server)
+		resp, err = n.poll()
 		if err != nil {
 			log.Printf("query error: %v", err)
 			return retry.ExpectedError(err)
 		}
 
-		if err = resp.Validate(); err != nil {
-			return retry.UnexpectedError(err)
-		}
-
 		return nil
 	})
 
@@ -81,6 +108,64 @@ func (n *NTP) Query() (resp *ntp.Response, err error) {
 	return resp, nil
 }
 
+// Sources returns the state of the servers.
+func (n *NTP) Sources() []SourceStatus {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	sources := make([]SourceStatus, 0, len(n.sources))
+	for _, s := range n.sources {
+		sources = append(sources, s.status())
+	}
+
+	return sources
+}
+
+// poll queries all of the servers, and selects the server the clock should
+// be synchronized to.
+func (n *NTP) poll() (*ntp.Response, error) {
+	var (
+		wg        sync.WaitGroup
+		responses = make([]*ntp.Response, len(n.sources))
+		errs      = make([]error, len(n.sources))
+	)
+
+	for i, s := range n.sources {
+		wg.Add(1)
+
+		go func(i int, s *source) {
+			defer wg.Done()
+
+			responses[i], errs[i] = s.query()
+		}(i, s)
+	}
+
+	wg.Wait()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var result *multierror.Error
+
+	for i, s := range n.sources {
+		s.update(responses[i], errs[i])
+
+		if errs[i] != nil {
+			result = multierror.Append(result, fmt.Errorf("%s: %w", s.server, errs[i]))
+		}
+	}
+
+	if selected := selectSource(n.sources); selected != nil {
+		return selected.resp, nil
+	}
+
+	if result != nil && len(result.Errors) == len(n.sources) {
+		return nil, result
+	}
+
+	return nil, errors.New("no majority of the servers agrees on the time")
+}
+
 // GetTime returns the current system time.
 func (n *NTP) GetTime() time.Time {
 	return time.Now()
@@ -91,13 +176,15 @@ func (n *NTP) QueryAndSetTime() (err error) {
 	var resp *ntp.Response
 
 	if resp, err = n.Query(); err != nil {
-		return fmt.Errorf("error querying %s for time, %s", n.Server, err)
+		return fmt.Errorf("error querying %s for time, %s", strings.Join(n.Servers, ", "), err)
 	}
 
-	if err = adjustTime(resp.ClockOffset); err != nil {
+	if err = n.adjustTime(resp.ClockOffset); err != nil {
 		return fmt.Errorf("failed to set time, %s", err)
 	}
 
+	n.synced()
+
 	return
 }
 
@@ -110,7 +197,42 @@ func setTime(adjustedTime time.Time) error {
 	return syscall.Settimeofday(&timeval)
 }
 
-// adjustTime adds an offset to the current time.
-func adjustTime(offset time.Duration) error {
-	return setTime(time.Now().Add(offset))
+// adjustTime adds an offset to the current time, small offsets are slewed so
+// that the time doesn't jump.
+func (n *NTP) adjustTime(offset time.Duration) error {
+	if offset > -stepThreshold && offset < stepThreshold {
+		return slewTime(offset)
+	}
+
+	if err := setTime(time.Now().Add(offset)); err != nil {
+		return err
+	}
+
+	n.clearOffsets()
+
+	return nil
+}
+
+// clearOffsets forgets the offsets of the sources, which are relative to the
+// clock before it was stepped.
+func (n *NTP) clearOffsets() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, s := range n.sources {
+		s.offsets = nil
+	}
+}
+
+// slewTime makes the kernel speed up or slow down the clock until the offset
+// is applied.
+func slewTime(offset time.Duration) error {
+	log.Printf("slewing time by %s", offset)
+
+	_, err := unix.Adjtimex(&unix.Timex{
+		Modes:  adjOffsetSingleshot,
+		Offset: int64(offset / time.Microsecond),
+	})
+
+	return err
 }
@@ -32,22 +32,30 @@ func (suite *NtpSuite) TestQuery() {
 }
 
 func (suite *NtpSuite) TestNtpConfig() {
-	server := "time.cloudflare.com"
+	servers := []string{"time.cloudflare.com"}
 
 	// Test unset config, single server config, multiple server config
 	for _, conf := range []runtime.Configurator{&v1alpha1.Config{MachineConfig: &v1alpha1.MachineConfig{}}, sampleConfigSingleServer(), sampleConfigMultipleServers()} {
 		// Check if ntp servers are defined
-		// Support for only a single time server currently
 		if len(conf.Machine().Time().Servers()) >= 1 {
-			server = conf.Machine().Time().Servers()[0]
+			servers = conf.Machine().Time().Servers()
 		}
 
 		n, err := NewNTPClient(
-			WithServer(server),
+			WithServers(servers...),
 		)
 		suite.Assert().NoError(err)
-		suite.Assert().Equal(server, n.Server)
+		suite.Assert().Equal(servers, n.Servers)
+		suite.Assert().Len(n.Sources(), len(servers))
 	}
+
+	_, err := NewNTPClient(WithServers())
+	suite.Assert().Error(err)
+
+	n, err := NewNTPClient(WithServers("pool.ntp.org", "nts://time.cloudflare.com"))
+	suite.Assert().NoError(err)
+	suite.Assert().Nil(n.sources[0].nts)
+	suite.Assert().Equal("time.cloudflare.com", n.sources[1].nts.Server)
 }
 
 func sampleConfigSingleServer() runtime.Configurator {
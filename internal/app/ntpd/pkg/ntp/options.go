@@ -23,7 +23,7 @@ func defaultOptions() *NTP {
 	// defaults for minpoll + maxpoll
 	// http://www.ntp.org/ntpfaq/NTP-s-algo.htm#AEN2082
 	return &NTP{
-		Server:  "pool.ntp.org",
+		Servers: []string{"pool.ntp.org"},
 		MaxPoll: MaxAllowablePoll * time.Second,
 		MinPoll: 64 * time.Second,
 	}
@@ -31,8 +31,16 @@ func defaultOptions() *NTP {
 
 // WithServer configures the ntp client to use the specified server
 func WithServer(o string) Option {
+	return WithServers(o)
+}
+
+// WithServers configures the ntp client to use the specified servers
+func WithServers(o ...string) Option {
 	return func(n *NTP) (err error) {
-		n.Server = o
+		if len(o) == 0 {
+			return fmt.Errorf("at least one server is required")
+		}
+		n.Servers = o
 		return err
 	}
 }
@@ -59,3 +67,30 @@ func WithMinPoll(o int) Option {
 		return err
 	}
 }
+
+// WithSyncedPath configures the ntp client to create the file once the time
+// is synchronized
+func WithSyncedPath(o string) Option {
+	return func(n *NTP) (err error) {
+		n.SyncedPath = o
+		return err
+	}
+}
+
+// WithLastKnownTimePath configures the ntp client to save the time of the
+// last synchronization as the modification time of the file
+func WithLastKnownTimePath(o string) Option {
+	return func(n *NTP) (err error) {
+		n.LastKnownTimePath = o
+		return err
+	}
+}
+
+// WithRTCPath configures the ntp client to set the hardware clock of the
+// device after each synchronization
+func WithRTCPath(o string) Option {
+	return func(n *NTP) (err error) {
+		n.RTCPath = o
+		return err
+	}
+}
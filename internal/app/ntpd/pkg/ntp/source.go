@@ -0,0 +1,282 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package ntp
+
+import (
+	"crypto/tls"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beevik/ntp"
+
+	"github.com/talos-systems/talos/internal/app/ntpd/pkg/nts"
+)
+
+const (
+	// sampleCount is the number of the recent offsets of a source kept to
+	// compute its jitter.
+	sampleCount = 8
+
+	// NTSPrefix marks the servers which are queried with NTS, the address is
+	// the address of the NTS-KE server.
+	NTSPrefix = "nts://"
+)
+
+// SourceStatus is the state of a time server.
+type SourceStatus struct {
+	Server string
+
+	// Offset is the offset of the server clock from the local clock, and
+	// Delay the round trip time, of the last successful query.
+	Offset time.Duration
+	Delay  time.Duration
+	Jitter time.Duration
+
+	Stratum uint8
+
+	// Reach is the reachability register, each bit is the result of one of
+	// the last 8 queries, the lowest bit being the last.
+	Reach uint8
+
+	Selected    bool
+	Falseticker bool
+
+	// Err is the error of the last query.
+	Err error
+}
+
+// source is a time server and the results of its recent queries.
+type source struct {
+	server string
+	nts    *nts.Client
+
+	// resp is the last successful response, the newest offset is first.
+	resp    *ntp.Response
+	offsets []time.Duration
+	reach   uint8
+	err     error
+
+	selected    bool
+	falseticker bool
+}
+
+func newSource(server string, tlsConfig *tls.Config) *source {
+	s := &source{server: server}
+
+	if strings.HasPrefix(server, NTSPrefix) {
+		s.nts = &nts.Client{
+			Server:    strings.TrimPrefix(server, NTSPrefix),
+			TLSConfig: tlsConfig,
+		}
+	}
+
+	return s
+}
+
+// query queries the server and verifies a successful response.
+func (s *source) query() (resp *ntp.Response, err error) {
+	if s.nts != nil {
+		resp, err = s.nts.Query()
+	} else {
+		resp, err = ntp.Query(s.server)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = resp.Validate(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// update records the result of a query of the source.
+func (s *source) update(resp *ntp.Response, err error) {
+	s.reach <<= 1
+	s.err = err
+
+	if err != nil {
+		return
+	}
+
+	s.reach |= 1
+	s.resp = resp
+
+	s.offsets = append([]time.Duration{resp.ClockOffset}, s.offsets...)
+	if len(s.offsets) > sampleCount {
+		s.offsets = s.offsets[:sampleCount]
+	}
+}
+
+// reachable returns whether the last query of the source succeeded.
+func (s *source) reachable() bool {
+	return s.reach&1 != 0
+}
+
+// jitter returns the root mean square of the differences between the recent
+// offsets and the last one.
+func (s *source) jitter() time.Duration {
+	if len(s.offsets) < 2 {
+		return 0
+	}
+
+	var sum float64
+
+	for _, offset := range s.offsets[1:] {
+		d := float64(offset - s.offsets[0])
+		sum += d * d
+	}
+
+	return time.Duration(math.Sqrt(sum / float64(len(s.offsets)-1)))
+}
+
+// distance returns the maximum error of the offset of the source.
+func (s *source) distance() time.Duration {
+	return s.resp.RootDistance + s.jitter()
+}
+
+func (s *source) status() SourceStatus {
+	status := SourceStatus{
+		Server:      s.server,
+		Reach:       s.reach,
+		Selected:    s.selected,
+		Falseticker: s.falseticker,
+		Err:         s.err,
+	}
+
+	if s.resp != nil {
+		status.Offset = s.resp.ClockOffset
+		status.Delay = s.resp.RTT
+		status.Jitter = s.jitter()
+		status.Stratum = s.resp.Stratum
+	}
+
+	return status
+}
+
+// selectSource marks the falsetickers, the reachable sources whose offset is
+// outside of the intersection of the majority of the sources, and returns
+// the truechimer with the lowest stratum and distance. It returns nil if no
+// majority of the sources agrees on the time.
+func selectSource(sources []*source) *source {
+	candidates := make([]*source, 0, len(sources))
+
+	for _, s := range sources {
+		s.selected = false
+		s.falseticker = false
+
+		if s.reachable() {
+			candidates = append(candidates, s)
+		}
+	}
+
+	low, high, ok := intersection(candidates)
+	if !ok {
+		return nil
+	}
+
+	var best *source
+
+	for _, s := range candidates {
+		if s.resp.ClockOffset < low || s.resp.ClockOffset > high {
+			s.falseticker = true
+			continue
+		}
+
+		if best == nil || s.resp.Stratum < best.resp.Stratum || (s.resp.Stratum == best.resp.Stratum && s.distance() < best.distance()) {
+			best = s
+		}
+	}
+
+	if best != nil {
+		best.selected = true
+	}
+
+	return best
+}
+
+type endpoint struct {
+	offset time.Duration
+	// kind is -1 for the lower end of an interval, 0 for its midpoint and 1
+	// for its upper end
+	kind int
+}
+
+// intersection returns the smallest interval which contains the offsets of
+// a majority of the sources, as the intersection algorithm of RFC 5905
+// does. The interval of a source is its offset plus or minus its distance.
+func intersection(sources []*source) (low, high time.Duration, ok bool) {
+	n := len(sources)
+	endpoints := make([]endpoint, 0, 3*n)
+
+	for _, s := range sources {
+		offset, distance := s.resp.ClockOffset, s.distance()
+
+		endpoints = append(endpoints,
+			endpoint{offset - distance, -1},
+			endpoint{offset, 0},
+			endpoint{offset + distance, 1},
+		)
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].offset == endpoints[j].offset {
+			return endpoints[i].kind < endpoints[j].kind
+		}
+
+		return endpoints[i].offset < endpoints[j].offset
+	})
+
+	// allow is the number of falsetickers, which must be a minority
+	for allow := 0; 2*allow < n; allow++ {
+		var (
+			found, chime      int
+			lowFound, hiFound bool
+		)
+
+		for _, e := range endpoints {
+			chime -= e.kind
+			if chime >= n-allow {
+				low, lowFound = e.offset, true
+				break
+			}
+
+			if e.kind == 0 {
+				found++
+			}
+		}
+
+		chime = 0
+
+		for i := len(endpoints) - 1; i >= 0; i-- {
+			e := endpoints[i]
+
+			chime += e.kind
+			if chime >= n-allow {
+				high, hiFound = e.offset, true
+				break
+			}
+
+			if e.kind == 0 {
+				found++
+			}
+		}
+
+		// the midpoints outside of the interval are falsetickers too
+		if found > allow || !lowFound || !hiFound {
+			continue
+		}
+
+		if low <= high {
+			return low, high, true
+		}
+	}
+
+	return 0, 0, false
+}
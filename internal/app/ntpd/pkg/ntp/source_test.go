@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package ntp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/beevik/ntp"
+	"github.com/stretchr/testify/suite"
+)
+
+type SourceSuite struct {
+	suite.Suite
+}
+
+func TestSourceSuite(t *testing.T) {
+	suite.Run(t, new(SourceSuite))
+}
+
+func (suite *SourceSuite) TestUpdate() {
+	s := &source{server: "a"}
+
+	for i := 0; i < 10; i++ {
+		s.update(response(time.Duration(i)*time.Millisecond, 10*time.Millisecond, 2), nil)
+	}
+
+	suite.Assert().Equal(uint8(0xff), s.reach)
+	suite.Assert().Len(s.offsets, sampleCount)
+	suite.Assert().Equal(9*time.Millisecond, s.offsets[0])
+
+	s.update(nil, errors.New("timeout"))
+
+	suite.Assert().Equal(uint8(0xfe), s.reach)
+	suite.Assert().False(s.reachable())
+
+	status := s.status()
+	suite.Assert().Equal(9*time.Millisecond, status.Offset)
+	suite.Assert().EqualError(status.Err, "timeout")
+}
+
+func (suite *SourceSuite) TestJitter() {
+	s := &source{server: "a"}
+	suite.Assert().Equal(time.Duration(0), s.jitter())
+
+	s.update(response(3*time.Millisecond, 0, 2), nil)
+	suite.Assert().Equal(time.Duration(0), s.jitter())
+
+	s.update(response(time.Millisecond, 0, 2), nil)
+	s.update(response(3*time.Millisecond, 0, 2), nil)
+
+	// differences of 2ms and 0ms from the last offset
+	suite.Assert().Equal(time.Duration(1414213), s.jitter())
+}
+
+func (suite *SourceSuite) TestClearOffsets() {
+	a := reachable("a", -time.Hour, 0, 2)
+	b := reachable("b", -time.Hour, 0, 2)
+	n := &NTP{sources: []*source{a, b}}
+
+	n.clearOffsets()
+
+	// the offsets after the step are small again, the jitter only depends on
+	// them
+	a.update(response(time.Millisecond, 0, 2), nil)
+	b.update(response(time.Millisecond, 0, 2), nil)
+
+	for _, s := range n.sources {
+		suite.Assert().Len(s.offsets, 1)
+		suite.Assert().Equal(time.Duration(0), s.jitter())
+	}
+}
+
+func (suite *SourceSuite) TestSelectSource() {
+	a := reachable("a", 10*time.Millisecond, 5*time.Millisecond, 2)
+	b := reachable("b", 12*time.Millisecond, 5*time.Millisecond, 1)
+	c := reachable("c", 11*time.Millisecond, 5*time.Millisecond, 2)
+	falseticker := reachable("d", 2*time.Second, 5*time.Millisecond, 1)
+	unreachable := &source{server: "e"}
+
+	suite.Assert().Equal(b, selectSource([]*source{a, b, c, falseticker, unreachable}))
+
+	suite.Assert().True(b.selected)
+	suite.Assert().False(a.selected)
+	suite.Assert().True(falseticker.falseticker)
+	suite.Assert().False(a.falseticker)
+	suite.Assert().False(unreachable.falseticker)
+
+	// the lowest distance wins among the same stratum
+	near := reachable("f", 11*time.Millisecond, time.Millisecond, 2)
+
+	suite.Assert().Equal(near, selectSource([]*source{a, c, near}))
+}
+
+func (suite *SourceSuite) TestSelectSourceNoMajority() {
+	a := reachable("a", 0, 5*time.Millisecond, 1)
+	b := reachable("b", time.Second, 5*time.Millisecond, 1)
+
+	suite.Assert().Nil(selectSource([]*source{a, b}))
+	suite.Assert().Nil(selectSource([]*source{{server: "c"}}))
+	suite.Assert().Nil(selectSource(nil))
+
+	// a single source is its own majority
+	suite.Assert().Equal(a, selectSource([]*source{a}))
+}
+
+func response(offset, distance time.Duration, stratum uint8) *ntp.Response {
+	return &ntp.Response{
+		ClockOffset:  offset,
+		RootDistance: distance,
+		Stratum:      stratum,
+	}
+}
+
+func reachable(server string, offset, distance time.Duration, stratum uint8) *source {
+	s := &source{server: server}
+	s.update(response(offset, distance, stratum), nil)
+
+	return s
+}
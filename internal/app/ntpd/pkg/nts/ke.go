@@ -0,0 +1,235 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nts
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultKEPort is the port of the NTS-KE servers.
+	DefaultKEPort = "4460"
+
+	// DefaultNTPPort is the port of the NTP servers, unless the NTS-KE server
+	// negotiates another one.
+	DefaultNTPPort = "123"
+
+	alpn          = "ntske/1"
+	exporterLabel = "EXPORTER-network-time-security"
+
+	// protocolNTPv4 is the NTS next protocol identifier of NTPv4.
+	protocolNTPv4 = 0
+)
+
+// The NTS-KE record types (RFC 8915 section 4).
+const (
+	recordEndOfMessage = 0
+	recordNextProtocol = 1
+	recordError        = 2
+	recordWarning      = 3
+	recordAEAD         = 4
+	recordNewCookie    = 5
+	recordServer       = 6
+	recordPort         = 7
+
+	recordCritical = 0x8000
+)
+
+// session holds the keys and the cookies of an NTP server, negotiated with
+// its NTS-KE server.
+type session struct {
+	// server is the address of the NTP server
+	server string
+
+	c2s cipher.AEAD
+	s2c cipher.AEAD
+
+	cookies [][]byte
+}
+
+// keyExchange negotiates NTPv4 with AEAD_AES_SIV_CMAC_256 with the NTS-KE
+// server over TLS 1.3, and returns the keys exported from the TLS session
+// and the cookies the server sends.
+func keyExchange(server string, tlsConfig *tls.Config, timeout time.Duration) (*session, error) {
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		host, port = server, DefaultKEPort
+	}
+
+	config := &tls.Config{}
+	if tlsConfig != nil {
+		config = tlsConfig.Clone()
+	}
+
+	if config.ServerName == "" {
+		config.ServerName = host
+	}
+
+	config.MinVersion = tls.VersionTLS13
+	config.NextProtos = []string{alpn}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", net.JoinHostPort(host, port), config)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	state := conn.ConnectionState()
+	if state.NegotiatedProtocol != alpn {
+		return nil, fmt.Errorf("server didn't negotiate %s", alpn)
+	}
+
+	var req []byte
+	req = appendRecord(req, recordNextProtocol, true, uint16Body(protocolNTPv4))
+	req = appendRecord(req, recordAEAD, true, uint16Body(AEADAESSIVCMAC256))
+	req = appendRecord(req, recordEndOfMessage, true, nil)
+
+	if _, err = conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	s := &session{}
+
+	if err = s.readResponse(bufio.NewReader(conn), host); err != nil {
+		return nil, err
+	}
+
+	if s.c2s, err = exportKey(&state, 0); err != nil {
+		return nil, err
+	}
+
+	if s.s2c, err = exportKey(&state, 1); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// readResponse reads the records of the NTS-KE response until the end of
+// the message.
+func (s *session) readResponse(r io.Reader, host string) error {
+	var (
+		protocol, aead bool
+		port           = DefaultNTPPort
+	)
+
+	for {
+		typ, critical, body, err := readRecord(r)
+		if err != nil {
+			return err
+		}
+
+		switch typ {
+		case recordEndOfMessage:
+			if !protocol || !aead {
+				return errors.New("server didn't negotiate NTPv4 with AEAD_AES_SIV_CMAC_256")
+			}
+
+			if len(s.cookies) == 0 {
+				return errors.New("server didn't send any cookie")
+			}
+
+			s.server = net.JoinHostPort(host, port)
+
+			return nil
+		case recordNextProtocol:
+			if len(body) != 2 || binary.BigEndian.Uint16(body) != protocolNTPv4 {
+				return errors.New("server doesn't support NTPv4")
+			}
+
+			protocol = true
+		case recordError:
+			if len(body) != 2 {
+				return errors.New("server error")
+			}
+
+			return fmt.Errorf("server error %d", binary.BigEndian.Uint16(body))
+		case recordWarning:
+		case recordAEAD:
+			if len(body) != 2 || binary.BigEndian.Uint16(body) != AEADAESSIVCMAC256 {
+				return errors.New("server doesn't support AEAD_AES_SIV_CMAC_256")
+			}
+
+			aead = true
+		case recordNewCookie:
+			s.cookies = append(s.cookies, body)
+		case recordServer:
+			host = string(body)
+		case recordPort:
+			if len(body) != 2 {
+				return errors.New("invalid port record")
+			}
+
+			port = strconv.Itoa(int(binary.BigEndian.Uint16(body)))
+		default:
+			if critical {
+				return fmt.Errorf("unknown critical record %d", typ)
+			}
+		}
+	}
+}
+
+// exportKey exports the key of the direction, 0 for client to server, 1 for
+// server to client.
+func exportKey(state *tls.ConnectionState, direction byte) (cipher.AEAD, error) {
+	exporterContext := []byte{0, protocolNTPv4, 0, AEADAESSIVCMAC256, direction}
+
+	key, err := state.ExportKeyingMaterial(exporterLabel, exporterContext, sivKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSIV(key)
+}
+
+func appendRecord(b []byte, typ uint16, critical bool, body []byte) []byte {
+	if critical {
+		typ |= recordCritical
+	}
+
+	b = append(b, byte(typ>>8), byte(typ), byte(len(body)>>8), byte(len(body)))
+
+	return append(b, body...)
+}
+
+func readRecord(r io.Reader) (typ uint16, critical bool, body []byte, err error) {
+	header := make([]byte, 4)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return 0, false, nil, err
+	}
+
+	typ = binary.BigEndian.Uint16(header)
+	critical = typ&recordCritical != 0
+	typ &^= recordCritical
+
+	body = make([]byte, binary.BigEndian.Uint16(header[2:]))
+	if _, err = io.ReadFull(r, body); err != nil {
+		return 0, false, nil, err
+	}
+
+	return typ, critical, body, nil
+}
+
+func uint16Body(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+
+	return b
+}
@@ -0,0 +1,406 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package nts implements a Network Time Security (RFC 8915) client.
+package nts
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/beevik/ntp"
+)
+
+const (
+	// DefaultTimeout is the timeout of the key exchange, and of the NTP
+	// queries.
+	DefaultTimeout = 5 * time.Second
+
+	// maxCookies is the number of cookies the client keeps, the server sends
+	// a new cookie with each reply.
+	maxCookies = 8
+
+	ntpHeaderLen = 48
+	uidLen       = 32
+
+	// extHeaderLen is the length of the type and the length of an extension
+	// field, and extMinLen the minimum length of an extension field (RFC
+	// 7822).
+	extHeaderLen = 4
+	extMinLen    = 16
+
+	// ntpEpoch is the number of seconds between the NTP epoch, 1900, and the
+	// Unix epoch.
+	ntpEpoch = 2208988800
+)
+
+// The NTS extension field types (RFC 8915 section 5.7).
+const (
+	extUniqueIdentifier  = 0x0104
+	extCookie            = 0x0204
+	extCookiePlaceholder = 0x0304
+	extAuthenticator     = 0x0404
+)
+
+// errNAK is returned when the server doesn't accept the cookie, the client
+// has to do the key exchange again.
+var errNAK = errors.New("server sent an NTS NAK")
+
+// Client queries a time server authenticated with NTS. The keys and the
+// cookies come from the key exchange with the NTS-KE server over TLS, which is
+// done again once the client runs out of cookies. Replies which aren't
+// authenticated are refused.
+type Client struct {
+	// Server is the address of the NTS-KE server, the port defaults to
+	// 4460.
+	Server    string
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+
+	mu      sync.Mutex
+	session *session
+}
+
+// Query does the key exchange if needed, and queries the NTP server the
+// NTS-KE server negotiated.
+func (c *Client) Query() (*ntp.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	if c.session == nil || len(c.session.cookies) == 0 {
+		s, err := keyExchange(c.Server, c.TLSConfig, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("NTS key exchange failed: %w", err)
+		}
+
+		c.session = s
+	}
+
+	resp, err := c.session.query(timeout)
+	if err != nil {
+		if errors.Is(err, errNAK) {
+			c.session = nil
+		}
+
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// query sends an authenticated NTP request with one of the cookies, and asks
+// for as many new cookies as the client is missing.
+func (s *session) query(timeout time.Duration) (*ntp.Response, error) {
+	cookie := s.cookies[0]
+	s.cookies = s.cookies[1:]
+
+	uid := make([]byte, uidLen)
+	nonce := make([]byte, sivNonceLen)
+
+	for _, b := range [][]byte{uid, nonce} {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := net.DialTimeout("udp", s.server, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	org := toNTPTime(time.Now())
+
+	req := make([]byte, ntpHeaderLen)
+	// no leap second warning, version 4, client mode
+	req[0] = 4<<3 | 3
+	binary.BigEndian.PutUint64(req[40:], uint64(org))
+
+	req = appendExtension(req, extUniqueIdentifier, uid)
+	req = appendExtension(req, extCookie, cookie)
+
+	for i := len(s.cookies) + 1; i < maxCookies; i++ {
+		req = appendExtension(req, extCookiePlaceholder, make([]byte, len(cookie)))
+	}
+
+	req = appendAuthenticator(req, s.c2s, nonce, nil)
+
+	if _, err = conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 2048)
+
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parseReply(buf[:n], uid, org, time.Now())
+}
+
+// parseReply verifies that the reply is the authenticated reply of the
+// request, and keeps the new cookies it carries.
+func (s *session) parseReply(b, uid []byte, org ntpTime, dst time.Time) (*ntp.Response, error) {
+	if len(b) < ntpHeaderLen {
+		return nil, errors.New("reply too short")
+	}
+
+	// server mode
+	if b[0]&0x7 != 4 {
+		return nil, errors.New("reply isn't a server reply")
+	}
+
+	if ntpTime(binary.BigEndian.Uint64(b[24:])) != org {
+		return nil, errors.New("reply doesn't match the request")
+	}
+
+	exts, err := parseExtensions(b, ntpHeaderLen)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth *extension
+
+	uidFound := false
+
+	for i := range exts {
+		switch exts[i].typ {
+		case extUniqueIdentifier:
+			// the identifier must be authenticated, unless it is a NAK
+			uidFound = uidFound || bytes.Equal(exts[i].body, uid)
+		case extAuthenticator:
+			if auth == nil {
+				auth = &exts[i]
+			}
+		}
+	}
+
+	if !uidFound {
+		return nil, errors.New("reply doesn't match the request")
+	}
+
+	if b[1] == 0 && string(b[12:16]) == "NTSN" {
+		return nil, errNAK
+	}
+
+	if auth == nil {
+		return nil, errors.New("reply isn't authenticated")
+	}
+
+	plaintext, err := openAuthenticator(b, auth, s.s2c)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticated := false
+
+	for _, e := range exts {
+		if e.offset >= auth.offset {
+			break
+		}
+
+		if e.typ == extUniqueIdentifier && bytes.Equal(e.body, uid) {
+			authenticated = true
+		}
+	}
+
+	if !authenticated {
+		return nil, errors.New("reply doesn't match the request")
+	}
+
+	encrypted, err := parseExtensions(plaintext, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range encrypted {
+		if e.typ == extCookie && len(s.cookies) < maxCookies {
+			s.cookies = append(s.cookies, e.body)
+		}
+	}
+
+	return toResponse(b, org, dst), nil
+}
+
+type extension struct {
+	typ  uint16
+	body []byte
+	// offset is the offset of the extension field in the packet
+	offset int
+}
+
+// parseExtensions returns the extension fields from the start offset to the
+// end of the packet.
+func parseExtensions(b []byte, start int) ([]extension, error) {
+	var exts []extension
+
+	for offset := start; offset < len(b); {
+		if len(b)-offset < extHeaderLen {
+			return nil, errors.New("truncated extension field")
+		}
+
+		length := int(binary.BigEndian.Uint16(b[offset+2:]))
+		if length < extHeaderLen || length%4 != 0 || offset+length > len(b) {
+			return nil, errors.New("invalid extension field length")
+		}
+
+		exts = append(exts, extension{
+			typ:    binary.BigEndian.Uint16(b[offset:]),
+			body:   b[offset+extHeaderLen : offset+length],
+			offset: offset,
+		})
+
+		offset += length
+	}
+
+	return exts, nil
+}
+
+// appendExtension appends the extension field with the body padded to a
+// multiple of 4 bytes.
+func appendExtension(b []byte, typ uint16, body []byte) []byte {
+	length := extHeaderLen + pad4(len(body))
+	if length < extMinLen {
+		length = extMinLen
+	}
+
+	b = append(b, byte(typ>>8), byte(typ), byte(length>>8), byte(length))
+	b = append(b, body...)
+
+	return append(b, make([]byte, length-extHeaderLen-len(body))...)
+}
+
+// appendAuthenticator appends the authenticator extension field, the
+// associated data is the whole packet before it.
+func appendAuthenticator(b []byte, aead cipher.AEAD, nonce, plaintext []byte) []byte {
+	ciphertext := aead.Seal(nil, nonce, plaintext, b)
+
+	body := make([]byte, 4, 4+pad4(len(nonce))+pad4(len(ciphertext)))
+	binary.BigEndian.PutUint16(body, uint16(len(nonce)))
+	binary.BigEndian.PutUint16(body[2:], uint16(len(ciphertext)))
+	body = append(body, nonce...)
+	body = append(body, make([]byte, pad4(len(nonce))-len(nonce))...)
+	body = append(body, ciphertext...)
+
+	return appendExtension(b, extAuthenticator, body)
+}
+
+// openAuthenticator authenticates the packet up to the authenticator, and
+// returns the encrypted extension fields.
+func openAuthenticator(b []byte, auth *extension, aead cipher.AEAD) ([]byte, error) {
+	if len(auth.body) < 4 {
+		return nil, errors.New("invalid authenticator")
+	}
+
+	nonceLen := int(binary.BigEndian.Uint16(auth.body))
+	ciphertextLen := int(binary.BigEndian.Uint16(auth.body[2:]))
+
+	if 4+pad4(nonceLen)+ciphertextLen > len(auth.body) {
+		return nil, errors.New("invalid authenticator")
+	}
+
+	nonce := auth.body[4 : 4+nonceLen]
+	ciphertext := auth.body[4+pad4(nonceLen) : 4+pad4(nonceLen)+ciphertextLen]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, b[:auth.offset])
+	if err != nil {
+		return nil, errors.New("reply authentication failed")
+	}
+
+	return plaintext, nil
+}
+
+// toResponse computes the offset and the round trip time from the
+// timestamps of the reply.
+func toResponse(b []byte, org ntpTime, dst time.Time) *ntp.Response {
+	rec := ntpTime(binary.BigEndian.Uint64(b[32:])).Time()
+	xmt := ntpTime(binary.BigEndian.Uint64(b[40:])).Time()
+
+	rtt := dst.Sub(org.Time()) - xmt.Sub(rec)
+	if rtt < 0 {
+		rtt = 0
+	}
+
+	resp := &ntp.Response{
+		Time:           xmt,
+		ClockOffset:    (rec.Sub(org.Time()) + xmt.Sub(dst)) / 2,
+		RTT:            rtt,
+		Precision:      toInterval(int8(b[3])),
+		Stratum:        b[1],
+		ReferenceID:    binary.BigEndian.Uint32(b[12:]),
+		ReferenceTime:  ntpTime(binary.BigEndian.Uint64(b[16:])).Time(),
+		RootDelay:      ntpShortTime(binary.BigEndian.Uint32(b[4:])).Duration(),
+		RootDispersion: ntpShortTime(binary.BigEndian.Uint32(b[8:])).Duration(),
+		Leap:           ntp.LeapIndicator(b[0] >> 6),
+		Poll:           toInterval(int8(b[2])),
+	}
+
+	resp.RootDistance = (resp.RTT+resp.RootDelay)/2 + resp.RootDispersion
+
+	if resp.Stratum == 0 {
+		resp.KissCode = string(b[12:16])
+	}
+
+	return resp
+}
+
+// ntpTime is an NTP timestamp, the seconds since 1900 and their fraction.
+type ntpTime uint64
+
+func toNTPTime(t time.Time) ntpTime {
+	nsec := uint64(t.Sub(time.Unix(-ntpEpoch, 0)))
+	sec := nsec / uint64(time.Second)
+	frac := (nsec - sec*uint64(time.Second)) << 32 / uint64(time.Second)
+
+	return ntpTime(sec<<32 | frac)
+}
+
+func (t ntpTime) Time() time.Time {
+	nsec := uint64(t&0xffffffff) * uint64(time.Second) >> 32
+
+	return time.Unix(int64(t>>32)-ntpEpoch, int64(nsec))
+}
+
+// ntpShortTime is a duration in seconds and their fraction, 16 bits each.
+type ntpShortTime uint32
+
+func (t ntpShortTime) Duration() time.Duration {
+	return time.Duration(t>>16)*time.Second + time.Duration(t&0xffff)*time.Second>>16
+}
+
+// toInterval converts a power of two exponent in seconds.
+func toInterval(t int8) time.Duration {
+	switch {
+	case t > 0:
+		return time.Second << uint(t)
+	case t < 0:
+		return time.Second >> uint(-t)
+	default:
+		return time.Second
+	}
+}
+
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
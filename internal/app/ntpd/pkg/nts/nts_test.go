@@ -0,0 +1,417 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nts
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	talosx509 "github.com/talos-systems/talos/pkg/crypto/x509"
+)
+
+type NTSSuite struct {
+	suite.Suite
+
+	server *standIn
+	client *Client
+}
+
+func TestNTSSuite(t *testing.T) {
+	suite.Run(t, new(NTSSuite))
+}
+
+func (suite *NTSSuite) SetupTest() {
+	ca, err := talosx509.NewSelfSignedCertificateAuthority(talosx509.IPAddresses([]net.IP{net.ParseIP("127.0.0.1")}))
+	suite.Require().NoError(err)
+
+	crt, err := tls.X509KeyPair(ca.CrtPEM, ca.KeyPEM)
+	suite.Require().NoError(err)
+
+	suite.server = newStandIn(suite.T(), crt)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Crt)
+
+	suite.client = &Client{
+		Server:    suite.server.ke.Addr().String(),
+		TLSConfig: &tls.Config{RootCAs: pool},
+		Timeout:   time.Second,
+	}
+}
+
+func (suite *NTSSuite) TearDownTest() {
+	suite.server.close()
+}
+
+func (suite *NTSSuite) TestQuery() {
+	for i := 0; i < 3; i++ {
+		resp, err := suite.client.Query()
+		suite.Require().NoError(err)
+		suite.Require().NoError(resp.Validate())
+
+		suite.Assert().InDelta(float64(time.Hour), float64(resp.ClockOffset), float64(time.Second))
+		suite.Assert().Equal(uint8(2), resp.Stratum)
+	}
+
+	// the used cookies are replaced
+	suite.Assert().Equal(1, suite.server.exchanges())
+	suite.Assert().Len(suite.client.session.cookies, maxCookies)
+}
+
+func (suite *NTSSuite) TestPlaceholders() {
+	suite.server.setMode(func(m *standInMode) { m.noCookies = true })
+
+	for i := 0; i < maxCookies/2; i++ {
+		_, err := suite.client.Query()
+		suite.Require().NoError(err)
+	}
+
+	suite.Assert().Len(suite.client.session.cookies, maxCookies/2)
+
+	// the client asks for the missing cookies
+	suite.server.setMode(func(m *standInMode) { m.noCookies = false })
+
+	_, err := suite.client.Query()
+	suite.Require().NoError(err)
+
+	suite.Assert().Len(suite.client.session.cookies, maxCookies)
+
+	// the key exchange is done again once the cookies run out
+	suite.server.setMode(func(m *standInMode) { m.noCookies = true })
+
+	for i := 0; i <= maxCookies; i++ {
+		_, err = suite.client.Query()
+		suite.Require().NoError(err)
+	}
+
+	suite.Assert().Equal(2, suite.server.exchanges())
+}
+
+func (suite *NTSSuite) TestUnauthenticated() {
+	suite.server.setMode(func(m *standInMode) { m.unauthenticated = true })
+
+	_, err := suite.client.Query()
+	suite.Assert().EqualError(err, "reply isn't authenticated")
+}
+
+func (suite *NTSSuite) TestTampered() {
+	suite.server.setMode(func(m *standInMode) { m.tampered = true })
+
+	_, err := suite.client.Query()
+	suite.Assert().EqualError(err, "reply authentication failed")
+}
+
+func (suite *NTSSuite) TestNAK() {
+	suite.server.setMode(func(m *standInMode) { m.nak = true })
+
+	_, err := suite.client.Query()
+	suite.Assert().Equal(errNAK, err)
+	suite.Assert().Nil(suite.client.session)
+
+	suite.server.setMode(func(m *standInMode) { m.nak = false })
+
+	_, err = suite.client.Query()
+	suite.Require().NoError(err)
+	suite.Assert().Equal(2, suite.server.exchanges())
+}
+
+func (suite *NTSSuite) TestKeyExchangeError() {
+	suite.server.setMode(func(m *standInMode) { m.keError = true })
+
+	_, err := suite.client.Query()
+	suite.Assert().EqualError(err, "NTS key exchange failed: server error 1")
+}
+
+func (suite *NTSSuite) TestNTPTime() {
+	now := time.Unix(1573603200, 123456789)
+
+	suite.Assert().InDelta(float64(now.UnixNano()), float64(toNTPTime(now).Time().UnixNano()), 1)
+	suite.Assert().Equal(uint64(1573603200+ntpEpoch), uint64(toNTPTime(now)>>32))
+	suite.Assert().Equal(1500*time.Millisecond, ntpShortTime(0x00018000).Duration())
+}
+
+// standInMode changes the replies of the stand-in.
+type standInMode struct {
+	noCookies       bool
+	unauthenticated bool
+	tampered        bool
+	nak             bool
+	keError         bool
+}
+
+// standIn is an NTS-KE server and an NTP server, whose clock is an hour
+// ahead.
+type standIn struct {
+	t *testing.T
+
+	ke  net.Listener
+	ntp net.PacketConn
+
+	mu    sync.Mutex
+	mode  standInMode
+	keys  map[string][2][]byte
+	count int
+}
+
+func newStandIn(t *testing.T, crt tls.Certificate) *standIn {
+	ke, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{crt},
+		NextProtos:   []string{alpn},
+		MinVersion:   tls.VersionTLS13,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ntp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &standIn{t: t, ke: ke, ntp: ntp, keys: map[string][2][]byte{}}
+
+	go s.serveKE()
+	go s.serveNTP()
+
+	return s
+}
+
+func (s *standIn) close() {
+	// nolint: errcheck
+	s.ke.Close()
+	// nolint: errcheck
+	s.ntp.Close()
+}
+
+func (s *standIn) setMode(f func(*standInMode)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f(&s.mode)
+}
+
+func (s *standIn) exchanges() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.count
+}
+
+func (s *standIn) serveKE() {
+	for {
+		conn, err := s.ke.Accept()
+		if err != nil {
+			return
+		}
+
+		s.keyExchange(conn.(*tls.Conn))
+	}
+}
+
+func (s *standIn) keyExchange(conn *tls.Conn) {
+	// nolint: errcheck
+	defer conn.Close()
+
+	for {
+		typ, _, _, err := readRecord(conn)
+		if err != nil {
+			s.t.Error(err)
+			return
+		}
+
+		if typ == recordEndOfMessage {
+			break
+		}
+	}
+
+	state := conn.ConnectionState()
+
+	var keys [2][]byte
+
+	for direction := range keys {
+		var err error
+
+		keys[direction], err = state.ExportKeyingMaterial(exporterLabel, []byte{0, 0, 0, AEADAESSIVCMAC256, byte(direction)}, sivKeyLen)
+		if err != nil {
+			s.t.Error(err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count++
+
+	var resp []byte
+
+	if s.mode.keError {
+		resp = appendRecord(resp, recordError, true, uint16Body(1))
+	} else {
+		_, port, _ := net.SplitHostPort(s.ntp.LocalAddr().String())
+		p, _ := strconv.Atoi(port)
+
+		resp = appendRecord(resp, recordNextProtocol, true, uint16Body(protocolNTPv4))
+		resp = appendRecord(resp, recordAEAD, true, uint16Body(AEADAESSIVCMAC256))
+		resp = appendRecord(resp, recordServer, true, []byte("127.0.0.1"))
+		resp = appendRecord(resp, recordPort, true, uint16Body(uint16(p)))
+
+		for i := 0; i < maxCookies; i++ {
+			resp = appendRecord(resp, recordNewCookie, false, s.cookie(keys))
+		}
+	}
+
+	resp = appendRecord(resp, recordEndOfMessage, true, nil)
+
+	if _, err := conn.Write(resp); err != nil {
+		s.t.Error(err)
+	}
+}
+
+// cookie returns a new cookie of the keys, the stand-in keeps the keys of
+// the cookies instead of encrypting them in the cookies.
+func (s *standIn) cookie(keys [2][]byte) []byte {
+	cookie := make([]byte, 64)
+
+	if _, err := rand.Read(cookie); err != nil {
+		s.t.Fatal(err)
+	}
+
+	s.keys[string(cookie)] = keys
+
+	return cookie
+}
+
+func (s *standIn) serveNTP() {
+	buf := make([]byte, 2048)
+
+	for {
+		n, addr, err := s.ntp.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		reply := s.reply(buf[:n])
+		if reply == nil {
+			continue
+		}
+
+		if _, err = s.ntp.WriteTo(reply, addr); err != nil {
+			s.t.Error(err)
+		}
+	}
+}
+
+// reply authenticates the request, and returns its reply.
+func (s *standIn) reply(req []byte) []byte {
+	rec := toNTPTime(time.Now().Add(time.Hour))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exts, err := parseExtensions(req, ntpHeaderLen)
+	if err != nil {
+		s.t.Error(err)
+		return nil
+	}
+
+	var (
+		uid, cookie  []byte
+		auth         *extension
+		placeholders int
+	)
+
+	for i, e := range exts {
+		switch e.typ {
+		case extUniqueIdentifier:
+			uid = e.body
+		case extCookie:
+			cookie = e.body
+		case extCookiePlaceholder:
+			placeholders++
+		case extAuthenticator:
+			auth = &exts[i]
+		}
+	}
+
+	keys, ok := s.keys[string(cookie)]
+	if !ok || auth == nil {
+		s.t.Error("request without a valid cookie, or an authenticator")
+		return nil
+	}
+
+	// cookies are used once
+	delete(s.keys, string(cookie))
+
+	c2s, err := newSIV(keys[0])
+	if err != nil {
+		s.t.Error(err)
+		return nil
+	}
+
+	if _, err = openAuthenticator(req, auth, c2s); err != nil {
+		s.t.Error(err)
+		return nil
+	}
+
+	reply := make([]byte, ntpHeaderLen)
+	// no leap second warning, version 4, server mode
+	reply[0] = 4<<3 | 4
+	reply[1] = 2
+	reply[2] = 6
+	reply[3] = 0xec
+	binary.BigEndian.PutUint32(reply[8:], 0x00000100)
+	copy(reply[12:], []byte{192, 168, 0, 1})
+	binary.BigEndian.PutUint64(reply[16:], uint64(rec)-1<<32)
+	copy(reply[24:32], req[40:48])
+	binary.BigEndian.PutUint64(reply[32:], uint64(rec))
+	binary.BigEndian.PutUint64(reply[40:], uint64(toNTPTime(time.Now().Add(time.Hour))))
+
+	if s.mode.nak {
+		reply[1] = 0
+		copy(reply[12:], "NTSN")
+
+		return appendExtension(reply, extUniqueIdentifier, uid)
+	}
+
+	reply = appendExtension(reply, extUniqueIdentifier, uid)
+
+	if s.mode.unauthenticated {
+		return reply
+	}
+
+	var plaintext []byte
+
+	if !s.mode.noCookies {
+		for i := 0; i <= placeholders; i++ {
+			plaintext = appendExtension(plaintext, extCookie, s.cookie(keys))
+		}
+	}
+
+	s2c, err := newSIV(keys[1])
+	if err != nil {
+		s.t.Error(err)
+		return nil
+	}
+
+	nonce := make([]byte, sivNonceLen)
+
+	reply = appendAuthenticator(reply, s2c, nonce, plaintext)
+
+	if s.mode.tampered {
+		reply[len(reply)-1] ^= 1
+	}
+
+	return reply
+}
@@ -0,0 +1,32 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nts
+
+import (
+	"crypto/cipher"
+	"fmt"
+
+	"github.com/miscreant/miscreant.go"
+)
+
+// AEADAESSIVCMAC256 is the numeric identifier of AEAD_AES_SIV_CMAC_256, the
+// only AEAD algorithm NTS requires.
+const AEADAESSIVCMAC256 = 15
+
+const (
+	sivKeyLen   = 32
+	sivNonceLen = 16
+)
+
+// newSIV returns the AEAD_AES_SIV_CMAC_256 (RFC 5297) AEAD of the 32 bytes
+// key, the nonce is the last component of the associated data (RFC 5116).
+func newSIV(key []byte) (cipher.AEAD, error) {
+	if len(key) != sivKeyLen {
+		return nil, fmt.Errorf("invalid AES-SIV-CMAC-256 key length %d", len(key))
+	}
+
+	// the length of the nonces of the server isn't fixed
+	return miscreant.NewAEAD("AES-SIV", key, -1)
+}
@@ -0,0 +1,73 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nts
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SIVSuite struct {
+	suite.Suite
+}
+
+func TestSIVSuite(t *testing.T) {
+	suite.Run(t, new(SIVSuite))
+}
+
+// RFC 5297 appendix A.1, the only component of the associated data is
+// passed as the nonce
+func (suite *SIVSuite) TestDeterministic() {
+	aead, err := newSIV(unhex("fffefdfc fbfaf9f8 f7f6f5f4 f3f2f1f0 f0f1f2f3 f4f5f6f7 f8f9fafb fcfdfeff"))
+	suite.Require().NoError(err)
+
+	ad := unhex("10111213 14151617 18191a1b 1c1d1e1f 20212223 24252627")
+	plaintext := unhex("11223344 55667788 99aabbcc ddee")
+
+	suite.Assert().Equal(unhex("85632d07 c6e8f37f 950acd32 0a2ecc93 40c02b96 90c4dc04 daef7f6a fe5c"), aead.Seal(nil, ad, plaintext, nil))
+}
+
+func (suite *SIVSuite) TestSealOpen() {
+	aead, err := newSIV(unhex("7f7e7d7c 7b7a7978 77767574 73727170 40414243 44454647 48494a4b 4c4d4e4f"))
+	suite.Require().NoError(err)
+
+	nonce := make([]byte, sivNonceLen)
+	ad := []byte("header")
+
+	for _, plaintext := range [][]byte{nil, []byte("short"), []byte(strings.Repeat("extension fields", 3))} {
+		sealed := aead.Seal([]byte("prefix"), nonce, plaintext, ad)
+		suite.Assert().Len(sealed, len("prefix")+aead.Overhead()+len(plaintext))
+
+		opened, err := aead.Open(nil, nonce, sealed[len("prefix"):], ad)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(string(plaintext), string(opened))
+
+		_, err = aead.Open(nil, nonce, sealed[len("prefix"):], []byte("tampered"))
+		suite.Assert().Error(err)
+
+		// the nonces of the server may be longer
+		_, err = aead.Open(nil, make([]byte, 2*sivNonceLen), sealed[len("prefix"):], ad)
+		suite.Assert().Error(err)
+
+		sealed[len(sealed)-1] ^= 1
+		_, err = aead.Open(nil, nonce, sealed[len("prefix"):], ad)
+		suite.Assert().Error(err)
+	}
+
+	_, err = newSIV(make([]byte, 16))
+	suite.Assert().Error(err)
+}
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(strings.Replace(s, " ", "", -1))
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
@@ -34,7 +34,8 @@ func (r *Registrator) Register(s *grpc.Server) {
 	timeapi.RegisterTimeServer(s, r)
 }
 
-// Time issues a query to the configured ntp server and displays the results
+// Time issues a query to the configured ntp servers and displays the results
+// of the selected server, and the state of all of the servers
 func (r *Registrator) Time(ctx context.Context, in *empty.Empty) (reply *timeapi.TimeReply, err error) {
 	reply = &timeapi.TimeReply{}
 
@@ -43,7 +44,7 @@ func (r *Registrator) Time(ctx context.Context, in *empty.Empty) (reply *timeapi
 		return reply, err
 	}
 
-	return genProtobufTimeReply(r.Ntpd.GetTime(), rt.Time, r.Ntpd.Server)
+	return genProtobufTimeReply(r.Ntpd.GetTime(), rt.Time, r.Ntpd.Sources())
 }
 
 // TimeCheck issues a query to the specified ntp server and displays the results
@@ -60,12 +61,24 @@ func (r *Registrator) TimeCheck(ctx context.Context, in *timeapi.TimeRequest) (r
 		return reply, err
 	}
 
-	return genProtobufTimeReply(tc.GetTime(), rt.Time, in.Server)
+	return genProtobufTimeReply(tc.GetTime(), rt.Time, tc.Sources())
 }
 
-func genProtobufTimeReply(local, remote time.Time, server string) (*timeapi.TimeReply, error) {
+func genProtobufTimeReply(local, remote time.Time, sources []ntp.SourceStatus) (*timeapi.TimeReply, error) {
 	reply := &timeapi.TimeReply{}
 
+	var server string
+
+	pbsources := make([]*timeapi.TimeSource, 0, len(sources))
+
+	for _, source := range sources {
+		if source.Selected {
+			server = source.Server
+		}
+
+		pbsources = append(pbsources, toTimeSource(source))
+	}
+
 	localpbts, err := ptypes.TimestampProto(local)
 	if err != nil {
 		return reply, err
@@ -82,9 +95,29 @@ func genProtobufTimeReply(local, remote time.Time, server string) (*timeapi.Time
 				Server:     server,
 				Localtime:  localpbts,
 				Remotetime: remotepbts,
+				Sources:    pbsources,
 			},
 		},
 	}
 
 	return reply, nil
 }
+
+func toTimeSource(source ntp.SourceStatus) *timeapi.TimeSource {
+	s := &timeapi.TimeSource{
+		Server:      source.Server,
+		Offset:      ptypes.DurationProto(source.Offset),
+		Delay:       ptypes.DurationProto(source.Delay),
+		Jitter:      ptypes.DurationProto(source.Jitter),
+		Stratum:     uint32(source.Stratum),
+		Reach:       uint32(source.Reach),
+		Selected:    source.Selected,
+		Falseticker: source.Falseticker,
+	}
+
+	if source.Err != nil {
+		s.Error = source.Err.Error()
+	}
+
+	return s
+}
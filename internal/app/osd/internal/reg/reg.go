@@ -13,6 +13,7 @@ import (
 	"syscall"
 
 	criconstants "github.com/containerd/cri/pkg/constants"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/prometheus/procfs"
 	"golang.org/x/sys/unix"
@@ -23,6 +24,7 @@ import (
 	"github.com/talos-systems/talos/internal/pkg/containers"
 	"github.com/talos-systems/talos/internal/pkg/containers/containerd"
 	"github.com/talos-systems/talos/internal/pkg/containers/cri"
+	"github.com/talos-systems/talos/internal/pkg/kmsg"
 	"github.com/talos-systems/talos/pkg/constants"
 )
 
@@ -189,6 +191,51 @@ func (r *Registrator) Dmesg(ctx context.Context, in *empty.Empty) (data *common.
 	return data, err
 }
 
+// DmesgStream implements the osapi.OSDServer interface. Kernel log records
+// are read from /dev/kmsg and streamed one by one.
+func (r *Registrator) DmesgStream(req *osapi.DmesgRequest, srv osapi.OS_DmesgStreamServer) error {
+	var opts []kmsg.ReaderOption
+
+	if req.Follow {
+		opts = append(opts, kmsg.Follow())
+	}
+
+	if req.Tail {
+		opts = append(opts, kmsg.FromTail())
+	}
+
+	reader, err := kmsg.NewReader(opts...)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer reader.Close()
+
+	for packet := range reader.Scan(srv.Context()) {
+		if packet.Err != nil {
+			return packet.Err
+		}
+
+		timestamp, err := ptypes.TimestampProto(packet.Message.Timestamp)
+		if err != nil {
+			return err
+		}
+
+		if err = srv.Send(&osapi.KernelMessage{
+			Facility:  int32(packet.Message.Facility),
+			Priority:  int32(packet.Message.Priority),
+			Sequence:  packet.Message.SequenceNumber,
+			Clock:     packet.Message.Clock.Microseconds(),
+			Timestamp: timestamp,
+			Message:   packet.Message.Message,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Processes implements the osapi.OSDServer interface
 func (r *Registrator) Processes(ctx context.Context, in *empty.Empty) (reply *osapi.ProcessesReply, err error) {
 	procs, err := procfs.AllProcs()
@@ -66,7 +66,9 @@ func (c *Container) Kill(signal syscall.Signal) error {
 }
 
 // GetLogChunker returns chunker for container log file
-func (c *Container) GetLogChunker() (chunker.Chunker, io.Closer, error) {
+//
+// Options are only applied if the container logs to a file.
+func (c *Container) GetLogChunker(opts ...file.Option) (chunker.Chunker, io.Closer, error) {
 	logFile := c.GetLogFile()
 	if logFile != "" {
 		f, err := os.OpenFile(logFile, os.O_RDONLY, 0)
@@ -74,7 +76,7 @@ func (c *Container) GetLogChunker() (chunker.Chunker, io.Closer, error) {
 			return nil, nil, err
 		}
 
-		return file.NewChunker(f), f, nil
+		return file.NewChunker(f, opts...), f, nil
 	}
 
 	filename, err := c.GetProcessStderr()
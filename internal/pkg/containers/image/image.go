@@ -0,0 +1,60 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package image pulls images which are verified against the image policies
+// of the machine config.
+package image
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+// Pull pulls and unpacks the image. If one of the policies matches the
+// reference, the image is verified before any of its content is fetched.
+func Pull(ctx context.Context, policies []machine.ImagePolicy, client *containerd.Client, ref string) (containerd.Image, error) {
+	resolver := &verifyingResolver{
+		Resolver: docker.NewResolver(docker.ResolverOptions{Client: http.DefaultClient}),
+		policies: policies,
+	}
+
+	return client.Pull(ctx, ref, containerd.WithPullUnpack, containerd.WithResolver(resolver))
+}
+
+// verifyingResolver verifies the descriptor a reference resolves to. The
+// image is then pulled by the digest of that descriptor, so that a tag which
+// moves in between can't bypass the verification.
+type verifyingResolver struct {
+	remotes.Resolver
+
+	policies []machine.ImagePolicy
+}
+
+func (r *verifyingResolver) Resolve(ctx context.Context, ref string) (string, ocispec.Descriptor, error) {
+	name, desc, err := r.Resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", ocispec.Descriptor{}, err
+	}
+
+	spec, err := reference.Parse(ref)
+	if err != nil {
+		return "", ocispec.Descriptor{}, err
+	}
+
+	if policy := Match(r.policies, spec.Locator); policy != nil {
+		if err = verify(ctx, r.Resolver, policy, spec.Locator, desc.Digest); err != nil {
+			return "", ocispec.Descriptor{}, err
+		}
+	}
+
+	return name, desc, nil
+}
@@ -0,0 +1,251 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package image
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+const (
+	// signatureAnnotation is the layer annotation cosign stores the
+	// signature of the layer in.
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// maxBlobSize limits the size of the signature manifest and payloads.
+	maxBlobSize = 1 << 20
+)
+
+// payload is the simple signing payload which is signed.
+type payload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// Match returns the first policy which matches the repository, a policy
+// image ending with "*" matches all the repositories with that prefix.
+func Match(policies []machine.ImagePolicy, repository string) *machine.ImagePolicy {
+	for i := range policies {
+		image := policies[i].Image
+
+		if strings.HasSuffix(image, "*") {
+			if strings.HasPrefix(repository, strings.TrimSuffix(image, "*")) {
+				return &policies[i]
+			}
+
+			continue
+		}
+
+		if image == repository {
+			return &policies[i]
+		}
+	}
+
+	return nil
+}
+
+func parseKeys(keys []string) ([]crypto.PublicKey, error) {
+	parsed := make([]crypto.PublicKey, 0, len(keys))
+
+	for _, key := range keys {
+		block, _ := pem.Decode([]byte(key))
+		if block == nil {
+			return nil, errors.New("failed to decode PEM public key")
+		}
+
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+
+		switch pub.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T", pub)
+		}
+
+		parsed = append(parsed, pub)
+	}
+
+	return parsed, nil
+}
+
+// verify ensures that the digest is one of the policy digests, and that the
+// image is signed by one of the policy keys.
+func verify(ctx context.Context, resolver remotes.Resolver, policy *machine.ImagePolicy, repository string, dgst digest.Digest) error {
+	if len(policy.Digests) > 0 {
+		allowed := false
+
+		for _, d := range policy.Digests {
+			if digest.Digest(d) == dgst {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return fmt.Errorf("image %s@%s does not match any of the trusted digests", repository, dgst)
+		}
+	}
+
+	if len(policy.Keys) == 0 {
+		return nil
+	}
+
+	keys, err := parseKeys(policy.Keys)
+	if err != nil {
+		return err
+	}
+
+	if err = verifySignatures(ctx, resolver, keys, repository, dgst); err != nil {
+		return fmt.Errorf("image %s@%s is not signed by a trusted key: %w", repository, dgst, err)
+	}
+
+	return nil
+}
+
+// verifySignatures looks up the signatures of the image the way cosign
+// stores them, and checks that one of them is made by one of the keys over a
+// payload which names the image digest.
+func verifySignatures(ctx context.Context, resolver remotes.Resolver, keys []crypto.PublicKey, repository string, dgst digest.Digest) error {
+	ref := fmt.Sprintf("%s:%s-%s.sig", repository, dgst.Algorithm(), dgst.Encoded())
+
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("failed to resolve signatures: %w", err)
+	}
+
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	b, err := fetch(ctx, fetcher, desc)
+	if err != nil {
+		return err
+	}
+
+	var manifest ocispec.Manifest
+
+	if err = json.Unmarshal(b, &manifest); err != nil {
+		return fmt.Errorf("failed to decode signature manifest: %w", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, ok := layer.Annotations[signatureAnnotation]
+		if !ok {
+			continue
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+
+		b, err := fetch(ctx, fetcher, layer)
+		if err != nil {
+			return err
+		}
+
+		if verifyPayload(keys, b, signature, dgst) {
+			return nil
+		}
+	}
+
+	return errors.New("no valid signature found")
+}
+
+// fetch reads the blob of the descriptor, and checks it against its digest.
+func fetch(ctx context.Context, fetcher remotes.Fetcher, desc ocispec.Descriptor) ([]byte, error) {
+	if desc.Size > maxBlobSize {
+		return nil, fmt.Errorf("blob %s is too large", desc.Digest)
+	}
+
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(io.LimitReader(rc, maxBlobSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if desc.Digest.Algorithm().FromBytes(b) != desc.Digest {
+		return nil, fmt.Errorf("blob %s does not match its digest", desc.Digest)
+	}
+
+	return b, nil
+}
+
+func verifyPayload(keys []crypto.PublicKey, b, signature []byte, dgst digest.Digest) bool {
+	signed := false
+
+	for _, key := range keys {
+		if verifySignature(key, b, signature) {
+			signed = true
+			break
+		}
+	}
+
+	if !signed {
+		return false
+	}
+
+	var p payload
+
+	if err := json.Unmarshal(b, &p); err != nil {
+		return false
+	}
+
+	return p.Critical.Image.DockerManifestDigest == dgst.String()
+}
+
+func verifySignature(key crypto.PublicKey, b, signature []byte) bool {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		var sig struct {
+			R, S *big.Int
+		}
+
+		if rest, err := asn1.Unmarshal(signature, &sig); err != nil || len(rest) != 0 {
+			return false
+		}
+
+		hash := sha256.Sum256(b)
+
+		return ecdsa.Verify(key, hash[:], sig.R, sig.S)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, b, signature)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,177 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package image
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+const repository = "docker.io/autonomy/installer"
+
+// fakeResolver serves the blobs it holds, and resolves the references it
+// holds to them.
+type fakeResolver struct {
+	refs  map[string]ocispec.Descriptor
+	blobs map[digest.Digest][]byte
+}
+
+func (r *fakeResolver) add(b []byte) ocispec.Descriptor {
+	desc := ocispec.Descriptor{Digest: digest.FromBytes(b), Size: int64(len(b))}
+	r.blobs[desc.Digest] = b
+
+	return desc
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, ref string) (string, ocispec.Descriptor, error) {
+	desc, ok := r.refs[ref]
+	if !ok {
+		return "", ocispec.Descriptor{}, errdefs.ErrNotFound
+	}
+
+	return ref, desc, nil
+}
+
+func (r *fakeResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return remotes.FetcherFunc(func(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+		b, ok := r.blobs[desc.Digest]
+		if !ok {
+			return nil, errdefs.ErrNotFound
+		}
+
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}), nil
+}
+
+func (r *fakeResolver) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+type PolicySuite struct {
+	suite.Suite
+
+	resolver *fakeResolver
+	key      *ecdsa.PrivateKey
+	image    digest.Digest
+}
+
+func (suite *PolicySuite) SetupTest() {
+	var err error
+
+	suite.resolver = &fakeResolver{refs: map[string]ocispec.Descriptor{}, blobs: map[digest.Digest][]byte{}}
+
+	suite.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+
+	suite.image = digest.FromString("image")
+}
+
+func (suite *PolicySuite) publicKey(key crypto.PublicKey) string {
+	b, err := x509.MarshalPKIXPublicKey(key)
+	suite.Require().NoError(err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}))
+}
+
+// sign stores a signature of the image digest made by the key the way
+// cosign does.
+func (suite *PolicySuite) sign(key *ecdsa.PrivateKey, dgst digest.Digest) {
+	var p payload
+
+	p.Critical.Image.DockerManifestDigest = dgst.String()
+
+	b, err := json.Marshal(p)
+	suite.Require().NoError(err)
+
+	hash := sha256.Sum256(b)
+
+	signature, err := key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	suite.Require().NoError(err)
+
+	layer := suite.resolver.add(b)
+	layer.Annotations = map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(signature)}
+
+	b, err = json.Marshal(ocispec.Manifest{Layers: []ocispec.Descriptor{layer}})
+	suite.Require().NoError(err)
+
+	suite.resolver.refs[fmt.Sprintf("%s:sha256-%s.sig", repository, dgst.Encoded())] = suite.resolver.add(b)
+}
+
+func (suite *PolicySuite) TestMatch() {
+	policies := []machine.ImagePolicy{
+		{Image: "docker.io/autonomy/installer"},
+		{Image: "docker.io/autonomy/*"},
+	}
+
+	suite.Assert().Equal(&policies[0], Match(policies, "docker.io/autonomy/installer"))
+	suite.Assert().Equal(&policies[1], Match(policies, "docker.io/autonomy/osd"))
+	suite.Assert().Nil(Match(policies, "k8s.gcr.io/etcd"))
+	suite.Assert().Nil(Match(policies, "docker.io/autonomy"))
+}
+
+func (suite *PolicySuite) TestVerifyDigests() {
+	policy := &machine.ImagePolicy{Image: repository, Digests: []string{digest.FromString("other").String(), suite.image.String()}}
+
+	suite.Assert().NoError(verify(context.Background(), suite.resolver, policy, repository, suite.image))
+	suite.Assert().Error(verify(context.Background(), suite.resolver, policy, repository, digest.FromString("untrusted")))
+}
+
+func (suite *PolicySuite) TestVerifySignature() {
+	policy := &machine.ImagePolicy{Image: repository, Keys: []string{suite.publicKey(suite.key.Public())}}
+
+	// not signed at all
+	suite.Assert().Error(verify(context.Background(), suite.resolver, policy, repository, suite.image))
+
+	suite.sign(suite.key, suite.image)
+
+	suite.Assert().NoError(verify(context.Background(), suite.resolver, policy, repository, suite.image))
+}
+
+func (suite *PolicySuite) TestVerifySignatureUntrustedKey() {
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+
+	suite.sign(other, suite.image)
+
+	policy := &machine.ImagePolicy{Image: repository, Keys: []string{suite.publicKey(suite.key.Public())}}
+
+	suite.Assert().Error(verify(context.Background(), suite.resolver, policy, repository, suite.image))
+}
+
+func (suite *PolicySuite) TestVerifySignatureOtherDigest() {
+	// a valid signature of another image, stored as the signature of this one
+	suite.sign(suite.key, digest.FromString("other"))
+
+	suite.resolver.refs[fmt.Sprintf("%s:sha256-%s.sig", repository, suite.image.Encoded())] = suite.resolver.refs[fmt.Sprintf("%s:sha256-%s.sig", repository, digest.FromString("other").Encoded())]
+
+	policy := &machine.ImagePolicy{Image: repository, Keys: []string{suite.publicKey(suite.key.Public())}}
+
+	suite.Assert().Error(verify(context.Background(), suite.resolver, policy, repository, suite.image))
+}
+
+func TestPolicySuite(t *testing.T) {
+	suite.Run(t, new(PolicySuite))
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"time"
 
 	"go.etcd.io/etcd/clientv3"
@@ -74,8 +75,24 @@ func NewClientFromControlPlaneIPs(creds *x509.PEMEncodedCertificateAndKey, endpo
 }
 
 // ValidateForUpgrade validates the etcd cluster state to ensure that performing
-// an upgrade is safe.
-func ValidateForUpgrade() error {
+// an upgrade is safe. If force is set, the cluster state is not validated, the
+// upgrade is allowed even if members are down, or the cluster loses quorum
+// once the node leaves it.
+func ValidateForUpgrade(force bool) error {
+	return validateForLeave("perform an upgrade", force)
+}
+
+// ValidateForReset validates the etcd cluster state to ensure that the node
+// can leave the cluster on reset.
+func ValidateForReset() error {
+	return validateForLeave("reset the node", false)
+}
+
+func validateForLeave(operation string, force bool) error {
+	if force {
+		return nil
+	}
+
 	content, err := config.FromFile(constants.ConfigPath)
 	if err != nil {
 		return err
@@ -103,9 +120,18 @@ func ValidateForUpgrade() error {
 		for _, member := range resp.Members {
 			// If the member is not started, the name will be an empty string.
 			if len(member.Name) == 0 {
-				return fmt.Errorf("etcd member %d is not started, all members must be running to perform an upgrade", member.ID)
+				return fmt.Errorf("etcd member %d is not started, all members must be running to %s", member.ID, operation)
 			}
 		}
+
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+
+		if err = ValidateQuorum(context.Background(), client, hostname); err != nil {
+			return fmt.Errorf("refusing to %s: %w", operation, err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"go.etcd.io/etcd/clientv3"
+)
+
+// statusTimeout is the timeout for querying the status of a single member.
+const statusTimeout = 5 * time.Second
+
+// ValidateQuorum ensures that the etcd cluster keeps quorum once the member
+// with the specified name is removed from it.
+func ValidateQuorum(ctx context.Context, client *clientv3.Client, name string) error {
+	resp, err := client.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+
+	member, err := findMember(resp.Members, name)
+	if err != nil {
+		return err
+	}
+
+	healthy := map[uint64]bool{}
+
+	for _, m := range resp.Members {
+		if m.ID != member.ID {
+			healthy[m.ID] = isHealthy(ctx, client, m)
+		}
+	}
+
+	return checkQuorum(resp.Members, member.ID, healthy)
+}
+
+// TransferLeadership moves the etcd leadership off the member with the
+// specified name to another healthy member, if the member is the leader.
+func TransferLeadership(ctx context.Context, client *clientv3.Client, name string) error {
+	resp, err := client.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+
+	member, err := findMember(resp.Members, name)
+	if err != nil {
+		return err
+	}
+
+	if len(member.ClientURLs) == 0 {
+		return fmt.Errorf("etcd member %q has no client URLs", name)
+	}
+
+	status, err := status(ctx, client, member.ClientURLs[0])
+	if err != nil {
+		return err
+	}
+
+	if status.Leader != member.ID {
+		return nil
+	}
+
+	var transferee *etcdserverpb.Member
+
+	for _, m := range resp.Members {
+		if m.ID != member.ID && isHealthy(ctx, client, m) {
+			transferee = m
+			break
+		}
+	}
+
+	if transferee == nil {
+		return errors.New("no healthy etcd member to transfer the leadership to")
+	}
+
+	// The leader is the only member which can move the leadership.
+	leader, err := NewClient(member.ClientURLs)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer leader.Close()
+
+	log.Printf("moving etcd leadership to %q\n", transferee.Name)
+
+	if _, err = leader.MoveLeader(ctx, transferee.ID); err != nil {
+		return fmt.Errorf("failed to move etcd leadership to %q: %w", transferee.Name, err)
+	}
+
+	return nil
+}
+
+func findMember(members []*etcdserverpb.Member, name string) (*etcdserverpb.Member, error) {
+	for _, member := range members {
+		if member.Name == name {
+			return member, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find %q in list of etcd members", name)
+}
+
+func status(ctx context.Context, client *clientv3.Client, endpoint string) (*clientv3.StatusResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, statusTimeout)
+	defer cancel()
+
+	return client.Status(ctx, endpoint)
+}
+
+// isHealthy returns true if the member responds, and follows a leader.
+func isHealthy(ctx context.Context, client *clientv3.Client, member *etcdserverpb.Member) bool {
+	for _, endpoint := range member.ClientURLs {
+		if resp, err := status(ctx, client, endpoint); err == nil && resp.Leader != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkQuorum verifies that enough of the members other than the one with
+// the specified ID are healthy to keep quorum once it is removed. A cluster
+// of a single member has no quorum to keep, the node is the cluster.
+func checkQuorum(members []*etcdserverpb.Member, id uint64, healthy map[uint64]bool) error {
+	remaining := len(members) - 1
+
+	if remaining == 0 {
+		return nil
+	}
+
+	available := 0
+
+	for _, member := range members {
+		if member.ID != id && healthy[member.ID] {
+			available++
+		}
+	}
+
+	if quorum := remaining/2 + 1; available < quorum {
+		return fmt.Errorf("only %d of the remaining %d etcd members are healthy, removing this member would lose quorum (%d required)", available, remaining, quorum)
+	}
+
+	return nil
+}
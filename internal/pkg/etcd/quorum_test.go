@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package etcd
+
+import (
+	"testing"
+
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+func members(n int) []*etcdserverpb.Member {
+	members := make([]*etcdserverpb.Member, n)
+
+	for i := range members {
+		members[i] = &etcdserverpb.Member{ID: uint64(i + 1)}
+	}
+
+	return members
+}
+
+func TestCheckQuorum(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		members   int
+		healthy   []uint64
+		expectErr bool
+	}{
+		{name: "single member", members: 1},
+		{name: "three members all healthy", members: 3, healthy: []uint64{2, 3}},
+		{name: "three members one down", members: 3, healthy: []uint64{2}, expectErr: true},
+		{name: "five members one down", members: 5, healthy: []uint64{2, 3, 4}},
+		{name: "five members two down", members: 5, healthy: []uint64{2, 3}, expectErr: true},
+		{name: "two members", members: 2, healthy: []uint64{2}},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			healthy := map[uint64]bool{}
+			for _, id := range tt.healthy {
+				healthy[id] = true
+			}
+
+			err := checkQuorum(members(tt.members), 1, healthy)
+			if tt.expectErr && err == nil {
+				t.Error("expected error")
+			}
+
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -16,9 +16,20 @@ const (
 	Reboot
 	// Upgrade is the upgrade event.
 	Upgrade
+	// Sequence is the event of a sequence starting or finishing.
+	Sequence
+	// Phase is the event of a phase starting or finishing.
+	Phase
+	// Service is the event of a service changing state or health.
+	Service
+	// Reset is the reset event.
+	Reset
 )
 
 // Event represents an event in the observer pattern.
+//
+// The data of Sequence, Phase and Service events is a *machineapi.Event, the
+// data of Upgrade and Reset events is the request.
 type Event struct {
 	Type Type
 	Data interface{}
@@ -68,7 +79,7 @@ func (e *Embeddable) Channel() Channel {
 // Types implements the Observer interface.
 func (e *Embeddable) Types() []Type {
 	if e.types == nil {
-		e.types = []Type{Shutdown, Reboot, Upgrade}
+		e.types = []Type{Shutdown, Reboot, Upgrade, Reset}
 	}
 
 	return e.types
@@ -5,10 +5,12 @@
 package event_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 
+	machineapi "github.com/talos-systems/talos/api/machine"
 	"github.com/talos-systems/talos/internal/pkg/event"
 )
 
@@ -61,6 +63,47 @@ func (suite *EventSuite) TestBus() {
 	suite.Assert().Equal(event.Event{Type: event.Upgrade, Data: nil}, <-subscriber1.Channel())
 }
 
+type machineObserver struct {
+	ch event.Channel
+}
+
+func (o *machineObserver) Channel() event.Channel {
+	return o.ch
+}
+
+func (o *machineObserver) Types() []event.Type {
+	return []event.Type{event.Sequence, event.Phase}
+}
+
+func (suite *EventSuite) TestMachineEvents() {
+	observer := &machineObserver{ch: make(event.Channel, 10)}
+
+	event.Bus().Register(observer)
+	defer event.Bus().Unregister(observer)
+
+	// not subscribed to
+	event.Bus().Notify(event.Event{Type: event.Service})
+
+	event.NotifySequence("boot", machineapi.EventAction_START, nil)
+	event.NotifyPhase("config", machineapi.EventAction_STOP, errors.New("failed"))
+
+	e := <-observer.Channel()
+	suite.Assert().Equal(event.Sequence, e.Type)
+	suite.Assert().Equal(machineapi.EventType_SEQUENCE, e.Data.(*machineapi.Event).Type)
+	suite.Assert().Equal(&machineapi.SequenceEvent{Sequence: "boot", Action: machineapi.EventAction_START}, e.Data.(*machineapi.Event).Sequence)
+
+	e = <-observer.Channel()
+	suite.Assert().Equal(event.Phase, e.Type)
+	suite.Assert().Equal(machineapi.EventType_PHASE, e.Data.(*machineapi.Event).Type)
+	suite.Assert().Equal(&machineapi.PhaseEvent{Phase: "config", Action: machineapi.EventAction_STOP, Error: "failed"}, e.Data.(*machineapi.Event).Phase)
+
+	select {
+	case <-observer.Channel():
+		suite.Require().Fail("no more events expected")
+	default:
+	}
+}
+
 func TestEventSuite(t *testing.T) {
 	suite.Run(t, new(EventSuite))
 }
@@ -0,0 +1,47 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package event
+
+import (
+	"github.com/golang/protobuf/ptypes"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+)
+
+// NotifySequence publishes a Sequence event on the bus.
+func NotifySequence(sequence string, action machineapi.EventAction, err error) {
+	e := &machineapi.Event{
+		Ts:   ptypes.TimestampNow(),
+		Type: machineapi.EventType_SEQUENCE,
+		Sequence: &machineapi.SequenceEvent{
+			Sequence: sequence,
+			Action:   action,
+		},
+	}
+
+	if err != nil {
+		e.Sequence.Error = err.Error()
+	}
+
+	Bus().Notify(Event{Type: Sequence, Data: e})
+}
+
+// NotifyPhase publishes a Phase event on the bus.
+func NotifyPhase(phase string, action machineapi.EventAction, err error) {
+	e := &machineapi.Event{
+		Ts:   ptypes.TimestampNow(),
+		Type: machineapi.EventType_PHASE,
+		Phase: &machineapi.PhaseEvent{
+			Phase:  phase,
+			Action: action,
+		},
+	}
+
+	if err != nil {
+		e.Phase.Error = err.Error()
+	}
+
+	Bus().Notify(Event{Type: Phase, Data: e})
+}
@@ -7,19 +7,134 @@ package install
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/mount"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/oci"
+	"github.com/containerd/containerd/reference"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
 	"github.com/opencontainers/runtime-spec/specs-go"
 
+	containerdimage "github.com/talos-systems/talos/internal/pkg/containers/image"
 	"github.com/talos-systems/talos/internal/pkg/kernel"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/config/machine"
 	"github.com/talos-systems/talos/pkg/constants"
 )
 
-// Install performs an installation via the installer container.
+// installerPaths are the paths which the installer image has to provide.
+var installerPaths = []string{"/bin/osctl", constants.KernelAssetPath, constants.InitramfsAssetPath}
+
+// PullAndValidateInstallerImage pulls and unpacks the installer image, and
+// verifies that it can be used for an installation.
+//
+// The content of the image is verified against its digests while pulling,
+// if the reference is pinned to a digest, the image has to match it. The
+// image also has to verify against the image policy matching it, if any.
+func PullAndValidateInstallerImage(policies []machine.ImagePolicy, ref string) (digest.Digest, error) {
+	ctx := namespaces.WithNamespace(context.Background(), constants.SystemContainerdNamespace)
+
+	client, err := containerd.New(constants.SystemContainerdAddress)
+	if err != nil {
+		return "", err
+	}
+
+	// nolint: errcheck
+	defer client.Close()
+
+	spec, err := reference.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid installer image reference %q: %w", ref, err)
+	}
+
+	log.Printf("pulling installer image %q", ref)
+
+	image, err := containerdimage.Pull(ctx, policies, client, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull installer image %q: %w", ref, err)
+	}
+
+	if expected := spec.Digest(); expected != "" && image.Target().Digest != expected {
+		return "", fmt.Errorf("installer image %q has digest %s, expected %s", ref, image.Target().Digest, expected)
+	}
+
+	if err = validateInstallerImage(ctx, client, image); err != nil {
+		return "", fmt.Errorf("invalid installer image %q: %w", ref, err)
+	}
+
+	return image.Target().Digest, nil
+}
+
+// PinnedReference returns the reference pinned to the digest, so that it
+// resolves to the same image even if the tag is moved.
+func PinnedReference(ref string, d digest.Digest) (string, error) {
+	spec, err := reference.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+
+	if spec.Digest() != "" {
+		return ref, nil
+	}
+
+	spec.Object += "@" + d.String()
+
+	return spec.String(), nil
+}
+
+// validateInstallerImage mounts the unpacked image read-only, and checks that
+// it provides the installation assets.
+func validateInstallerImage(ctx context.Context, client *containerd.Client, image containerd.Image) (err error) {
+	diffIDs, err := image.RootFS(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshotter := client.SnapshotService(containerd.DefaultSnapshotter)
+	key := "validate-" + image.Target().Digest.Encoded()
+
+	mounts, err := snapshotter.View(ctx, key, identity.ChainID(diffIDs).String())
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer snapshotter.Remove(ctx, key)
+
+	root, err := ioutil.TempDir(constants.SystemRunPath, "installer")
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer os.Remove(root)
+
+	if err = mount.All(mounts, root); err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer mount.UnmountAll(root, 0)
+
+	for _, path := range installerPaths {
+		if _, err = os.Stat(filepath.Join(root, path)); err != nil {
+			return fmt.Errorf("missing %s", path)
+		}
+	}
+
+	return nil
+}
+
+// Install performs an installation via the installer container. The
+// installer image is always pulled, a local image with the same tag may be
+// stale; the content pulled while staging is reused.
 //
 // nolint: gocyclo
 func Install(r runtime.Runtime) error {
@@ -30,7 +145,7 @@ func Install(r runtime.Runtime) error {
 		return err
 	}
 
-	image, err := client.Pull(ctx, r.Config().Machine().Install().Image(), []containerd.RemoteOpt{containerd.WithPullUnpack}...)
+	image, err := containerdimage.Pull(ctx, r.Config().Machine().ImagePolicies(), client, r.Config().Machine().Install().Image())
 	if err != nil {
 		return err
 	}
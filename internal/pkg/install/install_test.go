@@ -0,0 +1,38 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/suite"
+)
+
+type InstallSuite struct {
+	suite.Suite
+}
+
+func TestInstallSuite(t *testing.T) {
+	suite.Run(t, new(InstallSuite))
+}
+
+func (suite *InstallSuite) TestPinnedReference() {
+	d := digest.FromString("installer")
+
+	ref, err := PinnedReference("docker.io/autonomy/installer:v0.3.0", d)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("docker.io/autonomy/installer:v0.3.0@"+d.String(), ref)
+
+	pinned := "docker.io/autonomy/installer@" + digest.FromString("other").String()
+
+	ref, err = PinnedReference(pinned, d)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(pinned, ref)
+
+	ref, err = PinnedReference("docker.io/autonomy/installer", d)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("docker.io/autonomy/installer@"+d.String(), ref)
+}
@@ -0,0 +1,256 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package syslinux
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/talos-systems/talos/internal/pkg/kernel"
+)
+
+const (
+	// BootA is the name of the A boot slot.
+	BootA = "A"
+	// BootB is the name of the B boot slot.
+	BootB = "B"
+
+	// MaxBootAttempts is the number of times a newly installed boot slot is
+	// booted without being marked healthy before falling back to the
+	// previous slot.
+	MaxBootAttempts = 3
+)
+
+var (
+	defaultRegexp = regexp.MustCompile(`(?m)^DEFAULT\s+(\S+)`)
+	includeRegexp = regexp.MustCompile(`(?m)^INCLUDE\s+/([^/\s]+)/include\.cfg`)
+)
+
+// BootState tracks a newly installed boot slot until it is marked healthy.
+//
+// The pending slot is the default, every boot of it is counted, and the
+// fallback slot is made the default again once the pending slot was booted
+// MaxBootAttempts times without being marked healthy.
+type BootState struct {
+	Pending  string `yaml:"pending"`
+	Fallback string `yaml:"fallback"`
+	Attempts int    `yaml:"attempts"`
+}
+
+func cfgPaths(base string) []string {
+	return []string{filepath.Join(base, "syslinux", "syslinux.cfg"), filepath.Join(base, "EFI", "syslinux", "syslinux.cfg")}
+}
+
+func bootStatePath(base string) string {
+	return filepath.Join(base, "syslinux", "bootstate.yaml")
+}
+
+// Labels returns the label which is booted by default, and the label of the
+// other boot slot. The current label is empty if syslinux is not installed.
+func Labels(base string) (current, next string, err error) {
+	b, err := ioutil.ReadFile(cfgPaths(base)[0])
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", BootA, nil
+		}
+
+		return "", "", err
+	}
+
+	match := defaultRegexp.FindSubmatch(b)
+	if match == nil {
+		return "", "", errors.New("no default label found in syslinux config")
+	}
+
+	current = string(match[1])
+
+	// installations which predate the boot slots use the "default" label
+	if current == BootA {
+		return current, BootB, nil
+	}
+
+	return current, BootA, nil
+}
+
+// SetDefault sets the label which is booted by default.
+func SetDefault(base, label string) (err error) {
+	if _, err = os.Stat(filepath.Join(base, label, "include.cfg")); err != nil {
+		return fmt.Errorf("boot slot %q is not installed: %w", label, err)
+	}
+
+	for _, path := range cfgPaths(base) {
+		var b []byte
+
+		if b, err = ioutil.ReadFile(path); err != nil {
+			return err
+		}
+
+		b = defaultRegexp.ReplaceAll(b, []byte("DEFAULT "+label))
+
+		log.Printf("setting default syslinux label to %s in %s", label, path)
+
+		if err = ioutil.WriteFile(path, b, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Revert sets the boot slot which is not booted as the one booted by default,
+// and returns its label.
+func Revert(base, booted string) (label string, err error) {
+	b, err := ioutil.ReadFile(cfgPaths(base)[0])
+	if err != nil {
+		return "", err
+	}
+
+	if booted == "" {
+		if booted, _, err = Labels(base); err != nil {
+			return "", err
+		}
+	}
+
+	for _, match := range includeRegexp.FindAllSubmatch(b, -1) {
+		if string(match[1]) != booted {
+			label = string(match[1])
+			break
+		}
+	}
+
+	if label == "" {
+		return "", errors.New("no other boot slot to revert to")
+	}
+
+	if err = SetDefault(base, label); err != nil {
+		return "", err
+	}
+
+	if err = ClearBootState(base); err != nil {
+		return "", err
+	}
+
+	return label, nil
+}
+
+// ReadBootState reads the boot state, the state is empty if no boot slot is
+// pending.
+func ReadBootState(base string) (state *BootState, err error) {
+	state = &BootState{}
+
+	b, err := ioutil.ReadFile(bootStatePath(base))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(b, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// WriteBootState writes the boot state.
+func WriteBootState(base string, state *BootState) (err error) {
+	var b []byte
+
+	if b, err = yaml.Marshal(state); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(bootStatePath(base)), os.ModeDir); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(bootStatePath(base), b, 0600)
+}
+
+// ClearBootState clears the boot state, which marks the pending boot slot as
+// healthy.
+func ClearBootState(base string) error {
+	if err := os.Remove(bootStatePath(base)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// CheckBoot records a boot of the slot with the specified label. If the slot
+// is pending, and it was already booted MaxBootAttempts times without being
+// marked healthy, the fallback slot is set as the default, and its label is
+// returned.
+func CheckBoot(base, booted string) (fallback string, err error) {
+	state, err := ReadBootState(base)
+	if err != nil {
+		return "", err
+	}
+
+	if state.Pending == "" || state.Pending != booted {
+		return "", nil
+	}
+
+	if state.Attempts >= MaxBootAttempts {
+		if err = SetDefault(base, state.Fallback); err != nil {
+			return "", err
+		}
+
+		if err = ClearBootState(base); err != nil {
+			return "", err
+		}
+
+		return state.Fallback, nil
+	}
+
+	state.Attempts++
+
+	return "", WriteBootState(base, state)
+}
+
+// MarkHealthy marks the booted slot as healthy. If it is the pending slot,
+// the boot state is cleared, so that it stays the default.
+func MarkHealthy(base, booted string) (err error) {
+	state, err := ReadBootState(base)
+	if err != nil {
+		return err
+	}
+
+	if state.Pending == "" || state.Pending != booted {
+		return nil
+	}
+
+	if err = SetDefault(base, booted); err != nil {
+		return err
+	}
+
+	return ClearBootState(base)
+}
+
+// BootedLabel returns the label of the booted slot, as passed by syslinux via
+// the BOOT_IMAGE kernel parameter (e.g. BOOT_IMAGE=/A/vmlinuz).
+func BootedLabel(cmdline *kernel.Cmdline) string {
+	image := cmdline.Get("BOOT_IMAGE").First()
+	if image == nil {
+		return ""
+	}
+
+	parts := strings.Split(strings.TrimPrefix(*image, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[0]
+}
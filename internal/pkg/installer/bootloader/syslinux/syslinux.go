@@ -44,6 +44,9 @@ type Cfg struct {
 }
 
 // Label reprsents a label in the syslinux.cfg file.
+//
+// A label without a kernel refers to a boot slot which is already installed,
+// only the include of its config is written.
 type Label struct {
 	Root   string
 	Kernel string
@@ -110,7 +113,7 @@ func Install(base string, config interface{}) (err error) {
 		return err
 	}
 
-	paths := []string{filepath.Join(base, "syslinux", "syslinux.cfg"), filepath.Join(base, "EFI", "syslinux", "syslinux.cfg")}
+	paths := cfgPaths(base)
 	for _, path := range paths {
 		if err = WriteSyslinuxCfg(base, path, syslinuxcfg); err != nil {
 			return err
@@ -146,6 +149,10 @@ func WriteSyslinuxCfg(base, path string, syslinuxcfg *Cfg) (err error) {
 	}
 
 	for _, label := range syslinuxcfg.Labels {
+		if label.Kernel == "" {
+			continue
+		}
+
 		b = []byte{}
 		wr = bytes.NewBuffer(b)
 		t = template.Must(template.New("syslinux").Parse(syslinuxLabelTpl))
@@ -4,11 +4,185 @@
 
 package syslinux_test
 
-import "testing"
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/internal/pkg/installer/bootloader/syslinux"
+	"github.com/talos-systems/talos/internal/pkg/kernel"
+)
+
+type SyslinuxSuite struct {
+	suite.Suite
+
+	base string
+}
+
+func (suite *SyslinuxSuite) SetupTest() {
+	var err error
+
+	suite.base, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+}
+
+func (suite *SyslinuxSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.base))
+}
+
+func (suite *SyslinuxSuite) label(root string) *syslinux.Label {
+	return &syslinux.Label{
+		Root:   root,
+		Kernel: filepath.Join("/", root, "vmlinuz"),
+		Initrd: filepath.Join("/", root, "initramfs.xz"),
+		Append: "initrd=" + filepath.Join("/", root, "initramfs.xz"),
+	}
+}
+
+func (suite *SyslinuxSuite) write(cfg *syslinux.Cfg) {
+	for _, path := range []string{filepath.Join(suite.base, "syslinux", "syslinux.cfg"), filepath.Join(suite.base, "EFI", "syslinux", "syslinux.cfg")} {
+		suite.Require().NoError(syslinux.WriteSyslinuxCfg(suite.base, path, cfg))
+	}
+}
+
+// upgrade installs the slots A and B, with B pending.
+func (suite *SyslinuxSuite) upgrade() {
+	suite.write(&syslinux.Cfg{Default: syslinux.BootA, Labels: []*syslinux.Label{suite.label(syslinux.BootA)}})
+	suite.write(&syslinux.Cfg{Default: syslinux.BootB, Labels: []*syslinux.Label{suite.label(syslinux.BootB), {Root: syslinux.BootA}}})
+
+	suite.Require().NoError(syslinux.WriteBootState(suite.base, &syslinux.BootState{Pending: syslinux.BootB, Fallback: syslinux.BootA}))
+}
+
+func (suite *SyslinuxSuite) assertDefault(expected string) {
+	current, _, err := syslinux.Labels(suite.base)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(expected, current)
+
+	b, err := ioutil.ReadFile(filepath.Join(suite.base, "EFI", "syslinux", "syslinux.cfg"))
+	suite.Require().NoError(err)
+	suite.Assert().Contains(string(b), "DEFAULT "+expected+"\n")
+}
+
+func (suite *SyslinuxSuite) TestLabels() {
+	current, next, err := syslinux.Labels(suite.base)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("", current)
+	suite.Assert().Equal(syslinux.BootA, next)
+
+	suite.write(&syslinux.Cfg{Default: "default", Labels: []*syslinux.Label{suite.label("default")}})
+
+	current, next, err = syslinux.Labels(suite.base)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("default", current)
+	suite.Assert().Equal(syslinux.BootA, next)
+
+	suite.upgrade()
+
+	current, next, err = syslinux.Labels(suite.base)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(syslinux.BootB, current)
+	suite.Assert().Equal(syslinux.BootA, next)
+
+	// the include of the previous slot is kept as is
+	b, err := ioutil.ReadFile(filepath.Join(suite.base, syslinux.BootA, "include.cfg"))
+	suite.Require().NoError(err)
+	suite.Assert().Contains(string(b), "KERNEL /A/vmlinuz")
+}
+
+func (suite *SyslinuxSuite) TestRevert() {
+	suite.write(&syslinux.Cfg{Default: syslinux.BootA, Labels: []*syslinux.Label{suite.label(syslinux.BootA)}})
+
+	_, err := syslinux.Revert(suite.base, syslinux.BootA)
+	suite.Assert().Error(err)
+
+	suite.upgrade()
+
+	// B is booted, reverting before it is marked healthy
+	label, err := syslinux.Revert(suite.base, syslinux.BootB)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(syslinux.BootA, label)
+	suite.assertDefault(syslinux.BootA)
+
+	state, err := syslinux.ReadBootState(suite.base)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("", state.Pending)
+
+	label, err = syslinux.Revert(suite.base, syslinux.BootA)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(syslinux.BootB, label)
+	suite.assertDefault(syslinux.BootB)
+
+	// without the booted slot, the default one is reverted
+	label, err = syslinux.Revert(suite.base, "")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(syslinux.BootA, label)
+	suite.assertDefault(syslinux.BootA)
+}
+
+func (suite *SyslinuxSuite) TestSetDefaultNotInstalled() {
+	suite.write(&syslinux.Cfg{Default: syslinux.BootA, Labels: []*syslinux.Label{suite.label(syslinux.BootA)}})
+
+	suite.Assert().Error(syslinux.SetDefault(suite.base, syslinux.BootB))
+	suite.assertDefault(syslinux.BootA)
+}
+
+func (suite *SyslinuxSuite) TestCheckBootFallback() {
+	suite.upgrade()
+
+	for i := 0; i < syslinux.MaxBootAttempts; i++ {
+		fallback, err := syslinux.CheckBoot(suite.base, syslinux.BootB)
+		suite.Require().NoError(err)
+		suite.Assert().Equal("", fallback)
+		suite.assertDefault(syslinux.BootB)
+	}
+
+	fallback, err := syslinux.CheckBoot(suite.base, syslinux.BootB)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(syslinux.BootA, fallback)
+	suite.assertDefault(syslinux.BootA)
+
+	// booting the fallback doesn't change anything
+	fallback, err = syslinux.CheckBoot(suite.base, syslinux.BootA)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("", fallback)
+	suite.assertDefault(syslinux.BootA)
+
+	// the reverted slot is not made the default
+	suite.Require().NoError(syslinux.MarkHealthy(suite.base, syslinux.BootB))
+	suite.assertDefault(syslinux.BootA)
+}
+
+func (suite *SyslinuxSuite) TestCheckBootHealthy() {
+	suite.upgrade()
+
+	fallback, err := syslinux.CheckBoot(suite.base, syslinux.BootB)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("", fallback)
+
+	suite.Require().NoError(syslinux.MarkHealthy(suite.base, syslinux.BootB))
+
+	state, err := syslinux.ReadBootState(suite.base)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("", state.Pending)
+
+	for i := 0; i <= syslinux.MaxBootAttempts; i++ {
+		fallback, err = syslinux.CheckBoot(suite.base, syslinux.BootB)
+		suite.Require().NoError(err)
+		suite.Assert().Equal("", fallback)
+	}
+
+	suite.assertDefault(syslinux.BootB)
+}
+
+func (suite *SyslinuxSuite) TestBootedLabel() {
+	suite.Assert().Equal(syslinux.BootB, syslinux.BootedLabel(kernel.NewCmdline("BOOT_IMAGE=/B/vmlinuz initrd=/B/initramfs.xz")))
+	suite.Assert().Equal("", syslinux.BootedLabel(kernel.NewCmdline("BOOT_IMAGE=vmlinuz")))
+	suite.Assert().Equal("", syslinux.BootedLabel(kernel.NewCmdline("console=tty0")))
+}
+
+func TestSyslinuxSuite(t *testing.T) {
+	suite.Run(t, new(SyslinuxSuite))
 }
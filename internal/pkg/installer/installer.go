@@ -7,6 +7,7 @@ package installer
 import (
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"path/filepath"
 	"unsafe"
@@ -21,6 +22,7 @@ import (
 	"github.com/talos-systems/talos/internal/pkg/mount/manager"
 	"github.com/talos-systems/talos/internal/pkg/mount/manager/owned"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/blockdevice/probe"
 	"github.com/talos-systems/talos/pkg/config/machine"
 	"github.com/talos-systems/talos/pkg/constants"
 )
@@ -29,18 +31,38 @@ import (
 // installation methods.
 type Installer struct {
 	cmdline  *kernel.Cmdline
+	sequence runtime.Sequence
 	install  machine.Install
 	manifest *manifest.Manifest
+
+	// Current is the boot slot which is currently booted by default, and
+	// Next is the boot slot to install into.
+	Current string
+	Next    string
+
+	bootPartitionFound bool
 }
 
 // NewInstaller initializes and returns an Installer.
-func NewInstaller(cmdline *kernel.Cmdline, install machine.Install) (i *Installer, err error) {
+//
+// On upgrades, the existing partitions are kept, and Talos is installed into
+// the boot slot which is not currently booted by default, so that the node
+// can fall back to the current one.
+func NewInstaller(cmdline *kernel.Cmdline, sequence runtime.Sequence, install machine.Install) (i *Installer, err error) {
 	i = &Installer{
-		cmdline: cmdline,
-		install: install,
+		cmdline:  cmdline,
+		sequence: sequence,
+		install:  install,
+		Next:     syslinux.BootA,
+	}
+
+	if sequence == runtime.Upgrade && install.WithBootloader() {
+		if err = i.probeBootPartition(); err != nil {
+			return nil, fmt.Errorf("failed to probe boot partition: %w", err)
+		}
 	}
 
-	i.manifest, err = manifest.NewManifest(install)
+	i.manifest, err = manifest.NewManifest(i.Next, install)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create installation manifest: %w", err)
 	}
@@ -48,20 +70,53 @@ func NewInstaller(cmdline *kernel.Cmdline, install machine.Install) (i *Installe
 	return i, nil
 }
 
+// probeBootPartition looks up the boot slots of an existing installation.
+func (i *Installer) probeBootPartition() (err error) {
+	var dev *probe.ProbedBlockDevice
+
+	if dev, err = probe.DevForFileSystemLabel(i.install.Disk(), constants.BootPartitionLabel); err != nil {
+		// The boot partition is missing, the disk is installed from scratch.
+		return nil
+	}
+
+	// nolint: errcheck
+	defer dev.Close()
+
+	mountpoint := mount.NewMountPoint(dev.Path, constants.BootMountPoint, dev.SuperBlock.Type(), unix.MS_NOATIME, "")
+	if err = mountpoint.Mount(); err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer mountpoint.Unmount()
+
+	if i.Current, i.Next, err = syslinux.Labels(constants.BootMountPoint); err != nil {
+		return err
+	}
+
+	i.bootPartitionFound = true
+
+	return nil
+}
+
 // Install fetches the necessary data locations and copies or extracts
 // to the target locations.
 // nolint: gocyclo
-func (i *Installer) Install(sequence runtime.Sequence) (err error) {
-	if i.install.Zero() {
-		if err = zero(i.manifest); err != nil {
-			return fmt.Errorf("failed to wipe device(s): %w", err)
+func (i *Installer) Install() (err error) {
+	if i.bootPartitionFound {
+		log.Printf("installing into boot slot %s, keeping the existing partitions", i.Next)
+	} else {
+		if i.install.Zero() {
+			if err = zero(i.manifest); err != nil {
+				return fmt.Errorf("failed to wipe device(s): %w", err)
+			}
 		}
-	}
 
-	// Partition and format the block device(s).
+		// Partition and format the block device(s).
 
-	if err = i.manifest.ExecuteManifest(); err != nil {
-		return err
+		if err = i.manifest.ExecuteManifest(); err != nil {
+			return err
+		}
 	}
 
 	// Mount the partitions.
@@ -96,8 +151,10 @@ func (i *Installer) Install(sequence runtime.Sequence) (err error) {
 		for _, target := range targets {
 			switch target.Label {
 			case constants.BootPartitionLabel:
-				if err = syslinux.Prepare(target.Device); err != nil {
-					return err
+				if !i.bootPartitionFound {
+					if err = syslinux.Prepare(target.Device); err != nil {
+						return err
+					}
 				}
 			case constants.EphemeralPartitionLabel:
 				continue
@@ -116,23 +173,42 @@ func (i *Installer) Install(sequence runtime.Sequence) (err error) {
 		return nil
 	}
 
+	initrd := filepath.Join("/", i.Next, constants.InitramfsAsset)
+	i.cmdline.Append("initrd", initrd)
+
 	syslinuxcfg := &syslinux.Cfg{
-		Default: "default",
+		Default: i.Next,
 		Labels: []*syslinux.Label{
 			{
-				Root:   "default",
-				Initrd: filepath.Join("/", "default", constants.InitramfsAsset),
-				Kernel: filepath.Join("/", "default", constants.KernelAsset),
+				Root:   i.Next,
+				Initrd: initrd,
+				Kernel: filepath.Join("/", i.Next, constants.KernelAsset),
 				Append: i.cmdline.String(),
 			},
 		},
 	}
 
+	if i.Current != "" {
+		// Keep the current boot slot around to fall back to.
+		syslinuxcfg.Labels = append(syslinuxcfg.Labels, &syslinux.Label{Root: i.Current})
+	}
+
 	if err = syslinux.Install(filepath.Join(constants.BootMountPoint), syslinuxcfg); err != nil {
 		return err
 	}
 
-	metadata := metadata.NewMetadata(sequence)
+	if i.Current != "" {
+		state := &syslinux.BootState{
+			Pending:  i.Next,
+			Fallback: i.Current,
+		}
+
+		if err = syslinux.WriteBootState(constants.BootMountPoint, state); err != nil {
+			return err
+		}
+	}
+
+	metadata := metadata.NewMetadata(i.sequence)
 
 	return metadata.Save()
 }
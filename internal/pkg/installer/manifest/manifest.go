@@ -51,8 +51,9 @@ type Asset struct {
 	Destination string
 }
 
-// NewManifest initializes and returns a Manifest.
-func NewManifest(install machine.Install) (manifest *Manifest, err error) {
+// NewManifest initializes and returns a Manifest. The boot assets are
+// installed into the boot slot with the specified label.
+func NewManifest(label string, install machine.Install) (manifest *Manifest, err error) {
 	manifest = &Manifest{
 		Targets: map[string][]*Target{},
 	}
@@ -85,11 +86,11 @@ func NewManifest(install machine.Install) (manifest *Manifest, err error) {
 			Assets: []*Asset{
 				{
 					Source:      constants.KernelAssetPath,
-					Destination: filepath.Join(constants.BootMountPoint, "default", constants.KernelAsset),
+					Destination: filepath.Join(constants.BootMountPoint, label, constants.KernelAsset),
 				},
 				{
 					Source:      constants.InitramfsAssetPath,
-					Destination: filepath.Join(constants.BootMountPoint, "default", constants.InitramfsAsset),
+					Destination: filepath.Join(constants.BootMountPoint, label, constants.InitramfsAsset),
 				},
 			},
 		}
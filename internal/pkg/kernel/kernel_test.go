@@ -100,6 +100,10 @@ func (suite *KernelSuite) TestCmdlineSet() {
 		cmdline.Set(t.k, t.v)
 		suite.Assert().Equal(t.expected, cmdline.Get(t.k))
 	}
+
+	cmdline := NewCmdline("initrd=/default/initramfs.xz console=tty0")
+	cmdline.Set("initrd", NewParameter("initrd").Append("/A/initramfs.xz"))
+	suite.Assert().Equal("initrd=/A/initramfs.xz console=tty0", cmdline.String())
 }
 
 func (suite *KernelSuite) TestCmdlineAppend() {
@@ -4,11 +4,38 @@
 
 package kmsg_test
 
-import "testing"
+import (
+	"testing"
+	"time"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/internal/pkg/kmsg"
+)
+
+func TestParseMessage(t *testing.T) {
+	bootTime := time.Date(2019, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	msg, err := kmsg.ParseMessage([]byte("6,339,5140900,-;NET: Registered protocol family 10\n"), bootTime)
+	assert.NoError(t, err)
+	assert.Equal(t, kmsg.Message{
+		Facility:       0,
+		Priority:       6,
+		SequenceNumber: 339,
+		Clock:          5140900 * time.Microsecond,
+		Timestamp:      bootTime.Add(5140900 * time.Microsecond),
+		Message:        "NET: Registered protocol family 10",
+	}, msg)
+
+	msg, err = kmsg.ParseMessage([]byte("30,1000,6000000,-,caller=T1;[talos] service[kubelet](Running)\n SUBSYSTEM=foo\n"), bootTime)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, msg.Facility)
+	assert.Equal(t, 6, msg.Priority)
+	assert.Equal(t, "[talos] service[kubelet](Running)", msg.Message)
+
+	_, err = kmsg.ParseMessage([]byte("garbage"), bootTime)
+	assert.Error(t, err)
+
+	_, err = kmsg.ParseMessage([]byte("x,1,2,-;msg"), bootTime)
+	assert.Error(t, err)
 }
@@ -0,0 +1,245 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kmsg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Message is a single record of the kernel log.
+type Message struct {
+	Facility       int
+	Priority       int
+	SequenceNumber int64
+	Clock          time.Duration
+	Timestamp      time.Time
+	Message        string
+}
+
+// Packet is a message read from the kernel log, or an error.
+type Packet struct {
+	Message Message
+	Err     error
+}
+
+// Reader reads the kernel log via /dev/kmsg.
+type Reader interface {
+	Scan(ctx context.Context) <-chan Packet
+	Close() error
+}
+
+// ReaderOptions are the functional options for the reader.
+type ReaderOptions struct {
+	Follow bool
+	Tail   bool
+}
+
+// ReaderOption configures the reader.
+type ReaderOption func(*ReaderOptions)
+
+// Follow keeps waiting for new messages once the end of the log is reached.
+func Follow() ReaderOption {
+	return func(o *ReaderOptions) {
+		o.Follow = true
+	}
+}
+
+// FromTail starts reading at the end of the log, skipping the messages
+// which are already in the buffer.
+func FromTail() ReaderOption {
+	return func(o *ReaderOptions) {
+		o.Tail = true
+	}
+}
+
+type reader struct {
+	fd       int
+	options  ReaderOptions
+	bootTime time.Time
+}
+
+// NewReader initializes a Reader of /dev/kmsg.
+func NewReader(options ...ReaderOption) (Reader, error) {
+	r := &reader{}
+
+	for _, setter := range options {
+		setter(&r.options)
+	}
+
+	var err error
+
+	r.fd, err = unix.Open("/dev/kmsg", unix.O_RDONLY|unix.O_CLOEXEC|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open /dev/kmsg: %w", err)
+	}
+
+	if r.options.Tail {
+		if _, err = unix.Seek(r.fd, 0, io.SeekEnd); err != nil {
+			// nolint: errcheck
+			unix.Close(r.fd)
+
+			return nil, fmt.Errorf("failed to seek /dev/kmsg: %w", err)
+		}
+	}
+
+	var ts unix.Timespec
+
+	if err = unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		// nolint: errcheck
+		unix.Close(r.fd)
+
+		return nil, fmt.Errorf("failed to read monotonic clock: %w", err)
+	}
+
+	r.bootTime = time.Now().Add(-time.Duration(ts.Nano()))
+
+	return r, nil
+}
+
+// Close implements Reader.
+func (r *reader) Close() error {
+	return unix.Close(r.fd)
+}
+
+// Scan implements Reader.
+//
+// The returned channel is closed once the end of the log is reached (unless
+// following), on read error or when ctx is canceled.
+func (r *reader) Scan(ctx context.Context) <-chan Packet {
+	ch := make(chan Packet)
+
+	go func() {
+		defer close(ch)
+
+		// each read returns exactly one record, records are limited to 8k
+		buf := make([]byte, 8192)
+
+		for {
+			n, err := unix.Read(r.fd, buf)
+
+			switch {
+			case errors.Is(err, unix.EPIPE):
+				// messages were overwritten in the ring buffer before we
+				// read them, continue with the next available one
+				continue
+			case errors.Is(err, unix.EAGAIN):
+				if !r.options.Follow {
+					return
+				}
+
+				if !r.wait(ctx) {
+					return
+				}
+
+				continue
+			case err != nil:
+				r.send(ctx, ch, Packet{Err: fmt.Errorf("error reading /dev/kmsg: %w", err)})
+
+				return
+			}
+
+			msg, err := ParseMessage(buf[:n], r.bootTime)
+			if err != nil {
+				continue
+			}
+
+			if !r.send(ctx, ch, Packet{Message: msg}) {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// wait blocks until there is data to read or ctx is canceled.
+func (r *reader) wait(ctx context.Context) bool {
+	for {
+		fds := []unix.PollFd{{Fd: int32(r.fd), Events: unix.POLLIN}}
+
+		n, err := unix.Poll(fds, 100)
+		if err != nil && !errors.Is(err, unix.EINTR) {
+			return false
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		default:
+		}
+
+		if n > 0 {
+			return true
+		}
+	}
+}
+
+func (r *reader) send(ctx context.Context, ch chan<- Packet, p Packet) bool {
+	select {
+	case ch <- p:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// ParseMessage parses a record as read from /dev/kmsg, bootTime is used
+// to convert the clock of the record into a timestamp.
+//
+// The format is described in Documentation/ABI/testing/dev-kmsg:
+//
+//   6,339,5140900,-;NET: Registered protocol family 10
+func ParseMessage(b []byte, bootTime time.Time) (Message, error) {
+	line := string(b)
+
+	// continuation lines carry the dictionary, which is ignored
+	if idx := strings.IndexByte(line, '\n'); idx >= 0 {
+		line = line[:idx]
+	}
+
+	idx := strings.IndexByte(line, ';')
+	if idx < 0 {
+		return Message{}, fmt.Errorf("malformed kmsg record: %q", line)
+	}
+
+	fields := strings.Split(line[:idx], ",")
+	if len(fields) < 3 {
+		return Message{}, fmt.Errorf("malformed kmsg record prefix: %q", line[:idx])
+	}
+
+	prival, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return Message{}, fmt.Errorf("malformed kmsg priority: %w", err)
+	}
+
+	seq, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return Message{}, fmt.Errorf("malformed kmsg sequence number: %w", err)
+	}
+
+	usec, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return Message{}, fmt.Errorf("malformed kmsg timestamp: %w", err)
+	}
+
+	clock := time.Duration(usec) * time.Microsecond
+
+	return Message{
+		Facility:       prival >> 3,
+		Priority:       prival & 7,
+		SequenceNumber: seq,
+		Clock:          clock,
+		Timestamp:      bootTime.Add(clock),
+		Message:        line[idx+1:],
+	}, nil
+}
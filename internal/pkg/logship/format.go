@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package logship
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+// Syslog facilities, see RFC5424 section 6.2.1.
+const (
+	FacilityKern   = 0
+	FacilityDaemon = 3
+)
+
+// Syslog severities, see RFC5424 section 6.2.1.
+const (
+	SeverityErr  = 3
+	SeverityInfo = 6
+)
+
+var facilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+var severities = []string{
+	"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug",
+}
+
+// rfc5424Time is the timestamp format of RFC5424 with the maximum allowed
+// precision.
+const rfc5424Time = "2006-01-02T15:04:05.000000Z07:00"
+
+// encoder encodes a message for the wire. stream is set for stream
+// transports (tcp, tls), in which case messages have to be framed.
+type encoder func(m *Message, stream bool) []byte
+
+func newEncoder(format string) (encoder, error) {
+	switch format {
+	case "", machine.LogFormatSyslog:
+		return encodeSyslog, nil
+	case machine.LogFormatJSONLines:
+		return encodeJSON, nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", format)
+	}
+}
+
+// encodeSyslog formats the message according to RFC5424, stream transports
+// use octet counting framing as per RFC6587.
+func encodeSyslog(m *Message, stream bool) []byte {
+	msg := fmt.Sprintf("<%d>1 %s %s %s - - - %s",
+		m.Facility*8+m.Severity,
+		m.Timestamp.Format(rfc5424Time),
+		header(m.Hostname, 255),
+		header(m.App, 48),
+		strings.TrimRight(m.Text, "\n"),
+	)
+
+	if stream {
+		return []byte(strconv.Itoa(len(msg)) + " " + msg)
+	}
+
+	return []byte(msg)
+}
+
+// encodeJSON formats the message as a single line JSON object.
+func encodeJSON(m *Message, stream bool) []byte {
+	// nolint: errcheck
+	b, _ := json.Marshal(struct {
+		Timestamp string `json:"timestamp"`
+		Hostname  string `json:"hostname"`
+		App       string `json:"app"`
+		Facility  string `json:"facility"`
+		Severity  string `json:"severity"`
+		Message   string `json:"msg"`
+	}{
+		Timestamp: m.Timestamp.Format(rfc5424Time),
+		Hostname:  m.Hostname,
+		App:       m.App,
+		Facility:  name(facilities, m.Facility),
+		Severity:  name(severities, m.Severity),
+		Message:   strings.TrimRight(m.Text, "\n"),
+	})
+
+	return append(b, '\n')
+}
+
+// header sanitizes a syslog header field: it should be printable ASCII
+// without spaces, and "-" stands for an empty value.
+func header(s string, max int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+
+		return r
+	}, s)
+
+	if s == "" {
+		return "-"
+	}
+
+	if len(s) > max {
+		s = s[:max]
+	}
+
+	return s
+}
+
+func name(names []string, n int) string {
+	if n >= 0 && n < len(names) {
+		return names[n]
+	}
+
+	return strconv.Itoa(n)
+}
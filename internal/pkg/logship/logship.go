@@ -0,0 +1,131 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package logship forwards the logs of a node to remote collectors.
+package logship
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/talos-systems/talos/internal/pkg/kmsg"
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+// Message is a single log message.
+type Message struct {
+	Timestamp time.Time
+	Hostname  string
+	App       string
+	Facility  int
+	Severity  int
+	Text      string
+}
+
+// Shipper forwards log messages to the configured destinations.
+type Shipper struct {
+	senders []*sender
+
+	cancel     context.CancelFunc
+	kernelDone chan struct{}
+}
+
+// New initializes a Shipper for the destinations.
+func New(destinations []machine.LogDestination) (*Shipper, error) {
+	s := &Shipper{}
+
+	for _, destination := range destinations {
+		sender, err := newSender(destination)
+		if err != nil {
+			s.Close()
+
+			return nil, err
+		}
+
+		s.senders = append(s.senders, sender)
+	}
+
+	return s, nil
+}
+
+// Ship queues the message for delivery to every destination.
+func (s *Shipper) Ship(m *Message) {
+	if m.Hostname == "" {
+		// the hostname might change over time, so look it up every time
+		// nolint: errcheck
+		m.Hostname, _ = os.Hostname()
+	}
+
+	for _, sender := range s.senders {
+		sender.enqueue(m)
+	}
+}
+
+// WriteLine ships a line of a service log.
+func (s *Shipper) WriteLine(service string, line []byte) {
+	s.Ship(&Message{
+		Timestamp: time.Now(),
+		App:       service,
+		Facility:  FacilityDaemon,
+		Severity:  SeverityInfo,
+		Text:      string(line),
+	})
+}
+
+// ShipKernel starts shipping the kernel messages, starting with the ones
+// already in the ring buffer, until the shipper is closed.
+func (s *Shipper) ShipKernel() {
+	var ctx context.Context
+
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.kernelDone = make(chan struct{})
+
+	go func() {
+		defer close(s.kernelDone)
+
+		s.shipKernel(ctx)
+	}()
+}
+
+func (s *Shipper) shipKernel(ctx context.Context) {
+	reader, err := kmsg.NewReader(kmsg.Follow())
+	if err != nil {
+		log.Printf("failed to ship kernel messages: %v\n", err)
+		return
+	}
+
+	// nolint: errcheck
+	defer reader.Close()
+
+	for packet := range reader.Scan(ctx) {
+		if packet.Err != nil {
+			log.Printf("failed to ship kernel messages: %v\n", packet.Err)
+			return
+		}
+
+		s.Ship(&Message{
+			Timestamp: packet.Message.Timestamp,
+			App:       "kernel",
+			Facility:  packet.Message.Facility,
+			Severity:  packet.Message.Priority,
+			Text:      packet.Message.Message,
+		})
+	}
+}
+
+// Close stops shipping the kernel messages, and stops delivery to every
+// destination.
+func (s *Shipper) Close() {
+	if s.cancel != nil {
+		s.cancel()
+
+		<-s.kernelDone
+	}
+
+	for _, sender := range s.senders {
+		sender.close()
+	}
+}
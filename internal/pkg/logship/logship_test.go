@@ -0,0 +1,217 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package logship
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+	talosx509 "github.com/talos-systems/talos/pkg/crypto/x509"
+)
+
+type LogShipSuite struct {
+	suite.Suite
+}
+
+func (suite *LogShipSuite) message(text string) *Message {
+	return &Message{
+		Timestamp: time.Date(2019, 11, 1, 12, 30, 0, 123456000, time.UTC),
+		Hostname:  "master-1",
+		App:       "kubelet",
+		Facility:  FacilityDaemon,
+		Severity:  SeverityInfo,
+		Text:      text,
+	}
+}
+
+func (suite *LogShipSuite) TestEncodeSyslog() {
+	suite.Assert().Equal(
+		"<30>1 2019-11-01T12:30:00.123456Z master-1 kubelet - - - started",
+		string(encodeSyslog(suite.message("started\n"), false)),
+	)
+
+	suite.Assert().Equal(
+		"64 <30>1 2019-11-01T12:30:00.123456Z master-1 kubelet - - - started",
+		string(encodeSyslog(suite.message("started"), true)),
+	)
+
+	m := suite.message("hello")
+	m.Hostname = ""
+	m.App = "my app"
+
+	suite.Assert().Equal(
+		"<30>1 2019-11-01T12:30:00.123456Z - my_app - - - hello",
+		string(encodeSyslog(m, false)),
+	)
+}
+
+func (suite *LogShipSuite) TestEncodeJSON() {
+	suite.Assert().Equal(
+		`{"timestamp":"2019-11-01T12:30:00.123456Z","hostname":"master-1","app":"kubelet","facility":"daemon","severity":"info","msg":"started \"x\""}`+"\n",
+		string(encodeJSON(suite.message("started \"x\""), true)),
+	)
+}
+
+func (suite *LogShipSuite) TestInvalidDestination() {
+	for _, endpoint := range []string{"http://1.2.3.4", "udp://", "tcp//1.2.3.4"} {
+		_, err := New([]machine.LogDestination{{Endpoint: endpoint}})
+		suite.Assert().Error(err, endpoint)
+	}
+
+	_, err := New([]machine.LogDestination{{Endpoint: "tcp://127.0.0.1:514", Format: "xml"}})
+	suite.Assert().Error(err)
+
+	ca := &talosx509.PEMEncodedCertificateAndKey{Crt: []byte("not a certificate")}
+
+	_, err = New([]machine.LogDestination{{Endpoint: "tls://127.0.0.1:6514", CA: ca}})
+	suite.Assert().Error(err)
+
+	_, err = New([]machine.LogDestination{{Endpoint: "tcp://127.0.0.1:514", CA: ca}})
+	suite.Assert().Error(err)
+}
+
+func (suite *LogShipSuite) TestTLS() {
+	ca, err := talosx509.NewSelfSignedCertificateAuthority(talosx509.IPAddresses([]net.IP{net.ParseIP("127.0.0.1")}))
+	suite.Require().NoError(err)
+
+	crt, err := tls.X509KeyPair(ca.CrtPEM, ca.KeyPEM)
+	suite.Require().NoError(err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Crt)
+
+	// the collector requires a client certificate signed by the CA
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{crt},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer l.Close()
+
+	shipper, err := New([]machine.LogDestination{{
+		Endpoint:    "tls://" + l.Addr().String(),
+		Format:      machine.LogFormatJSONLines,
+		CA:          &talosx509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM},
+		Certificate: &talosx509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM, Key: ca.KeyPEM},
+	}})
+	suite.Require().NoError(err)
+
+	defer shipper.Close()
+
+	shipper.Ship(suite.message("hello"))
+
+	conn, err := l.Accept()
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(10 * time.Second)))
+
+	scanner := bufio.NewScanner(conn)
+
+	suite.Require().True(scanner.Scan())
+	suite.Assert().Contains(scanner.Text(), `"msg":"hello"`)
+}
+
+func (suite *LogShipSuite) TestBuffering() {
+	// reserve a port, but don't listen yet
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	addr := l.Addr().String()
+	suite.Require().NoError(l.Close())
+
+	shipper, err := New([]machine.LogDestination{{Endpoint: "tcp://" + addr, Format: machine.LogFormatJSONLines}})
+	suite.Require().NoError(err)
+
+	defer shipper.Close()
+
+	shipper.Ship(suite.message("line 1"))
+	shipper.Ship(suite.message("line 2"))
+
+	// collector comes up later
+	time.Sleep(200 * time.Millisecond)
+
+	l, err = net.Listen("tcp", addr)
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer l.Close()
+
+	shipper.Ship(suite.message("line 3"))
+
+	conn, err := l.Accept()
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(10 * time.Second)))
+
+	scanner := bufio.NewScanner(conn)
+
+	for _, expected := range []string{"line 1", "line 2", "line 3"} {
+		suite.Require().True(scanner.Scan())
+		suite.Assert().Contains(scanner.Text(), `"msg":"`+expected+`"`)
+	}
+}
+
+func (suite *LogShipSuite) TestDropOldest() {
+	// sender which is not running, so nothing is delivered
+	s := &sender{}
+	s.cond = sync.NewCond(&s.mu)
+
+	for i := 0; i < queueSize; i++ {
+		s.enqueue(suite.message(strconv.Itoa(i)))
+	}
+
+	s.enqueue(suite.message("overflow"))
+
+	suite.Assert().Len(s.queue, queueSize)
+	suite.Assert().Equal(1, s.dropped)
+	suite.Assert().Equal("1", s.queue[0].Text)
+	suite.Assert().Equal("overflow", s.queue[queueSize-1].Text)
+}
+
+func (suite *LogShipSuite) TestReportDropped() {
+	s := &sender{network: "tcp", address: "127.0.0.1:514"}
+	s.cond = sync.NewCond(&s.mu)
+
+	now := time.Now()
+
+	s.dropped = 3
+	suite.Assert().Equal(3, s.reportDropped(false, now))
+	suite.Assert().Equal(0, s.dropped)
+
+	// rate-limited while the destination stays reachable
+	s.dropped = 2
+	suite.Assert().Equal(0, s.reportDropped(false, now.Add(time.Second)))
+
+	s.dropped = 1
+	suite.Assert().Equal(3, s.reportDropped(false, now.Add(dropReportInterval)))
+
+	// reported right away once the connection is restored
+	s.dropped = 4
+	suite.Assert().Equal(4, s.reportDropped(true, now.Add(dropReportInterval+time.Second)))
+
+	suite.Assert().Equal(0, s.reportDropped(true, now.Add(2*dropReportInterval)))
+}
+
+func TestLogShipSuite(t *testing.T) {
+	suite.Run(t, new(LogShipSuite))
+}
@@ -0,0 +1,332 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package logship
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+const (
+	// queueSize is the number of messages buffered for each destination.
+	queueSize = 8192
+
+	dialTimeout  = 10 * time.Second
+	writeTimeout = 10 * time.Second
+
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+
+	// dropReportInterval is the minimum interval between two reports of the
+	// dropped messages while the destination is reachable.
+	dropReportInterval = time.Minute
+)
+
+// sender delivers messages to a single destination.
+//
+// Messages are queued in memory, and delivered in order by a single
+// goroutine which (re)connects to the destination as needed. If the
+// destination is unreachable for long enough for the queue to fill up, the
+// oldest messages are dropped, so that writers never block. The dropped
+// messages are counted, and reported once the destination is reachable again.
+type sender struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	encode    encoder
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []*Message
+	dropped int
+	closed  bool
+
+	// owned by the run goroutine
+	conn           net.Conn
+	unreported     int
+	lastDropReport time.Time
+
+	closing chan struct{}
+	done    chan struct{}
+}
+
+func newSender(destination machine.LogDestination) (*sender, error) {
+	u, err := url.Parse(destination.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log destination %q: %w", destination.Endpoint, err)
+	}
+
+	s := &sender{
+		address: u.Host,
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	s.cond = sync.NewCond(&s.mu)
+
+	defaultPort := "514"
+
+	switch u.Scheme {
+	case "udp", "tcp":
+		s.network = u.Scheme
+	case "tls":
+		s.network = "tcp"
+		defaultPort = "6514"
+
+		if s.tlsConfig, err = newTLSConfig(destination); err != nil {
+			return nil, fmt.Errorf("invalid log destination %q: %w", destination.Endpoint, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid log destination %q: unsupported scheme %q", destination.Endpoint, u.Scheme)
+	}
+
+	if s.tlsConfig == nil && (destination.CA != nil || destination.Certificate != nil) {
+		return nil, fmt.Errorf("invalid log destination %q: the CA and the certificate require the tls scheme", destination.Endpoint)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid log destination %q: missing host", destination.Endpoint)
+	}
+
+	if u.Port() == "" {
+		s.address = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+
+	if s.encode, err = newEncoder(destination.Format); err != nil {
+		return nil, fmt.Errorf("invalid log destination %q: %w", destination.Endpoint, err)
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// newTLSConfig builds the TLS config of the destination, with the CA and the
+// client certificate it specifies.
+func newTLSConfig(destination machine.LogDestination) (*tls.Config, error) {
+	config := &tls.Config{}
+
+	if destination.CA != nil {
+		config.RootCAs = x509.NewCertPool()
+
+		if !config.RootCAs.AppendCertsFromPEM(destination.CA.Crt) {
+			return nil, errors.New("failed to parse the CA certificate")
+		}
+	}
+
+	if destination.Certificate != nil {
+		cert, err := tls.X509KeyPair(destination.Certificate.Crt, destination.Certificate.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the client certificate: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+func (s *sender) String() string {
+	if s.tlsConfig != nil {
+		return "tls://" + s.address
+	}
+
+	return s.network + "://" + s.address
+}
+
+// enqueue adds the message to the queue, dropping the oldest message if the
+// queue is full.
+func (s *sender) enqueue(m *Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	if len(s.queue) >= queueSize {
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		s.dropped++
+	}
+
+	s.queue = append(s.queue, m)
+	s.cond.Signal()
+}
+
+// close stops the sender, messages which are still queued are discarded.
+func (s *sender) close() {
+	s.mu.Lock()
+
+	if !s.closed {
+		s.closed = true
+		s.cond.Signal()
+
+		close(s.closing)
+	}
+
+	s.mu.Unlock()
+
+	<-s.done
+}
+
+// next waits for the next message to deliver, it returns nil once the
+// sender is closed.
+func (s *sender) next() *Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.queue) == 0 && !s.closed {
+		s.cond.Wait()
+	}
+
+	if s.closed {
+		return nil
+	}
+
+	return s.queue[0]
+}
+
+// reportDropped logs the number of messages dropped since the last report.
+// The report is rate-limited to one per dropReportInterval, unless the
+// connection to the destination was just restored. It returns the number of
+// messages reported.
+func (s *sender) reportDropped(restored bool, now time.Time) int {
+	s.mu.Lock()
+	s.unreported += s.dropped
+	s.dropped = 0
+	s.mu.Unlock()
+
+	if s.unreported == 0 || (!restored && now.Sub(s.lastDropReport) < dropReportInterval) {
+		return 0
+	}
+
+	n := s.unreported
+
+	log.Printf("log destination %s: dropped %d messages\n", s, n)
+
+	s.unreported = 0
+	s.lastDropReport = now
+
+	return n
+}
+
+// pop removes the message which was just delivered from the queue.
+func (s *sender) pop(m *Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the message might have been dropped already while being delivered
+	if len(s.queue) > 0 && s.queue[0] == m {
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+	}
+}
+
+func (s *sender) run() {
+	defer close(s.done)
+
+	defer func() {
+		if s.conn != nil {
+			// nolint: errcheck
+			s.conn.Close()
+		}
+	}()
+
+	backoff := minBackoff
+	failing := false
+
+	for {
+		m := s.next()
+		if m == nil {
+			return
+		}
+
+		err := s.write(m)
+		if err == nil {
+			s.pop(m)
+
+			if failing {
+				log.Printf("log destination %s: connection restored\n", s)
+			}
+
+			s.reportDropped(failing, time.Now())
+
+			backoff = minBackoff
+			failing = false
+
+			continue
+		}
+
+		if !failing {
+			log.Printf("log destination %s: %v\n", s, err)
+		}
+
+		failing = true
+
+		if s.conn != nil {
+			// nolint: errcheck
+			s.conn.Close()
+			s.conn = nil
+		}
+
+		if !s.sleep(backoff) {
+			return
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// sleep waits for d, it returns false if the sender was closed meanwhile.
+func (s *sender) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-s.closing:
+		return false
+	}
+}
+
+func (s *sender) write(m *Message) (err error) {
+	if s.conn == nil {
+		if s.conn, err = s.dial(); err != nil {
+			return err
+		}
+	}
+
+	if err = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	_, err = s.conn.Write(s.encode(m, s.network != "udp"))
+
+	return err
+}
+
+func (s *sender) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	if s.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, s.network, s.address, s.tlsConfig)
+	}
+
+	return dialer.Dial(s.network, s.address)
+}
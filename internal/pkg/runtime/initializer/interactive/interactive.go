@@ -63,18 +63,17 @@ func (i *Interactive) Initialize(r runtime.Runtime) (err error) {
 	}
 
 	cmdline := kernel.NewDefaultCmdline()
-	cmdline.Append("initrd", filepath.Join("/", "default", constants.InitramfsAsset))
 	cmdline.Append(constants.KernelParamPlatform, r.Platform().Name())
 	cmdline.Append(constants.KernelParamConfig, endpoint)
 
 	var inst *installer.Installer
 
-	inst, err = installer.NewInstaller(cmdline, r.Config().Machine().Install())
+	inst, err = installer.NewInstaller(cmdline, r.Sequence(), r.Config().Machine().Install())
 	if err != nil {
 		return err
 	}
 
-	if err = inst.Install(r.Sequence()); err != nil {
+	if err = inst.Install(); err != nil {
 		return fmt.Errorf("failed to install: %w", err)
 	}
 
@@ -21,8 +21,15 @@ const (
 	Shutdown
 	// Upgrade is the upgrade sequence.
 	Upgrade
+	// Reset is the reset sequence.
+	Reset
 )
 
+// String returns the string representation of a Sequence.
+func (s Sequence) String() string {
+	return [...]string{"none", "boot", "shutdown", "upgrade", "reset"}[s]
+}
+
 // Mode is a runtime mode.
 type Mode int
 
@@ -18,7 +18,9 @@ import (
 
 // Options is the functional options struct.
 type Options struct {
-	Size int
+	Size   int
+	Follow bool
+	Tail   int
 }
 
 // Option is the functional option func.
@@ -31,6 +33,22 @@ func Size(s int) Option {
 	}
 }
 
+// Follow sets whether the Chunker keeps waiting for new data once it reaches
+// the end of the file.
+func Follow(f bool) Option {
+	return func(args *Options) {
+		args.Follow = f
+	}
+}
+
+// Tail sets the number of lines from the end of the file the Chunker starts
+// reading from. Zero means the whole file is read.
+func Tail(lines int) Option {
+	return func(args *Options) {
+		args.Tail = lines
+	}
+}
+
 // File is a conecrete type that implements the chunker.Chunker interface.
 type File struct {
 	source  Source
@@ -43,7 +61,8 @@ type Source = *os.File
 // NewChunker initializes a Chunker with default values.
 func NewChunker(source Source, setters ...Option) chunker.Chunker {
 	opts := &Options{
-		Size: 1024,
+		Size:   1024,
+		Follow: true,
 	}
 
 	for _, setter := range setters {
@@ -68,29 +87,58 @@ func (c *File) Read(ctx context.Context) <-chan []byte {
 	go func(ch chan []byte) {
 		defer close(ch)
 
-		watcher, err := fsnotify.NewWatcher()
-		if err != nil {
-			log.Printf("failed to watch: %v\n", err)
-			return
-		}
-		// nolint: errcheck
-		defer watcher.Close()
+		var (
+			events <-chan fsnotify.Event
+			errors <-chan error
+		)
 
-		if err = watcher.Add(filepath.Dir(filename)); err != nil {
-			log.Printf("failed to watch add: %v\n", err)
-			return
+		if c.options.Follow {
+			watcher, err := fsnotify.NewWatcher()
+			if err != nil {
+				log.Printf("failed to watch: %v\n", err)
+				return
+			}
+			// nolint: errcheck
+			defer watcher.Close()
+
+			if err = watcher.Add(filepath.Dir(filename)); err != nil {
+				log.Printf("failed to watch add: %v\n", err)
+				return
+			}
+
+			events, errors = watcher.Events, watcher.Errors
 		}
+
 		offset, err := c.source.Seek(0, io.SeekStart)
 		if err != nil {
 			log.Printf("failed to seek: %v\n", err)
 			return
 		}
 
+		if c.options.Tail > 0 {
+			if offset, err = tailOffset(c.source, c.options.Tail); err != nil {
+				log.Printf("failed to find tail: %v\n", err)
+				return
+			}
+		}
+
+		// source is replaced with a new file if the file is re-created
+		// under the same name (e.g. when the log is rotated)
+		source := c.source
+		reopen := false
+
+		defer func() {
+			if source != c.source {
+				// nolint: errcheck
+				source.Close()
+			}
+		}()
+
 		buf := make([]byte, c.options.Size)
 
 		for {
 			for {
-				n, err := c.source.ReadAt(buf, offset)
+				n, err := source.ReadAt(buf, offset)
 				if err != nil && err != io.EOF {
 					log.Printf("read error: %s\n", err.Error())
 					return
@@ -107,13 +155,18 @@ func (c *File) Read(ctx context.Context) <-chan []byte {
 					select {
 					case <-ctx.Done():
 						return
-					case event := <-watcher.Events:
+					case event := <-events:
 						// drain events while waiting for the buffer to be delivered
 						// otherwise inotify() queue might overflow
-						if event.Name == filename && event.Op == fsnotify.Write {
-							// clear EOF condition (if there was one) to make sure
-							// we read more data
-							err = nil
+						if event.Name == filename {
+							switch event.Op {
+							case fsnotify.Write:
+								// clear EOF condition (if there was one) to make sure
+								// we read more data
+								err = nil
+							case fsnotify.Create:
+								reopen = true
+							}
 						}
 						goto DELIVER
 					case ch <- b:
@@ -125,11 +178,36 @@ func (c *File) Read(ctx context.Context) <-chan []byte {
 				}
 			}
 
+			if !c.options.Follow {
+				return
+			}
+
+			if reopen {
+				// the old file has been read to the end, continue with the
+				// file which replaced it
+				reopen = false
+
+				f, err := os.Open(filename)
+				if err != nil {
+					log.Printf("failed to reopen: %v\n", err)
+					return
+				}
+
+				if source != c.source {
+					// nolint: errcheck
+					source.Close()
+				}
+
+				source, offset = f, 0
+
+				continue
+			}
+
 		WATCH:
 			select {
 			case <-ctx.Done():
 				return
-			case event := <-watcher.Events:
+			case event := <-events:
 				if event.Name != filename {
 					// ignore events for other files
 					goto WATCH
@@ -137,6 +215,9 @@ func (c *File) Read(ctx context.Context) <-chan []byte {
 				switch event.Op {
 				case fsnotify.Write:
 					// new data, run one more loop copying data back to the client
+				case fsnotify.Create:
+					// file was re-created, drain the old one and switch over
+					reopen = true
 				case fsnotify.Remove:
 					log.Printf("file was removed while watching: %s", filename)
 					return
@@ -144,7 +225,7 @@ func (c *File) Read(ctx context.Context) <-chan []byte {
 					log.Printf("ignoring fsnotify event: %v\n", event)
 					goto WATCH
 				}
-			case err := <-watcher.Errors:
+			case err := <-errors:
 				log.Printf("failed to watch: %v\n", err)
 				return
 			}
@@ -153,3 +234,45 @@ func (c *File) Read(ctx context.Context) <-chan []byte {
 
 	return ch
 }
+
+// tailOffset returns the offset of the start of the last n lines of the file.
+//
+// A trailing newline at the very end of the file doesn't start a new line.
+func tailOffset(f Source, n int) (int64, error) {
+	const bufSize = 4096
+
+	st, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	end := st.Size()
+	offset := end
+	buf := make([]byte, bufSize)
+
+	for offset > 0 {
+		size := int64(bufSize)
+		if offset < size {
+			size = offset
+		}
+
+		offset -= size
+
+		if _, err = f.ReadAt(buf[:size], offset); err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		for i := size - 1; i >= 0; i-- {
+			if buf[i] != '\n' || offset+i == end-1 {
+				continue
+			}
+
+			n--
+			if n == 0 {
+				return offset + i + 1, nil
+			}
+		}
+	}
+
+	return 0, nil
+}
@@ -186,6 +186,97 @@ func (suite *FileChunkerSuite) TestStreamingDeleted() {
 	suite.Require().Equal([]byte("abcdefghijklmno"), <-combinedCh)
 }
 
+func (suite *FileChunkerSuite) TestStreamingRotated() {
+	chunker := file.NewChunker(suite.reader)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	chunksCh := chunker.Read(ctx)
+	combinedCh := collectChunks(chunksCh)
+
+	// nolint: errcheck
+	suite.writer.WriteString("abc")
+	// nolint: errcheck
+	suite.writer.WriteString("def")
+	time.Sleep(50 * time.Millisecond)
+
+	// rotate the file: rename it and start writing to a new one
+	suite.Require().NoError(os.Rename(suite.writer.Name(), suite.writer.Name()+".1"))
+
+	// nolint: errcheck
+	suite.writer.WriteString("ghi")
+
+	name := suite.writer.Name()
+	suite.Require().NoError(suite.writer.Close())
+
+	var err error
+
+	suite.writer, err = os.Create(name)
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	suite.writer.WriteString("jkl")
+	time.Sleep(50 * time.Millisecond)
+	// nolint: errcheck
+	suite.writer.WriteString("mno")
+	time.Sleep(50 * time.Millisecond)
+
+	ctxCancel()
+
+	suite.Require().Equal([]byte("abcdefghijklmno"), <-combinedCh)
+}
+
+func (suite *FileChunkerSuite) TestNoFollow() {
+	// nolint: errcheck
+	suite.writer.WriteString("abc\ndef\n")
+
+	chunker := file.NewChunker(suite.reader, file.Follow(false))
+
+	// chunker should terminate once it reaches the end of the file
+	suite.Require().Equal([]byte("abc\ndef\n"), <-collectChunks(chunker.Read(context.Background())))
+}
+
+func (suite *FileChunkerSuite) TestTail() {
+	// nolint: errcheck
+	suite.writer.WriteString("abc\ndef\nghi\njkl\n")
+
+	for _, tc := range []struct {
+		lines    int
+		expected string
+	}{
+		{0, "abc\ndef\nghi\njkl\n"},
+		{1, "jkl\n"},
+		{3, "def\nghi\njkl\n"},
+		{10, "abc\ndef\nghi\njkl\n"},
+	} {
+		chunker := file.NewChunker(suite.reader, file.Follow(false), file.Tail(tc.lines), file.Size(3))
+
+		suite.Assert().Equal([]byte(tc.expected), <-collectChunks(chunker.Read(context.Background())), "tail %d", tc.lines)
+	}
+}
+
+func (suite *FileChunkerSuite) TestTailFollow() {
+	// nolint: errcheck
+	suite.writer.WriteString("abc\ndef\nghi")
+
+	chunker := file.NewChunker(suite.reader, file.Tail(1))
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	combinedCh := collectChunks(chunker.Read(ctx))
+
+	time.Sleep(50 * time.Millisecond)
+	// nolint: errcheck
+	suite.writer.WriteString("\njkl\n")
+	time.Sleep(50 * time.Millisecond)
+
+	ctxCancel()
+
+	suite.Require().Equal([]byte("ghi\njkl\n"), <-combinedCh)
+}
+
 func TestFileChunkerSuite(t *testing.T) {
 	suite.Run(t, new(FileChunkerSuite))
 }
@@ -5,11 +5,20 @@
 package config
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/talos-systems/talos/pkg/config/machine"
 	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
+	talosx509 "github.com/talos-systems/talos/pkg/crypto/x509"
 )
 
 type Suite struct {
@@ -39,3 +48,74 @@ func (suite *Suite) TestNew() {
 		}
 	}
 }
+
+func (suite *Suite) TestValidateImagePolicies() {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	suite.Require().NoError(err)
+
+	publicKey := func(pub interface{}) string {
+		b, err := x509.MarshalPKIXPublicKey(pub)
+		suite.Require().NoError(err)
+
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}))
+	}
+
+	image := "docker.io/autonomy/installer"
+	dgst := digest.FromString("installer").String()
+
+	for _, t := range []struct {
+		policy      machine.ImagePolicy
+		errExpected bool
+	}{
+		{machine.ImagePolicy{Image: image, Digests: []string{dgst}}, false},
+		{machine.ImagePolicy{Image: image, Keys: []string{publicKey(ecdsaKey.Public())}}, false},
+		{machine.ImagePolicy{Image: image}, true},
+		{machine.ImagePolicy{Digests: []string{dgst}}, true},
+		{machine.ImagePolicy{Image: image, Digests: []string{"v0.3.0"}}, true},
+		{machine.ImagePolicy{Image: image, Keys: []string{"key"}}, true},
+		{machine.ImagePolicy{Image: image, Keys: []string{publicKey(rsaKey.Public())}}, true},
+	} {
+		err := v1alpha1.ValidateImagePolicies([]machine.ImagePolicy{t.policy})
+
+		if t.errExpected {
+			suite.Require().Error(err)
+		} else {
+			suite.Require().NoError(err)
+		}
+	}
+}
+
+func (suite *Suite) TestValidateLogDestinations() {
+	ca, err := talosx509.NewSelfSignedCertificateAuthority()
+	suite.Require().NoError(err)
+
+	caCrt := &talosx509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM}
+	clientCrt := &talosx509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM, Key: ca.KeyPEM}
+
+	for _, t := range []struct {
+		destination machine.LogDestination
+		errExpected bool
+	}{
+		{machine.LogDestination{Endpoint: "udp://10.0.0.1:514"}, false},
+		{machine.LogDestination{Endpoint: "tcp://logs.example.com", Format: machine.LogFormatJSONLines}, false},
+		{machine.LogDestination{Endpoint: "tls://logs.example.com:6514", CA: caCrt, Certificate: clientCrt}, false},
+		{machine.LogDestination{Endpoint: "http://logs.example.com"}, true},
+		{machine.LogDestination{Endpoint: "udp://"}, true},
+		{machine.LogDestination{Endpoint: "10.0.0.1:514"}, true},
+		{machine.LogDestination{Endpoint: "udp://10.0.0.1:514", Format: "xml"}, true},
+		{machine.LogDestination{Endpoint: "tcp://10.0.0.1:514", CA: caCrt}, true},
+		{machine.LogDestination{Endpoint: "tls://10.0.0.1:6514", CA: &talosx509.PEMEncodedCertificateAndKey{Crt: []byte("ca")}}, true},
+		{machine.LogDestination{Endpoint: "tls://10.0.0.1:6514", Certificate: caCrt}, true},
+	} {
+		err := v1alpha1.ValidateLogDestinations([]machine.LogDestination{t.destination})
+
+		if t.errExpected {
+			suite.Require().Error(err, t.destination.Endpoint)
+		} else {
+			suite.Require().NoError(err, t.destination.Endpoint)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package machine
 
 import (
 	"os"
+	"time"
 
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 
@@ -36,6 +37,8 @@ type Machine interface {
 	Files() []File
 	Type() Type
 	Kubelet() Kubelet
+	Logging() Logging
+	ImagePolicies() []ImagePolicy
 }
 
 // Env represents a set of environment variables.
@@ -48,6 +51,13 @@ type File struct {
 	Path        string      `yaml:"path"`
 }
 
+// ImagePolicy represents the trust policy for the images of a repository.
+type ImagePolicy struct {
+	Image   string   `yaml:"image"`
+	Digests []string `yaml:"digests,omitempty"`
+	Keys    []string `yaml:"keys,omitempty"`
+}
+
 // Security defines the requirements for a config that pertains to security
 // related options.
 type Security interface {
@@ -64,17 +74,24 @@ type Network interface {
 	SetHostname(string)
 	Resolvers() []string
 	Devices() []Device
+	Rules() []Rule
+	DNSCache() *DNSCache
 }
 
 // Device represents a network interface.
 type Device struct {
-	Interface string  `yaml:"interface"`
-	CIDR      string  `yaml:"cidr"`
-	Routes    []Route `yaml:"routes"`
-	Bond      *Bond   `yaml:"bond"`
-	MTU       int     `yaml:"mtu"`
-	DHCP      bool    `yaml:"dhcp"`
-	Ignore    bool    `yaml:"ignore"`
+	Interface string     `yaml:"interface"`
+	CIDR      string     `yaml:"cidr"`
+	Routes    []Route    `yaml:"routes"`
+	Bond      *Bond      `yaml:"bond"`
+	Vlans     []*Vlan    `yaml:"vlans"`
+	Wireguard *Wireguard `yaml:"wireguard"`
+	VIP       *VIP       `yaml:"vip"`
+	MTU       int        `yaml:"mtu"`
+	DHCP      bool       `yaml:"dhcp"`
+	DHCP6     bool       `yaml:"dhcp6"`
+	SLAAC     bool       `yaml:"slaac"`
+	Ignore    bool       `yaml:"ignore"`
 }
 
 // Bond contains the various options for configuring a
@@ -86,10 +103,82 @@ type Bond struct {
 	Interfaces []string `yaml:"interfaces"`
 }
 
+// Vlan represents an 802.1Q VLAN on top of a device.
+type Vlan struct {
+	ID     uint16  `yaml:"vlanId"`
+	CIDR   string  `yaml:"cidr"`
+	Routes []Route `yaml:"routes"`
+	DHCP   bool    `yaml:"dhcp"`
+	DHCP6  bool    `yaml:"dhcp6"`
+	SLAAC  bool    `yaml:"slaac"`
+	MTU    int     `yaml:"mtu"`
+}
+
+// VIP contains the options for a virtual IP shared by the control plane
+// nodes.
+type VIP struct {
+	IP string `yaml:"ip"`
+}
+
+// Wireguard contains the options for configuring a Wireguard interface.
+type Wireguard struct {
+	PrivateKey string          `yaml:"privateKey"`
+	ListenPort int             `yaml:"listenPort"`
+	Peers      []WireguardPeer `yaml:"peers"`
+}
+
+// WireguardPeer represents a peer of a Wireguard interface.
+type WireguardPeer struct {
+	PublicKey  string   `yaml:"publicKey"`
+	Endpoint   string   `yaml:"endpoint"`
+	AllowedIPs []string `yaml:"allowedIPs"`
+	// PersistentKeepalive is the keepalive interval in seconds.
+	PersistentKeepalive int `yaml:"persistentKeepalive"`
+}
+
 // Route represents a network route.
 type Route struct {
 	Network string `yaml:"network"`
 	Gateway string `yaml:"gateway"`
+	Source  string `yaml:"source"`
+	Metric  uint32 `yaml:"metric"`
+	MTU     uint32 `yaml:"mtu"`
+	OnLink  bool   `yaml:"onlink"`
+	Table   uint32 `yaml:"table"`
+}
+
+// Rule represents a routing policy rule, the packets which match the rule
+// are routed with the routes of the table.
+type Rule struct {
+	From     string `yaml:"from"`
+	To       string `yaml:"to"`
+	IIF      string `yaml:"iif"`
+	OIF      string `yaml:"oif"`
+	FwMark   uint32 `yaml:"fwmark"`
+	Table    uint32 `yaml:"table"`
+	Priority uint32 `yaml:"priority"`
+}
+
+// DNSCache represents the local caching DNS forwarder. The queries of the
+// domains of the forwards are sent to the upstreams of the forwards, the
+// other queries are sent to the upstreams of the cache.
+type DNSCache struct {
+	Enabled   bool          `yaml:"enabled"`
+	Upstreams []DNSUpstream `yaml:"upstreams,omitempty"`
+	Forwards  []DNSForward  `yaml:"forwards,omitempty"`
+}
+
+// DNSForward represents the upstreams of a domain and its subdomains.
+type DNSForward struct {
+	Domain    string        `yaml:"domain"`
+	Upstreams []DNSUpstream `yaml:"upstreams"`
+}
+
+// DNSUpstream represents an upstream DNS server. The queries are sent over
+// DNS-over-TLS if the TLS server name is set.
+type DNSUpstream struct {
+	Address       string `yaml:"address"`
+	TLSServerName string `yaml:"tlsServerName,omitempty"`
 }
 
 // Install defines the requirements for a config that pertains to install
@@ -120,6 +209,8 @@ type Partition struct {
 // options.
 type Time interface {
 	Servers() []string
+	SyncWait() bool
+	SyncTimeout() time.Duration
 }
 
 // Kubelet defines the requirements for a config that pertains to kubelet
@@ -128,3 +219,31 @@ type Kubelet interface {
 	ExtraArgs() map[string]string
 	ExtraMounts() []specs.Mount
 }
+
+// Logging defines the requirements for a config that pertains to service log
+// related options.
+type Logging interface {
+	MaxSize() int64
+	MaxFiles() int
+	Destinations() []LogDestination
+}
+
+// LogDestination represents a remote endpoint the service and kernel logs
+// are forwarded to.
+type LogDestination struct {
+	Endpoint string `yaml:"endpoint"`
+	Format   string `yaml:"format,omitempty"`
+	// CA verifies the certificate of a tls endpoint, the system CAs are used
+	// if it is not set.
+	CA *x509.PEMEncodedCertificateAndKey `yaml:"ca,omitempty"`
+	// Certificate is the client certificate presented to a tls endpoint.
+	Certificate *x509.PEMEncodedCertificateAndKey `yaml:"certificate,omitempty"`
+}
+
+const (
+	// LogFormatSyslog is the RFC5424 syslog format.
+	LogFormatSyslog = "syslog"
+	// LogFormatJSONLines is the JSON lines format, one JSON object per
+	// message.
+	LogFormatJSONLines = "json_lines"
+)
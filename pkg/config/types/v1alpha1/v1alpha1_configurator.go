@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -66,6 +67,33 @@ func (c *Config) Validate(mode runtime.Mode) error {
 		}
 	}
 
+	if err := ValidateImagePolicies(c.MachineConfig.MachineImagePolicies); err != nil {
+		return fmt.Errorf("invalid image policies: %w", err)
+	}
+
+	for _, device := range c.MachineConfig.Network().Devices() {
+		device := device
+		if device.VIP == nil {
+			continue
+		}
+
+		if c.MachineConfig.Type() == machine.Worker {
+			return fmt.Errorf("the virtual IP of %q requires a control plane machine", device.Interface)
+		}
+
+		if err := Validate(&device, CheckDeviceVIP()); err != nil {
+			return fmt.Errorf("invalid virtual IP of %q: %w", device.Interface, err)
+		}
+	}
+
+	if err := ValidateDNSCache(c.MachineConfig.Network().DNSCache()); err != nil {
+		return fmt.Errorf("invalid dns cache: %w", err)
+	}
+
+	if err := ValidateLogDestinations(c.MachineConfig.Logging().Destinations()); err != nil {
+		return fmt.Errorf("invalid log destinations: %w", err)
+	}
+
 	return nil
 }
 
@@ -121,6 +149,20 @@ func (m *MachineConfig) Kubelet() machine.Kubelet {
 	return m.MachineKubelet
 }
 
+// Logging implements the Configurator interface.
+func (m *MachineConfig) Logging() machine.Logging {
+	if m.MachineLogging == nil {
+		return &LoggingConfig{}
+	}
+
+	return m.MachineLogging
+}
+
+// ImagePolicies implements the Configurator interface.
+func (m *MachineConfig) ImagePolicies() []machine.ImagePolicy {
+	return m.MachineImagePolicies
+}
+
 // Env implements the Configurator interface.
 func (m *MachineConfig) Env() machine.Env {
 	return m.MachineEnv
@@ -340,11 +382,62 @@ func (n *NetworkConfig) Resolvers() []string {
 	return n.NameServers
 }
 
+// Rules implements the Configurator interface.
+func (n *NetworkConfig) Rules() []machine.Rule {
+	return n.NetworkRules
+}
+
+// DNSCache implements the Configurator interface.
+func (n *NetworkConfig) DNSCache() *machine.DNSCache {
+	if n.NetworkDNSCache == nil {
+		return &machine.DNSCache{}
+	}
+
+	return n.NetworkDNSCache
+}
+
 // Servers implements the Configurator interface.
 func (t *TimeConfig) Servers() []string {
 	return t.TimeServers
 }
 
+// SyncWait implements the Configurator interface.
+func (t *TimeConfig) SyncWait() bool {
+	return !t.TimeDisableSyncWait
+}
+
+// SyncTimeout implements the Configurator interface.
+func (t *TimeConfig) SyncTimeout() time.Duration {
+	if t.TimeSyncTimeout == 0 {
+		return constants.DefaultTimeSyncTimeout
+	}
+
+	return t.TimeSyncTimeout
+}
+
+// MaxSize implements the Configurator interface.
+func (l *LoggingConfig) MaxSize() int64 {
+	if l.LoggingMaxSize == 0 {
+		return constants.DefaultLogMaxSize
+	}
+
+	return l.LoggingMaxSize
+}
+
+// MaxFiles implements the Configurator interface.
+func (l *LoggingConfig) MaxFiles() int {
+	if l.LoggingMaxFiles == 0 {
+		return constants.DefaultLogMaxFiles
+	}
+
+	return l.LoggingMaxFiles
+}
+
+// Destinations implements the Configurator interface.
+func (l *LoggingConfig) Destinations() []machine.LogDestination {
+	return l.LoggingDestinations
+}
+
 // Image implements the Configurator interface.
 func (i *InstallConfig) Image() string {
 	return i.InstallImage
@@ -8,6 +8,7 @@ package v1alpha1
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/talos-systems/talos/pkg/config/machine"
 	"github.com/talos-systems/talos/pkg/crypto/x509"
@@ -165,6 +166,35 @@ type MachineConfig struct {
 	//         servers:
 	//           - time.cloudflare.com
 	MachineTime *TimeConfig `yaml:"time,omitempty"`
+	//   description: |
+	//     Used to configure the machine's service logs.
+	//   examples:
+	//     - |
+	//       logging:
+	//         maxSize: 10485760
+	//         maxFiles: 3
+	MachineLogging *LoggingConfig `yaml:"logging,omitempty"`
+	//   description: |
+	//     Pins the trust for the images which Talos pulls itself: the installer image, etcd, and hyperkube for the kubelet.
+	//     The images pulled by the kubelet through the CRI, the control plane and all the pods, are not covered by the policies.
+	//     The `image` is a repository, or a repository prefix ending with `*`, the first policy matching an image applies.
+	//     An image matching a policy is only pulled if its digest is one of the `digests`,
+	//     and if it is signed by one of the PEM encoded public `keys` (ECDSA or Ed25519).
+	//     Signatures are looked up in the same repository as cosign stores them, under the tag `sha256-<digest>.sig`.
+	//     Images which match no policy are pulled as is.
+	//   examples:
+	//     - |
+	//       imagePolicies:
+	//         - image: k8s.gcr.io/etcd
+	//           digests:
+	//             - sha256:12c2c5e5731c3bcd56e6f1c05c0f9198b6f06793fa7fca2fb43aab9622dc4afa
+	//         - image: docker.io/autonomy/*
+	//           keys:
+	//             - |
+	//               -----BEGIN PUBLIC KEY-----
+	//               ...
+	//               -----END PUBLIC KEY-----
+	MachineImagePolicies []machine.ImagePolicy `yaml:"imagePolicies,omitempty"`
 }
 
 // ClusterConfig reperesents the cluster-wide config values
@@ -286,7 +316,7 @@ type NetworkConfig struct {
 	//     ##### machine.network.interfaces.cidr
 	//
 	//     `cidr` is used to specify a static IP address to the interface.
-	//     This should be in proper CIDR notation ( `192.168.2.5/24` ).
+	//     This should be in proper CIDR notation ( `192.168.2.5/24` or `2001:db8::5/64` ).
 	//
 	//     > Note: This option is mutually exclusive with DHCP.
 	//
@@ -303,6 +333,23 @@ type NetworkConfig struct {
 	//
 	//     > Note: This option is mutually exclusive with CIDR.
 	//
+	//     ##### machine.network.interfaces.dhcp6
+	//
+	//     `dhcp6` is used to specify that this device should get an IPv6 address via DHCPv6.
+	//     The default route is learned from router advertisements.
+	//     It can be combined with `dhcp` or an IPv4 `cidr` for dual-stack addressing.
+	//
+	//     The following DHCPv6 options are supported:
+	//
+	//     - `OptionIANA`
+	//     - `OptionDNSRecursiveNameServer`
+	//
+	//     ##### machine.network.interfaces.slaac
+	//
+	//     `slaac` is used to specify that this device should get an IPv6 address via stateless address autoconfiguration from router advertisements.
+	//     The nameservers are taken from the RDNSS option of the router advertisements.
+	//     It can be combined with `dhcp` or an IPv4 `cidr` for dual-stack addressing.
+	//
 	//     ##### machine.network.interfaces.ignore
 	//
 	//     `ignore` is used to exclude a specific interface from configuration.
@@ -313,12 +360,140 @@ type NetworkConfig struct {
 	//     `routes` is used to specify static routes that may be necessary.
 	//     This parameter is optional.
 	//
-	//     Routes can be repeated and includes a `Network` and `Gateway` field.
+	//     Routes can be repeated and includes a `network` and `gateway` field.
+	//     The optional `source` field sets the preferred source address of the route, it defaults to the address of the interface.
+	//     `metric` sets the priority of the route, `mtu` the MTU of the path, and `onlink` makes the gateway reachable over the interface even if it isn't within the subnet of the interface.
+	//     `table` adds the route to a routing table other than the main table, see `rules`.
+	//
+	//     ```yaml
+	//     interfaces:
+	//       - interface: eth1
+	//         cidr: 10.5.0.10/24
+	//         routes:
+	//           - network: 0.0.0.0/0
+	//             gateway: 10.5.0.1
+	//             table: 100
+	//           - network: 10.6.0.0/16
+	//             gateway: 10.5.0.1
+	//             metric: 100
+	//             mtu: 9000
+	//     ```
+	//
+	//     ##### machine.network.interfaces.bond
+	//
+	//     `bond` is used to create a bond with the name of the `interface`, over the member `interfaces`.
+	//     The addressing of the interface is configured on the bond.
+	//     This parameter is optional.
+	//
+	//     ```yaml
+	//     interfaces:
+	//       - interface: bond0
+	//         dhcp: true
+	//         bond:
+	//           mode: 802.3ad
+	//           hashpolicy: layer3+4
+	//           lacprate: fast
+	//           interfaces:
+	//             - eth0
+	//             - eth1
+	//     ```
+	//
+	//     ##### machine.network.interfaces.vlans
+	//
+	//     `vlans` is used to create 802.1Q VLANs on top of the interface, named `<interface>.<vlanId>`.
+	//     Each VLAN has its own addressing, configured with `cidr`, `dhcp`, `dhcp6` or `slaac`, `routes` and `mtu` like an interface.
+	//     This parameter is optional.
+	//
+	//     ```yaml
+	//     interfaces:
+	//       - interface: eth0
+	//         dhcp: true
+	//         vlans:
+	//           - vlanId: 100
+	//             cidr: 10.100.0.5/24
+	//           - vlanId: 200
+	//             dhcp: true
+	//     ```
+	//
+	//     ##### machine.network.interfaces.wireguard
+	//
+	//     `wireguard` is used to create a Wireguard interface, which is configured with the base64 encoded `privateKey`, the `listenPort` and the `peers`.
+	//     Each peer is configured with its base64 encoded `publicKey`, its `endpoint` (`host:port`), the `allowedIPs` which are routed to and accepted from the peer, and the `persistentKeepalive` interval in seconds.
+	//     The interface is addressed with `cidr` and `routes`, the routes over the tunnel use the tunnel address of a peer as the `gateway`.
+	//     This parameter is optional.
+	//
+	//     ```yaml
+	//     interfaces:
+	//       - interface: wg0
+	//         cidr: 10.10.0.2/24
+	//         wireguard:
+	//           privateKey: EG6iSp2vW6r/ZgzUSEJjOMbFJtEl2RSWxkVwhUr8kHY=
+	//           listenPort: 51820
+	//           peers:
+	//             - publicKey: ABCDEF+Gh1234abcdEFGH/ijkl5678MNOP90qrstuvw=
+	//               endpoint: 192.168.0.1:51820
+	//               allowedIPs:
+	//                 - 10.10.0.0/24
+	//               persistentKeepalive: 25
+	//     ```
+	//
+	//     ##### machine.network.interfaces.vip
+	//
+	//     `vip` is used to share a virtual IPv4 address (`ip`) between the control plane nodes, which can be used as the cluster endpoint instead of an external load balancer.
+	//     The control plane nodes elect the owner of the address through etcd, the owner adds the address to the interface and announces it with gratuitous ARP.
+	//     The address moves to another control plane node within 10 seconds once the owner goes away.
+	//     The address must be in the subnet of the interface, and the interfaces of all the control plane nodes must be in the same layer 2 network.
+	//     Since the owner is elected through etcd, the address is available once etcd is running on the node.
+	//     This parameter is optional, and only valid on control plane nodes.
+	//
+	//     ```yaml
+	//     interfaces:
+	//       - interface: eth0
+	//         cidr: 192.168.0.2/24
+	//         vip:
+	//           ip: 192.168.0.15
+	//     ```
 	NetworkInterfaces []machine.Device `yaml:"interfaces,omitempty"`
 	//   description: |
 	//     Used to statically set the nameservers for the host.
 	//     Defaults to `1.1.1.1` and `8.8.8.8`
 	NameServers []string `yaml:"nameservers,omitempty"`
+	//   description: |
+	//     `rules` is used to define routing policy rules, like `ip rule`.
+	//     The packets which match all the selectors of a rule are routed with the routes of the rule's `table`.
+	//     The selectors are the `from` and `to` prefixes, the input (`iif`) and output (`oif`) interfaces and the firewall mark (`fwmark`).
+	//     Rules without a `from` or `to` prefix apply to both IPv4 and IPv6.
+	//     `priority` orders the rules, the rules with lower priorities are matched first.
+	//     This parameter is optional.
+	//   examples:
+	//     - |
+	//       rules:
+	//         - from: 10.5.0.0/24
+	//           table: 100
+	//           priority: 1000
+	NetworkRules []machine.Rule `yaml:"rules,omitempty"`
+	//   description: |
+	//     `dnsCache` is used to run a local caching DNS forwarder on the node, which the host services resolve names with.
+	//     The forwarder caches the answers, and sends the queries to the healthy `upstreams`, the upstreams which don't respond are health-checked until they do.
+	//     The `upstreams` default to the `nameservers`, or the DNS servers received through DHCP.
+	//     The queries of the `domain` of a forward, and of its subdomains, are sent to the `upstreams` of the forward.
+	//     The queries are sent over DNS-over-TLS to the upstreams with a `tlsServerName`, which is verified against the certificate of the upstream.
+	//     The `address` of an upstream is an IP address, with an optional port, which defaults to 53, or 853 for DNS-over-TLS.
+	//     Kubernetes pods keep resolving names with the upstreams.
+	//     This parameter is optional.
+	//   examples:
+	//     - |
+	//       dnsCache:
+	//         enabled: true
+	//         upstreams:
+	//           - address: 1.1.1.1
+	//             tlsServerName: cloudflare-dns.com
+	//         forwards:
+	//           - domain: corp.example.com
+	//             upstreams:
+	//               - address: 10.0.0.53
+	//               - address: 10.0.1.53
+	NetworkDNSCache *machine.DNSCache `yaml:"dnsCache,omitempty"`
 }
 
 // InstallConfig represents the installation options for preparing a node.
@@ -375,8 +550,64 @@ type TimeConfig struct {
 	//     Specifies time (ntp) servers to use for setting system time.
 	//     Defaults to `pool.ntp.org`
 	//
-	//     > Note: This parameter only supports a single time server
+	//     > Note: All of the servers are queried, the servers whose time disagrees with the majority of the servers are ignored.
+	//     > Small offsets are slewed, larger offsets step the clock.
+	//
+	//     Servers prefixed with `nts://` are authenticated with Network Time Security (RFC 8915), the address is the address of the NTS-KE server, the port defaults to 4460.
+	//     Replies of these servers which aren't authenticated are refused.
+	//     Until the time is synchronized, the certificates of the NTS-KE servers are verified at the last time ntpd synchronized, if the clock is behind it.
+	//     A node whose clock is behind the validity of these certificates, and which never synchronized, needs a server without `nts://` to synchronize the first time.
+	//   examples:
+	//     - |
+	//       servers:
+	//         - pool.ntp.org
+	//         - nts://time.cloudflare.com
 	TimeServers []string `yaml:"servers,omitempty"`
+	//   description: |
+	//     Disables waiting for ntpd to synchronize the time before starting etcd, trustd and the kubelet.
+	//     Defaults to `false`.
+	TimeDisableSyncWait bool `yaml:"disableSyncWait,omitempty"`
+	//   description: |
+	//     The time etcd, trustd and the kubelet wait for ntpd to synchronize the time.
+	//     Once it has elapsed, they start anyway, the clock is never behind the last time ntpd synchronized, which Talos restores on boot.
+	//     Defaults to `2m`.
+	//   examples:
+	//     - |
+	//       syncTimeout: 5m
+	TimeSyncTimeout time.Duration `yaml:"syncTimeout,omitempty"`
+}
+
+// LoggingConfig represents the options for service logs on a node.
+type LoggingConfig struct {
+	//   description: |
+	//     The size in bytes a service log file may reach before it is rotated.
+	//     Defaults to `10485760` (10 MiB).
+	LoggingMaxSize int64 `yaml:"maxSize,omitempty"`
+	//   description: |
+	//     The number of rotated log files to keep for each service, in addition to the active one.
+	//     Older files are removed.
+	//     Defaults to `3`.
+	LoggingMaxFiles int `yaml:"maxFiles,omitempty"`
+	//   description: |
+	//     The remote endpoints the service logs and kernel messages are forwarded to.
+	//     The endpoint is a URL with the scheme `udp`, `tcp` or `tls` (TCP with TLS).
+	//     The format is either `syslog` (RFC5424, the default) or `json_lines`.
+	//     A `tls` endpoint is verified with the system CAs, or with the base64 encoded `ca` certificate;
+	//     the base64 encoded client `certificate` (`crt` and `key`) is presented if the endpoint requires one.
+	//     While an endpoint is unreachable, messages are buffered in memory;
+	//     once the buffer is full, the oldest messages are dropped.
+	//   examples:
+	//     - |
+	//       destinations:
+	//         - endpoint: udp://10.0.0.1:514
+	//         - endpoint: tls://logs.example.com:6514
+	//           format: json_lines
+	//           ca:
+	//             crt: LS0tLS1CRUdJTiBDRV...
+	//           certificate:
+	//             crt: LS0tLS1CRUdJTiBDRV...
+	//             key: LS0tLS1CRUdJTiBFRD...
+	LoggingDestinations []machine.LogDestination `yaml:"destinations,omitempty"`
 }
 
 // Endpoint struct holds the endpoint url parsed out of machine config.
@@ -531,18 +762,87 @@ type ClusterNetworkConfig struct {
 type Bond struct {
 	//   description: |
 	//     The bond mode.
+	//     Defaults to `balance-rr`.
+	//   values:
+	//     - balance-rr
+	//     - active-backup
+	//     - balance-xor
+	//     - broadcast
+	//     - 802.3ad
+	//     - balance-tlb
+	//     - balance-alb
 	Mode string `yaml:"mode"`
 	//   description: |
-	//     The hash policy.
+	//     The transmit hash policy, used by the `balance-xor` and `802.3ad` modes.
+	//     Defaults to `layer2`.
+	//   values:
+	//     - layer2
+	//     - layer2+3
+	//     - layer3+4
+	//     - encap2+3
+	//     - encap3+4
 	HashPolicy string `yaml:"hashpolicy"`
 	//   description: |
-	//     The LACP rate.
+	//     The rate at which the link partner is asked to transmit LACPDUs in `802.3ad` mode.
+	//     Defaults to `slow`.
+	//   values:
+	//     - slow
+	//     - fast
 	LACPRate string `yaml:"lacprate"`
 	//   description: |
 	//     The interfaces if which the bond should be comprised of.
+	//     The addressing is configured on the bond, the member interfaces are not configured by themselves.
+	//   examples:
+	//     - |
+	//       interfaces:
+	//         - eth0
+	//         - eth1
 	Interfaces []string `yaml:"interfaces"`
 }
 
+// Vlan represents an 802.1Q VLAN on top of an interface.
+type Vlan struct {
+	//   description: |
+	//     The VLAN ID, the VLAN interface is named `<interface>.<vlanId>`.
+	//   examples:
+	//     - 100
+	ID uint16 `yaml:"vlanId"`
+	//   description: |
+	//     The static IP address of the VLAN interface in CIDR notation.
+	//     Mutually exclusive with `dhcp`.
+	CIDR string `yaml:"cidr"`
+	//   description: |
+	//     The static routes of the VLAN interface.
+	Routes []Route `yaml:"routes"`
+	//   description: |
+	//     Indicates if the VLAN interface is configured via DHCP.
+	//   values:
+	//     - true
+	//     - yes
+	//     - false
+	//     - no
+	DHCP bool `yaml:"dhcp"`
+	//   description: |
+	//     Indicates if the VLAN interface gets an IPv6 address via DHCPv6.
+	//   values:
+	//     - true
+	//     - yes
+	//     - false
+	//     - no
+	DHCP6 bool `yaml:"dhcp6"`
+	//   description: |
+	//     Indicates if the VLAN interface gets an IPv6 address via stateless address autoconfiguration.
+	//   values:
+	//     - true
+	//     - yes
+	//     - false
+	//     - no
+	SLAAC bool `yaml:"slaac"`
+	//   description: |
+	//     The MTU of the VLAN interface, it can't exceed the MTU of the interface.
+	MTU int `yaml:"mtu"`
+}
+
 // Route represents a network route.
 type Route struct {
 	//   description: |
@@ -7,12 +7,20 @@
 package v1alpha1
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"strconv"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/opencontainers/go-digest"
 
 	"github.com/talos-systems/talos/pkg/config/machine"
 )
@@ -46,6 +54,28 @@ var (
 	ErrBadAddressing = errors.New("invalid network device addressing method")
 	// ErrInvalidAddress denotes that a bad address was provided
 	ErrInvalidAddress = errors.New("invalid network address")
+	// ErrInvalidWireguardKey denotes that a bad Wireguard key was provided
+	ErrInvalidWireguardKey = errors.New("invalid wireguard key")
+
+	// Images
+
+	// ErrInvalidDigest denotes that a bad image digest was provided
+	ErrInvalidDigest = errors.New("invalid image digest")
+	// ErrInvalidPublicKey denotes that a bad or unsupported public key was
+	// provided
+	ErrInvalidPublicKey = errors.New("invalid public key")
+
+	// Logging
+
+	// ErrInvalidLogEndpoint denotes that a bad log destination endpoint was
+	// provided
+	ErrInvalidLogEndpoint = errors.New("invalid log endpoint")
+	// ErrUnsupportedLogFormat denotes that the log destination format is
+	// not supported
+	ErrUnsupportedLogFormat = errors.New("unsupported log format")
+	// ErrLogTLSRequired denotes that the TLS settings of a log destination
+	// are set for an endpoint without TLS
+	ErrLogTLSRequired = errors.New("the tls scheme is required")
 )
 
 // NetworkDeviceCheck defines the function type for checks.
@@ -89,21 +119,23 @@ func CheckDeviceAddressing() NetworkDeviceCheck {
 	return func(d *machine.Device) error {
 		var result *multierror.Error
 
-		// Test for both dhcp and cidr specified
-		if d.DHCP && d.CIDR != "" {
-			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device", "", ErrBadAddressing))
-		}
-
 		// test for neither dhcp nor cidr specified
-		if !d.DHCP && d.CIDR == "" {
+		if !d.DHCP && d.CIDR == "" && !d.DHCP6 && !d.SLAAC {
 			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device", "", ErrBadAddressing))
 		}
 
 		// ensure cidr is a valid address
 		if d.CIDR != "" {
-			if _, _, err := net.ParseCIDR(d.CIDR); err != nil {
+			ip, _, err := net.ParseCIDR(d.CIDR)
+			if err != nil {
 				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.CIDR", "", err))
 			}
+
+			// Test for both dhcp and an IPv4 cidr specified, an IPv6 cidr
+			// can be combined with dhcp for dual-stack addressing
+			if ip != nil && ip.To4() != nil && d.DHCP {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device", "", ErrBadAddressing))
+			}
 		}
 
 		return result.ErrorOrNil()
@@ -128,7 +160,223 @@ func CheckDeviceRoutes() NetworkDeviceCheck {
 			if ip := net.ParseIP(route.Gateway); ip == nil {
 				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Gateway", route.Gateway, ErrInvalidAddress))
 			}
+
+			if route.Source != "" && net.ParseIP(route.Source) == nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Source", route.Source, ErrInvalidAddress))
+			}
 		}
 		return result.ErrorOrNil()
 	}
 }
+
+// CheckDeviceWireguard ensures that the Wireguard keys, endpoints and allowed
+// IPs are valid.
+// nolint: dupl
+func CheckDeviceWireguard() NetworkDeviceCheck {
+	return func(d *machine.Device) error {
+		var result *multierror.Error
+
+		if d.Wireguard == nil {
+			return result.ErrorOrNil()
+		}
+
+		if !validWireguardKey(d.Wireguard.PrivateKey) {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.wireguard.privateKey", "", ErrInvalidWireguardKey))
+		}
+
+		for idx, peer := range d.Wireguard.Peers {
+			prefix := "networking.os.device.wireguard.peers[" + strconv.Itoa(idx) + "]"
+
+			if !validWireguardKey(peer.PublicKey) {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".publicKey", peer.PublicKey, ErrInvalidWireguardKey))
+			}
+
+			if peer.Endpoint != "" {
+				if _, _, err := net.SplitHostPort(peer.Endpoint); err != nil {
+					result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".endpoint", peer.Endpoint, ErrInvalidAddress))
+				}
+			}
+
+			for _, cidr := range peer.AllowedIPs {
+				if _, _, err := net.ParseCIDR(cidr); err != nil {
+					result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".allowedIPs", cidr, ErrInvalidAddress))
+				}
+			}
+		}
+
+		return result.ErrorOrNil()
+	}
+}
+
+// CheckDeviceVIP ensures that the virtual IP is a valid IPv4 address.
+// nolint: dupl
+func CheckDeviceVIP() NetworkDeviceCheck {
+	return func(d *machine.Device) error {
+		var result *multierror.Error
+
+		if d.VIP == nil {
+			return result.ErrorOrNil()
+		}
+
+		if ip := net.ParseIP(d.VIP.IP); ip == nil || ip.To4() == nil {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.vip.ip", d.VIP.IP, ErrInvalidAddress))
+		}
+
+		return result.ErrorOrNil()
+	}
+}
+
+// validWireguardKey checks that the key is a base64 encoded 32 byte key.
+func validWireguardKey(s string) bool {
+	key, err := base64.StdEncoding.DecodeString(s)
+
+	return err == nil && len(key) == 32
+}
+
+// ValidateDNSCache ensures that the addresses of the upstreams and the
+// domains of the forwards of the DNS cache are valid.
+func ValidateDNSCache(c *machine.DNSCache) error {
+	var result *multierror.Error
+
+	checkUpstreams := func(prefix string, upstreams []machine.DNSUpstream) {
+		for idx, upstream := range upstreams {
+			if !validUpstreamAddress(upstream.Address) {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".upstreams["+strconv.Itoa(idx)+"].address", upstream.Address, ErrInvalidAddress))
+			}
+		}
+	}
+
+	checkUpstreams("networking.os.dnsCache", c.Upstreams)
+
+	for idx, forward := range c.Forwards {
+		prefix := "networking.os.dnsCache.forwards[" + strconv.Itoa(idx) + "]"
+
+		if forward.Domain == "" {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".domain", "", ErrRequiredSection))
+		}
+
+		if len(forward.Upstreams) == 0 {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".upstreams", "", ErrRequiredSection))
+		}
+
+		checkUpstreams(prefix, forward.Upstreams)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validUpstreamAddress checks that the address is an IP address, with an
+// optional port.
+func validUpstreamAddress(s string) bool {
+	if net.ParseIP(s) != nil {
+		return true
+	}
+
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return false
+	}
+
+	_, err = strconv.ParseUint(port, 10, 16)
+
+	return err == nil && net.ParseIP(host) != nil
+}
+
+// ValidateImagePolicies ensures that the policies have an image, and either
+// digests or keys, and that the digests and the keys can be parsed.
+func ValidateImagePolicies(policies []machine.ImagePolicy) error {
+	var result *multierror.Error
+
+	for idx, policy := range policies {
+		prefix := "machine.imagePolicies[" + strconv.Itoa(idx) + "]"
+
+		if policy.Image == "" {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".image", "", ErrRequiredSection))
+		}
+
+		if len(policy.Digests) == 0 && len(policy.Keys) == 0 {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".digests", "", ErrRequiredSection))
+		}
+
+		for _, d := range policy.Digests {
+			if _, err := digest.Parse(d); err != nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".digests", d, ErrInvalidDigest))
+			}
+		}
+
+		for _, key := range policy.Keys {
+			if !validPublicKey(key) {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".keys", "", ErrInvalidPublicKey))
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validPublicKey checks that the key is a PEM encoded ECDSA or Ed25519
+// public key.
+func validPublicKey(key string) bool {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return false
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	switch pub.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateLogDestinations ensures that the endpoints of the log destinations
+// are valid URLs with a supported scheme, that the formats are supported,
+// and that the CA and the client certificate can be parsed.
+func ValidateLogDestinations(destinations []machine.LogDestination) error {
+	var result *multierror.Error
+
+	for idx, destination := range destinations {
+		prefix := "machine.logging.destinations[" + strconv.Itoa(idx) + "]"
+
+		u, err := url.Parse(destination.Endpoint)
+		if err != nil || (u.Scheme != "udp" && u.Scheme != "tcp" && u.Scheme != "tls") || u.Hostname() == "" {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".endpoint", destination.Endpoint, ErrInvalidLogEndpoint))
+
+			continue
+		}
+
+		switch destination.Format {
+		case "", machine.LogFormatSyslog, machine.LogFormatJSONLines:
+		default:
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".format", destination.Format, ErrUnsupportedLogFormat))
+		}
+
+		if destination.CA != nil {
+			if u.Scheme != "tls" {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".ca", destination.Endpoint, ErrLogTLSRequired))
+			}
+
+			if !x509.NewCertPool().AppendCertsFromPEM(destination.CA.Crt) {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".ca", "", ErrInvalidCert))
+			}
+		}
+
+		if destination.Certificate != nil {
+			if u.Scheme != "tls" {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".certificate", destination.Endpoint, ErrLogTLSRequired))
+			}
+
+			if _, err = tls.X509KeyPair(destination.Certificate.Crt, destination.Certificate.Key); err != nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", prefix+".certificate", "", ErrInvalidCert))
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
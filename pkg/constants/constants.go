@@ -203,12 +203,47 @@ const (
 	// TimeSocketPath is the path to file socket of time API.
 	TimeSocketPath = SystemRunPath + "/ntpd/ntpd.sock"
 
+	// TimeSyncedPath is the path to the file ntpd creates once it has
+	// synchronized the time since boot.
+	TimeSyncedPath = SystemRunPath + "/ntpd/synced"
+
+	// LastKnownTimePath is the path to the file whose modification time is the
+	// last time ntpd synchronized, the clock is never set before it on boot.
+	LastKnownTimePath = SystemVarPath + "/time/last-known"
+
+	// StagedUpgradePath is the path to the upgrade which is applied on the
+	// next reboot.
+	StagedUpgradePath = SystemVarPath + "/upgrade/staged"
+
+	// RTCPath is the path to the hardware clock, ntpd syncs it after a
+	// successful synchronization.
+	RTCPath = "/dev/rtc0"
+
+	// DefaultTimeSyncTimeout is the default time the services which need a
+	// synchronized time wait for ntpd, before they start with the time the
+	// clock was restored to on boot.
+	DefaultTimeSyncTimeout = 2 * time.Minute
+
 	// NetworkSocketPath is the path to file socket of network API.
 	NetworkSocketPath = SystemRunPath + "/networkd/networkd.sock"
 
+	// NetworkApplyStatePath is the path to the state of the network changes
+	// applied by networkd. It is kept in memory, the changes are lost on
+	// reboot.
+	NetworkApplyStatePath = SystemRunPath + "/networkd/apply.yaml"
+
 	// OSSocketPath is the path to file socket of os API.
 	OSSocketPath = SystemRunPath + "/osd/osd.sock"
 
+	// DNSCacheAddress is the address the local caching DNS forwarder listens
+	// on.
+	DNSCacheAddress = "127.0.0.53"
+
+	// DNSUpstreamResolvConfPath is the path to the resolv.conf with the
+	// resolvers of the network, which networkd writes out instead of
+	// /etc/resolv.conf when the DNS cache is enabled.
+	DNSUpstreamResolvConfPath = SystemRunPath + "/networkd/resolv.conf"
+
 	// KernelAsset defines a well known name for our kernel filename
 	KernelAsset = "vmlinuz"
 
@@ -242,6 +277,14 @@ const (
 	// DefaultLogPath is the default path to the log storage directory.
 	DefaultLogPath = SystemRunPath + "/log"
 
+	// DefaultLogMaxSize is the default size in bytes a service log grows to
+	// before it is rotated.
+	DefaultLogMaxSize = 10 * 1024 * 1024
+
+	// DefaultLogMaxFiles is the default number of rotated service log files
+	// kept in addition to the active one.
+	DefaultLogMaxFiles = 3
+
 	// DefaultCNI is the default CNI.
 	DefaultCNI = "flannel"
 
@@ -7,27 +7,66 @@ package net
 import (
 	"errors"
 	"net"
+	"strings"
+
+	"github.com/jsimonetti/rtnetlink"
+	"golang.org/x/sys/unix"
 )
 
+// vipLabelSuffix ends the label of the addresses of the virtual IPs.
+const vipLabelSuffix = ":vip"
+
 // IPAddrs finds and returns a list of non-loopback IPv4 addresses of the
-// current machine.
+// current machine. The virtual IPs, which move between the machines, are
+// skipped.
 func IPAddrs() (ips []net.IP, err error) {
-	ips = []net.IP{}
+	conn, err := rtnetlink.Dial(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
 
-	addrs, err := net.InterfaceAddrs()
+	msgs, err := conn.Address.List()
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	for _, a := range addrs {
-		if ipnet, ok := a.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
-			if ipnet.IP.To4() != nil {
-				ips = append(ips, ipnet.IP)
-			}
+	return ipAddrs(msgs), nil
+}
+
+func ipAddrs(msgs []rtnetlink.AddressMessage) []net.IP {
+	ips := []net.IP{}
+
+	for _, msg := range msgs {
+		if msg.Family != unix.AF_INET || strings.HasSuffix(msg.Attributes.Label, vipLabelSuffix) {
+			continue
 		}
+
+		// the address of point to point interfaces is the address of the peer
+		ip := msg.Attributes.Local
+		if ip == nil {
+			ip = msg.Attributes.Address
+		}
+
+		if ip = ip.To16(); ip != nil && !ip.IsLoopback() {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips
+}
+
+// VIPLabel returns the label of the addresses of the virtual IPs of the
+// interface, which IPAddrs skips.
+func VIPLabel(iface string) string {
+	// labels are limited to IFNAMSIZ-1 characters
+	if max := unix.IFNAMSIZ - 1 - len(vipLabelSuffix); len(iface) > max {
+		iface = iface[:max]
 	}
 
-	return ips, nil
+	return iface + vipLabelSuffix
 }
 
 // FormatAddress checks that the address has a consistent format.
@@ -9,14 +9,27 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/jsimonetti/rtnetlink"
+	"golang.org/x/sys/unix"
 	"gotest.tools/assert"
 )
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+func TestIPAddrs(t *testing.T) {
+	msgs := []rtnetlink.AddressMessage{
+		{Family: unix.AF_INET, Attributes: rtnetlink.AddressAttributes{Local: net.IP{127, 0, 0, 1}, Label: "lo"}},
+		{Family: unix.AF_INET, Attributes: rtnetlink.AddressAttributes{Local: net.IP{192, 168, 0, 10}, Label: "eth0"}},
+		{Family: unix.AF_INET, Attributes: rtnetlink.AddressAttributes{Local: net.IP{192, 168, 0, 100}, Label: VIPLabel("eth0")}},
+		{Family: unix.AF_INET6, Attributes: rtnetlink.AddressAttributes{Address: net.ParseIP("2001:db8::1")}},
+		{Family: unix.AF_INET, Attributes: rtnetlink.AddressAttributes{Address: net.IP{10, 0, 0, 1}, Label: "wg0"}},
+	}
+
+	assert.DeepEqual(t, ipAddrs(msgs), []net.IP{net.ParseIP("192.168.0.10"), net.ParseIP("10.0.0.1")})
+}
+
+func TestVIPLabel(t *testing.T) {
+	assert.Equal(t, VIPLabel("eth0"), "eth0:vip")
+	assert.Equal(t, VIPLabel("enp0s31f6.100"), "enp0s31f6.1:vip")
+	assert.Equal(t, len(VIPLabel("enp0s31f6.100")), unix.IFNAMSIZ-1)
 }
 
 func TestFormatAddress(t *testing.T) {
@@ -87,7 +100,7 @@ func TestNthIPInNetwork(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := NthIPInNetwork(tt.args.network, tt.args.n)
 			if err != nil {
-				t.Errorf("%w", err)
+				t.Errorf("%v", err)
 			}
 
 			if !reflect.DeepEqual(got, tt.want) {